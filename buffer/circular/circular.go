@@ -1,11 +1,22 @@
 package circular
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/dterbah/gods/collection"
+	"github.com/dterbah/gods/internal/format"
+	"github.com/dterbah/gods/internal/serialization"
+	comparator "github.com/dterbah/gods/utils"
 )
 
 /*
-Struct used to defines what is a CircularBuffer
+Struct used to defines what is a CircularBuffer. The zero value is an empty buffer
+without capacity: Enqueue returns an error until the buffer is resized, so create
+the buffers with New
 */
 type CircularBuffer[T any] struct {
 	size         int
@@ -13,40 +24,180 @@ type CircularBuffer[T any] struct {
 	writePointer int
 	elements     []T
 	full         bool
+	overwrite    bool
 	zeroElement  T
+	comparator   comparator.Comparator[T]
+}
+
+/*
+Payload of the binary format of a CircularBuffer. Elements contains the elements
+from the oldest to the newest, with the capacity and the overwrite mode of the buffer
+*/
+type circularBuffer[T any] struct {
+	Elements  []T
+	Size      int
+	Overwrite bool
+}
+
+/*
+Function used to configure a CircularBuffer at its creation
+*/
+type Option[T any] func(buffer *CircularBuffer[T])
+
+/*
+When the buffer is full, Enqueue replaces the oldest element instead of
+returning an error. Useful for rolling windows
+*/
+func WithOverwrite[T any]() Option[T] {
+	return func(buffer *CircularBuffer[T]) {
+		buffer.overwrite = true
+	}
 }
 
 /*
-Create a new CircularBuffer with a specified size
+Set the comparator used by Contains, ContainsAll, IndexOf and Remove. Without this
+option, the comparator registered for the type of the elements is used, if any
 */
-func New[T any](size int) *CircularBuffer[T] {
-	if size < 0 {
+func WithComparator[T any](comparator comparator.Comparator[T]) Option[T] {
+	return func(buffer *CircularBuffer[T]) {
+		buffer.comparator = comparator
+	}
+}
+
+/*
+Create a new CircularBuffer with a specified size. If the size is
+not strictly positive, this function returns nil
+*/
+func New[T any](size int, options ...Option[T]) *CircularBuffer[T] {
+	if size <= 0 {
 		return nil
 	}
 
 	var zero T
 
-	return &CircularBuffer[T]{
+	buffer := &CircularBuffer[T]{
 		size:        size,
 		elements:    make([]T, size),
 		full:        false,
 		zeroElement: zero,
 	}
+
+	for _, option := range options {
+		option(buffer)
+	}
+
+	if buffer.comparator == nil {
+		// The comparator is optional, so a missing default comparator is not an error
+		buffer.comparator, _ = comparator.Default[T]()
+	}
+
+	return buffer
+}
+
+/*
+Enqueue elements inside the buffer. When the buffer is full, the elements
+are dropped, unless the buffer is in overwrite mode
+*/
+func (buffer *CircularBuffer[T]) Add(elements ...T) {
+	for _, element := range elements {
+		buffer.Enqueue(element)
+	}
+}
+
+/*
+Enqueue all the elements of the collection. When the buffer is full, the elements
+are dropped, unless the buffer is in overwrite mode
+*/
+func (buffer *CircularBuffer[T]) AddAll(elements collection.Collection[T]) {
+	for i := 0; i < elements.Size(); i++ {
+		element, _ := elements.At(i)
+		buffer.Enqueue(element)
+	}
+}
+
+/*
+Return an iterator over the elements of the buffer, from the oldest to the newest,
+with their logical index. The iteration stops as soon as the loop body breaks
+*/
+func (buffer *CircularBuffer[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for index := 0; index < buffer.Len(); index++ {
+			if !yield(index, buffer.elements[buffer.physicalIndex(index)]) {
+				return
+			}
+		}
+	}
+}
+
+/*
+Retrieve an element by its index, relative to the oldest element of the buffer.
+If the index is negative or greater than the buffer length, the method will return an error
+*/
+func (buffer *CircularBuffer[T]) At(index int) (T, error) {
+	if index < 0 || index >= buffer.Len() {
+		return buffer.zeroElement, errors.New("index out of bound")
+	}
+
+	return buffer.elements[buffer.physicalIndex(index)], nil
+}
+
+/*
+Return the maximum number of elements the buffer can store
+*/
+func (buffer *CircularBuffer[T]) Cap() int {
+	return buffer.size
+}
+
+/*
+Remove all the elements of the buffer
+*/
+func (buffer *CircularBuffer[T]) Clear() {
+	buffer.elements = make([]T, buffer.size)
+	buffer.readPointer = 0
+	buffer.writePointer = 0
+	buffer.full = false
+}
+
+/*
+Return true if the buffer contains at least one occurence of the element, else false
+*/
+func (buffer *CircularBuffer[T]) Contains(element T) bool {
+	return buffer.IndexOf(element) != -1
+}
+
+func (buffer *CircularBuffer[T]) ContainsAll(collection collection.Collection[T]) bool {
+	for index := 0; index < collection.Size(); index++ {
+		value, _ := collection.At(index)
+		if !buffer.Contains(value) {
+			return false
+		}
+	}
+
+	return true
 }
 
 /*
 Enqueue new element inside the buffer. If the buffer is already full,
-this method will return an error
+this method will return an error, unless the buffer is in overwrite mode:
+in that case, the oldest element is replaced. A buffer without capacity
+always returns an error
 */
 func (buffer *CircularBuffer[T]) Enqueue(element T) error {
-	if buffer.full {
+	if buffer.size == 0 {
+		return errors.New("circular buffer without capacity, create it with New")
+	}
+
+	if buffer.full && !buffer.overwrite {
 		return errors.New("circular buffer full")
 	}
 
 	buffer.elements[buffer.writePointer] = element
 	buffer.writePointer = (buffer.writePointer + 1) % buffer.size
 
-	if buffer.writePointer == buffer.readPointer {
+	if buffer.full {
+		// The oldest element has been overwritten
+		buffer.readPointer = buffer.writePointer
+	} else if buffer.writePointer == buffer.readPointer {
 		buffer.full = true
 	}
 
@@ -58,17 +209,69 @@ Dequeue an element. It returns the element and nil if the buffer is not empty.
 Otherwise, it returns a zero element and an error
 */
 func (buffer *CircularBuffer[T]) Dequeue() (T, error) {
-	if !buffer.full && buffer.readPointer == buffer.writePointer {
+	if buffer.IsEmpty() {
 		return buffer.zeroElement, errors.New("buffer empty")
 	}
 
 	element := buffer.elements[buffer.readPointer]
+	buffer.elements[buffer.readPointer] = buffer.zeroElement
 	buffer.readPointer = (buffer.readPointer + 1) % buffer.size
 	buffer.full = false
 
 	return element, nil
 }
 
+/*
+Apply a function for each element of the buffer, from the oldest to the newest
+*/
+func (buffer *CircularBuffer[T]) ForEach(callback func(element T, index int)) {
+	for index, element := range buffer.All() {
+		callback(element, index)
+	}
+}
+
+/*
+Format the buffer for the fmt package: %v writes its elements, %+v also writes
+its size and its capacity, and %#v writes a Go-syntax representation.
+The other verbs are applied to each element
+*/
+func (buffer *CircularBuffer[T]) Format(state fmt.State, verb rune) {
+	format.Format(state, verb, buffer, buffer.describe())
+}
+
+/*
+Decode the buffer from the gob encoding, see UnmarshalBinary
+*/
+func (buffer *CircularBuffer[T]) GobDecode(data []byte) error {
+	return buffer.UnmarshalBinary(data)
+}
+
+/*
+Encode the buffer for gob, see MarshalBinary
+*/
+func (buffer *CircularBuffer[T]) GobEncode() ([]byte, error) {
+	return buffer.MarshalBinary()
+}
+
+/*
+Return the index of the element relative to the oldest element of the buffer.
+If the element is not present in the buffer, or if the buffer has no comparator,
+the method will return -1
+*/
+func (buffer *CircularBuffer[T]) IndexOf(element T) int {
+	if buffer.comparator == nil {
+		return -1
+	}
+
+	for index, value := range buffer.All() {
+		if buffer.comparator(value, element) == 0 {
+			return index
+		}
+	}
+
+	return -1
+}
+
 /*
 Return true if the buffer is empty, else false
 */
@@ -82,3 +285,240 @@ Return true if the buffer is full, else false
 func (buffer CircularBuffer[T]) IsFull() bool {
 	return buffer.full
 }
+
+/*
+Return true if the buffer replaces its oldest element when it is full, else false
+*/
+func (buffer CircularBuffer[T]) IsOverwrite() bool {
+	return buffer.overwrite
+}
+
+/*
+Return the number of elements currently stored in the buffer
+*/
+func (buffer *CircularBuffer[T]) Len() int {
+	if buffer.full || buffer.size == 0 {
+		return buffer.size
+	}
+
+	return (buffer.writePointer - buffer.readPointer + buffer.size) % buffer.size
+}
+
+/*
+Encode the buffer in a binary format made of a versioned header
+followed by the gob encoding of its elements, its capacity and its overwrite mode
+*/
+func (buffer *CircularBuffer[T]) MarshalBinary() ([]byte, error) {
+	return serialization.Marshal(&circularBuffer[T]{
+		Elements:  buffer.ToArray(),
+		Size:      buffer.size,
+		Overwrite: buffer.overwrite,
+	})
+}
+
+/*
+Encode the buffer as a JSON array of its elements, from the oldest to the newest
+*/
+func (buffer *CircularBuffer[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(buffer.ToArray())
+}
+
+/*
+Return the oldest element of the buffer without removing it.
+If the buffer is empty, it returns an error
+*/
+func (buffer *CircularBuffer[T]) Peek() (T, error) {
+	if buffer.IsEmpty() {
+		return buffer.zeroElement, errors.New("buffer empty")
+	}
+
+	return buffer.elements[buffer.readPointer], nil
+}
+
+/*
+Return the newest element of the buffer without removing it.
+If the buffer is empty, it returns an error
+*/
+func (buffer *CircularBuffer[T]) PeekLast() (T, error) {
+	if buffer.IsEmpty() {
+		return buffer.zeroElement, errors.New("buffer empty")
+	}
+
+	return buffer.elements[buffer.physicalIndex(buffer.Len()-1)], nil
+}
+
+func (buffer *CircularBuffer[T]) Print() {
+	fmt.Println(buffer.String())
+}
+
+/*
+Remove the first occurence of the element in the buffer.
+The newer elements are shifted to keep the order
+*/
+func (buffer *CircularBuffer[T]) Remove(element T) {
+	index := buffer.IndexOf(element)
+	if index == -1 {
+		return
+	}
+
+	length := buffer.Len()
+	for i := index; i < length-1; i++ {
+		buffer.elements[buffer.physicalIndex(i)] = buffer.elements[buffer.physicalIndex(i+1)]
+	}
+
+	buffer.writePointer = (buffer.writePointer - 1 + buffer.size) % buffer.size
+	buffer.elements[buffer.writePointer] = buffer.zeroElement
+	buffer.full = false
+}
+
+/*
+Change the capacity of the buffer, keeping its elements in the same order.
+If the new size is not strictly positive or is lower than the number of elements
+in the buffer, this method will return an error
+*/
+func (buffer *CircularBuffer[T]) Resize(newSize int) error {
+	if newSize <= 0 {
+		return errors.New("invalid buffer size")
+	}
+
+	length := buffer.Len()
+	if newSize < length {
+		return errors.New("buffer contains more elements than the new size")
+	}
+
+	newElements := make([]T, newSize)
+	for index, element := range buffer.All() {
+		newElements[index] = element
+	}
+
+	buffer.elements = newElements
+	buffer.size = newSize
+	buffer.readPointer = 0
+	buffer.writePointer = length % newSize
+	buffer.full = length == newSize
+
+	return nil
+}
+
+/*
+Return the number of elements currently stored in the buffer
+*/
+func (buffer *CircularBuffer[T]) Size() int {
+	return buffer.Len()
+}
+
+/*
+Return the elements of the buffer as a string, e.g. [1, 2, 3] from the oldest to the newest
+*/
+func (buffer *CircularBuffer[T]) String() string {
+	return format.String(buffer.describe())
+}
+
+/*
+Return the elements of the buffer, from the oldest to the newest
+*/
+func (buffer *CircularBuffer[T]) ToArray() []T {
+	elements := make([]T, 0, buffer.Len())
+	for _, element := range buffer.All() {
+		elements = append(elements, element)
+	}
+
+	return elements
+}
+
+/*
+Decode the binary format written by MarshalBinary into the buffer, replacing its content.
+The buffer gets the capacity and the overwrite mode of the encoded one. Its comparator is
+kept, or the one registered for the type of the elements is used if any, as the buffer
+can work without comparator
+*/
+func (buffer *CircularBuffer[T]) UnmarshalBinary(data []byte) error {
+	var payload circularBuffer[T]
+	if err := serialization.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	if payload.Size <= 0 {
+		return errors.New("invalid buffer size")
+	}
+
+	buffer.size = payload.Size
+	buffer.overwrite = payload.Overwrite
+	return buffer.load(payload.Elements)
+}
+
+/*
+Decode a JSON array of elements, from the oldest to the newest, into the buffer,
+replacing its content. The size and the options of the buffer are kept. If the buffer
+has not been created with New, its size is the number of elements. If there are more
+elements than the size, this method will return an error, unless the buffer is in
+overwrite mode: in that case, only the newest elements are kept
+*/
+func (buffer *CircularBuffer[T]) UnmarshalJSON(data []byte) error {
+	var elements []T
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	return buffer.load(elements)
+}
+
+/*
+Return an iterator over the elements of the buffer, from the oldest to the newest.
+The iteration stops as soon as the loop body breaks
+*/
+func (buffer *CircularBuffer[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, element := range buffer.All() {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+/*
+Write the elements of the buffer, as returned by String, to the writer
+*/
+func (buffer *CircularBuffer[T]) WriteTo(writer io.Writer) (int64, error) {
+	return format.WriteTo(writer, buffer.describe())
+}
+
+// Private methods
+
+// Describe the buffer for the format package
+func (buffer *CircularBuffer[T]) describe() format.Container[int, T] {
+	return format.Container[int, T]{
+		Name:     "CircularBuffer",
+		Open:     "[",
+		Close:    "]",
+		All:      buffer.All(),
+		Size:     buffer.Len(),
+		Capacity: buffer.size,
+	}
+}
+
+// Replace the content of the buffer by the elements, resolving the comparator if needed
+func (buffer *CircularBuffer[T]) load(elements []T) error {
+	if buffer.size == 0 {
+		buffer.size = max(len(elements), 1)
+	}
+
+	if len(elements) > buffer.size && !buffer.overwrite {
+		return errors.New("buffer contains more elements than its size")
+	}
+
+	if buffer.comparator == nil {
+		// The comparator is optional, so a missing default comparator is not an error
+		buffer.comparator, _ = comparator.Default[T]()
+	}
+
+	buffer.Clear()
+	buffer.Add(elements...)
+	return nil
+}
+
+// Convert a logical index (relative to the oldest element) into an index of the storage
+func (buffer *CircularBuffer[T]) physicalIndex(index int) int {
+	return (buffer.readPointer + index) % buffer.size
+}
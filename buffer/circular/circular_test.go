@@ -1,19 +1,103 @@
 package circular
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/dterbah/gods/collection"
+	"github.com/dterbah/gods/iterable"
+	"github.com/dterbah/gods/list/arraylist"
+	comparator "github.com/dterbah/gods/utils"
 	"github.com/stretchr/testify/assert"
 )
 
+func TestCircularBufferBinary(t *testing.T) {
+	assert := assert.New(t)
+	original := New[int](3, WithOverwrite[int]())
+	original.Add(1, 2, 3, 4)
+
+	data, err := original.MarshalBinary()
+	assert.Nil(err)
+
+	var decoded CircularBuffer[int]
+	assert.Nil(decoded.UnmarshalBinary(data))
+	assert.Equal([]int{2, 3, 4}, decoded.ToArray())
+
+	// Containers can be embedded in gob encoded structs
+	var buffer bytes.Buffer
+	assert.Nil(gob.NewEncoder(&buffer).Encode(struct{ Container *CircularBuffer[int] }{original}))
+
+	var checkpoint struct{ Container *CircularBuffer[int] }
+	assert.Nil(gob.NewDecoder(&buffer).Decode(&checkpoint))
+	decoded = *checkpoint.Container
+	assert.Equal([]int{2, 3, 4}, decoded.ToArray())
+
+	assert.NotNil(decoded.UnmarshalBinary([]byte("invalid")))
+}
+
+func TestCircularBufferBinaryOptions(t *testing.T) {
+	assert := assert.New(t)
+	original := New(10, WithOverwrite[int]())
+	original.Add(1, 2)
+
+	var buffer bytes.Buffer
+	assert.Nil(gob.NewEncoder(&buffer).Encode(struct{ Container *CircularBuffer[int] }{original}))
+
+	var checkpoint struct{ Container *CircularBuffer[int] }
+	assert.Nil(gob.NewDecoder(&buffer).Decode(&checkpoint))
+	decoded := checkpoint.Container
+	assert.Equal(10, decoded.Cap())
+	assert.True(decoded.IsOverwrite())
+	assert.Equal([]int{1, 2}, decoded.ToArray())
+
+	for element := 3; element <= 12; element++ {
+		assert.Nil(decoded.Enqueue(element))
+	}
+	assert.Equal([]int{3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, decoded.ToArray())
+	assert.True(decoded.Contains(12))
+}
+
+func TestCircularBufferJSON(t *testing.T) {
+	assert := assert.New(t)
+	buffer := New[int](3, WithOverwrite[int]())
+	buffer.Add(1, 2, 3, 4)
+
+	data, err := json.Marshal(buffer)
+	assert.Nil(err)
+	assert.Equal("[2,3,4]", string(data))
+
+	// The size is the number of elements when the buffer is not created with New
+	var decoded CircularBuffer[int]
+	assert.Nil(json.Unmarshal(data, &decoded))
+	assert.Equal([]int{2, 3, 4}, decoded.ToArray())
+	assert.Equal(3, decoded.Cap())
+	assert.True(decoded.Contains(3))
+
+	// The size and the options of an existing buffer are kept
+	window := New[int](2, WithOverwrite[int]())
+	assert.Nil(json.Unmarshal(data, window))
+	assert.Equal([]int{3, 4}, window.ToArray())
+
+	small := New[int](2)
+	assert.NotNil(json.Unmarshal(data, small))
+}
+
 func TestCircularBufferNew(t *testing.T) {
 	assert := assert.New(t)
 	buffer := New[int](-1)
 
 	assert.Nil(buffer)
 
+	buffer = New[int](0)
+	assert.Nil(buffer)
+
 	buffer = New[int](10)
 	assert.NotNil(buffer)
+	assert.False(buffer.IsOverwrite())
 }
 
 func TestCircularBufferEnqueue(t *testing.T) {
@@ -62,3 +146,298 @@ func TestCircularBufferIsFull(t *testing.T) {
 	buffer.Enqueue(1)
 	assert.True(buffer.IsFull())
 }
+
+func TestCircularBufferAll(t *testing.T) {
+	assert := assert.New(t)
+	buffer := New[int](4)
+	for i := 1; i <= 4; i++ {
+		buffer.Enqueue(i)
+	}
+
+	indexes := []int{}
+	values := []int{}
+	for index, value := range buffer.All() {
+		if index == 2 {
+			break
+		}
+		indexes = append(indexes, index)
+		values = append(values, value)
+	}
+
+	assert.Equal([]int{0, 1}, indexes)
+	assert.Equal([]int{1, 2}, values)
+}
+
+func TestCircularBufferString(t *testing.T) {
+	assert := assert.New(t)
+	buffer := New[int](3, WithOverwrite[int]())
+	buffer.Add(1, 2, 3, 4)
+
+	assert.Equal("[2, 3, 4]", buffer.String())
+	assert.Equal("[2, 3, 4]", fmt.Sprint(buffer))
+	assert.Equal("CircularBuffer(size=3, capacity=3) [2, 3, 4]", fmt.Sprintf("%+v", buffer))
+	assert.Equal(`&circular.CircularBuffer[int]{2, 3, 4}`, fmt.Sprintf("%#v", buffer))
+
+	var builder strings.Builder
+	count, err := buffer.WriteTo(&builder)
+	assert.Nil(err)
+	assert.Equal(int64(len("[2, 3, 4]")), count)
+	assert.Equal("[2, 3, 4]", builder.String())
+}
+
+func TestCircularBufferValues(t *testing.T) {
+	assert := assert.New(t)
+	buffer := New[int](4)
+	for i := 1; i <= 4; i++ {
+		buffer.Enqueue(i)
+	}
+
+	values := []int{}
+	for value := range buffer.Values() {
+		values = append(values, value)
+	}
+	assert.Equal([]int{1, 2, 3, 4}, values)
+
+	values = []int{}
+	for value := range buffer.Values() {
+		if value == 3 {
+			break
+		}
+		values = append(values, value)
+	}
+	assert.Equal([]int{1, 2}, values)
+}
+
+func TestCircularBufferValuesWrapped(t *testing.T) {
+	assert := assert.New(t)
+	buffer := New[int](3)
+	buffer.Enqueue(1)
+	buffer.Enqueue(2)
+	buffer.Dequeue()
+	buffer.Enqueue(3)
+	buffer.Enqueue(4)
+
+	values := []int{}
+	for value := range buffer.Values() {
+		values = append(values, value)
+	}
+
+	assert.Equal([]int{2, 3, 4}, values)
+}
+
+func TestCircularBufferAdd(t *testing.T) {
+	assert := assert.New(t)
+	buffer := New[int](3)
+
+	buffer.Add(1, 2, 3, 4)
+	assert.Equal([]int{1, 2, 3}, buffer.ToArray())
+
+	buffer = New(3, WithOverwrite[int]())
+	buffer.AddAll(arraylist.New(comparator.IntComparator, 1, 2, 3, 4))
+	assert.Equal([]int{2, 3, 4}, buffer.ToArray())
+}
+
+func TestCircularBufferAt(t *testing.T) {
+	assert := assert.New(t)
+	buffer := New(3, WithOverwrite[int]())
+	buffer.Add(1, 2, 3, 4, 5)
+
+	value, err := buffer.At(0)
+	assert.Nil(err)
+	assert.Equal(3, value)
+
+	value, err = buffer.At(2)
+	assert.Nil(err)
+	assert.Equal(5, value)
+
+	_, err = buffer.At(3)
+	assert.NotNil(err)
+	_, err = buffer.At(-1)
+	assert.NotNil(err)
+}
+
+func TestCircularBufferClear(t *testing.T) {
+	assert := assert.New(t)
+	buffer := New[int](2)
+	buffer.Add(1, 2)
+	buffer.Clear()
+
+	assert.True(buffer.IsEmpty())
+	assert.Equal(0, buffer.Len())
+	assert.Equal(2, buffer.Cap())
+}
+
+func TestCircularBufferContains(t *testing.T) {
+	assert := assert.New(t)
+	buffer := New(3, WithComparator(comparator.IntComparator))
+	buffer.Add(1, 2, 3)
+
+	assert.True(buffer.Contains(2))
+	assert.False(buffer.Contains(4))
+	assert.Equal(2, buffer.IndexOf(3))
+	assert.Equal(-1, buffer.IndexOf(4))
+	assert.True(buffer.ContainsAll(arraylist.New(comparator.IntComparator, 1, 3)))
+	assert.False(buffer.ContainsAll(arraylist.New(comparator.IntComparator, 1, 4)))
+}
+
+func TestCircularBufferContainsDefaultComparator(t *testing.T) {
+	assert := assert.New(t)
+	buffer := New[int](3)
+	buffer.Add(1, 2, 3)
+
+	assert.True(buffer.Contains(2))
+	assert.Equal(1, buffer.IndexOf(2))
+	assert.True(buffer.ContainsAll(arraylist.New(comparator.IntComparator, 1, 3)))
+	buffer.Remove(2)
+	assert.Equal([]int{1, 3}, buffer.ToArray())
+
+	// A type with no registered comparator never matches
+	type point struct{ x, y int }
+	points := New[point](2)
+	points.Add(point{1, 2})
+	assert.False(points.Contains(point{1, 2}))
+	assert.Equal(-1, points.IndexOf(point{1, 2}))
+	points.Remove(point{1, 2})
+	assert.Equal(1, points.Len())
+}
+
+func TestCircularBufferForEach(t *testing.T) {
+	assert := assert.New(t)
+	buffer := New(3, WithOverwrite[int]())
+	buffer.Add(1, 2, 3, 4)
+
+	expectedValues := []int{2, 3, 4}
+	buffer.ForEach(func(element, index int) {
+		assert.Equal(expectedValues[index], element)
+	})
+}
+
+func TestCircularBufferImplementsInterfaces(t *testing.T) {
+	var collection collection.Collection[int] = New(1, WithComparator(comparator.IntComparator))
+	var iterable iterable.Iterable[int] = New(1, WithComparator(comparator.IntComparator))
+
+	collection.Add(1)
+	assert.Equal(t, 1, collection.Size())
+	assert.Equal(t, -1, iterable.IndexOf(1))
+}
+
+func TestCircularBufferLenCap(t *testing.T) {
+	assert := assert.New(t)
+	buffer := New[int](3)
+
+	assert.Equal(0, buffer.Len())
+	assert.Equal(3, buffer.Cap())
+
+	buffer.Add(1, 2)
+	assert.Equal(2, buffer.Len())
+	assert.Equal(2, buffer.Size())
+}
+
+func TestCircularBufferOverwrite(t *testing.T) {
+	assert := assert.New(t)
+	buffer := New(3, WithOverwrite[int]())
+	assert.True(buffer.IsOverwrite())
+
+	for i := 1; i <= 5; i++ {
+		assert.Nil(buffer.Enqueue(i))
+	}
+
+	assert.True(buffer.IsFull())
+	assert.Equal(3, buffer.Len())
+
+	for _, expected := range []int{3, 4, 5} {
+		value, err := buffer.Dequeue()
+		assert.Nil(err)
+		assert.Equal(expected, value)
+	}
+
+	assert.True(buffer.IsEmpty())
+}
+
+func TestCircularBufferPeek(t *testing.T) {
+	assert := assert.New(t)
+	buffer := New(3, WithOverwrite[int]())
+
+	_, err := buffer.Peek()
+	assert.NotNil(err)
+	_, err = buffer.PeekLast()
+	assert.NotNil(err)
+
+	buffer.Add(1, 2, 3, 4)
+
+	value, err := buffer.Peek()
+	assert.Nil(err)
+	assert.Equal(2, value)
+
+	value, err = buffer.PeekLast()
+	assert.Nil(err)
+	assert.Equal(4, value)
+	assert.Equal(3, buffer.Len())
+}
+
+func TestCircularBufferPrint(t *testing.T) {
+	buffer := New[int](3)
+	buffer.Add(1, 2)
+	buffer.Print()
+}
+
+func TestCircularBufferRemove(t *testing.T) {
+	assert := assert.New(t)
+	buffer := New(4, WithOverwrite[int](), WithComparator(comparator.IntComparator))
+	buffer.Add(0, 1, 2, 3, 4, 5)
+
+	buffer.Remove(3)
+	assert.Equal([]int{2, 4, 5}, buffer.ToArray())
+	assert.False(buffer.IsFull())
+
+	buffer.Remove(10)
+	assert.Equal(3, buffer.Len())
+
+	buffer.Add(6, 7)
+	assert.Equal([]int{4, 5, 6, 7}, buffer.ToArray())
+}
+
+func TestCircularBufferResize(t *testing.T) {
+	assert := assert.New(t)
+	buffer := New(3, WithOverwrite[int]())
+	buffer.Add(1, 2, 3, 4)
+
+	assert.NotNil(buffer.Resize(0))
+	assert.NotNil(buffer.Resize(2))
+
+	assert.Nil(buffer.Resize(5))
+	assert.Equal(5, buffer.Cap())
+	assert.Equal([]int{2, 3, 4}, buffer.ToArray())
+	assert.False(buffer.IsFull())
+
+	buffer.Add(5, 6)
+	assert.True(buffer.IsFull())
+	assert.Equal([]int{2, 3, 4, 5, 6}, buffer.ToArray())
+
+	assert.Nil(buffer.Resize(5))
+	assert.True(buffer.IsFull())
+	buffer.Add(7)
+	assert.Equal([]int{3, 4, 5, 6, 7}, buffer.ToArray())
+}
+
+func TestCircularBufferZeroValue(t *testing.T) {
+	assert := assert.New(t)
+	var buffer CircularBuffer[int]
+
+	assert.Equal(0, buffer.Len())
+	assert.True(buffer.IsEmpty())
+	assert.Equal("[]", buffer.String())
+	assert.Equal(-1, buffer.IndexOf(1))
+	assert.NotNil(buffer.Enqueue(1))
+	buffer.Add(1)
+	assert.Equal(0, buffer.Size())
+	_, err := buffer.Dequeue()
+	assert.NotNil(err)
+	_, err = buffer.PeekLast()
+	assert.NotNil(err)
+
+	// A resized buffer gets a capacity
+	assert.Nil(buffer.Resize(2))
+	assert.Nil(buffer.Enqueue(1))
+	assert.Equal([]int{1}, buffer.ToArray())
+}
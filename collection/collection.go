@@ -43,7 +43,8 @@ type Collection[T any] interface {
 	IsEmpty() bool
 
 	/*
-		Remove specified element if it exists
+		Remove specified element if it exists. A container accepting
+		duplicates only removes its first occurrence
 	*/
 	Remove(element T)
 
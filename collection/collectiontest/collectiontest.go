@@ -0,0 +1,655 @@
+/*
+Package collectiontest implements conformance tests for the implementations of
+collection.Collection, list.List and set.BasicSet. Each suite takes a factory
+returning a new empty container of int, ordered with comparator.IntComparator,
+and runs every check as a subtest. The suites end with randomized tests
+comparing the container with a plain slice or map after each operation
+*/
+package collectiontest
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/dterbah/gods/collection"
+	"github.com/dterbah/gods/list"
+	"github.com/dterbah/gods/set"
+	"github.com/stretchr/testify/assert"
+)
+
+// Number of random operations run by the model-based tests
+const operations = 500
+
+// Seeds of the model-based tests, so that a failure can be replayed
+var seeds = []uint64{1, 2, 3}
+
+/*
+Run the conformance tests of the collection.Collection contract. The collections must
+not be ordered by insertion for these tests, so they also apply to the sets
+*/
+func TestCollection(t *testing.T, factory func() collection.Collection[int]) {
+	t.Run("Empty", func(t *testing.T) {
+		assert := assert.New(t)
+		collection := factory()
+
+		assert.True(collection.IsEmpty())
+		assert.Equal(0, collection.Size())
+		assert.Empty(collection.ToArray())
+		assert.False(collection.Contains(0))
+
+		_, err := collection.At(0)
+		assert.NotNil(err)
+		_, err = collection.At(-1)
+		assert.NotNil(err)
+
+		collection.Remove(1)
+		assert.Equal(0, collection.Size())
+	})
+
+	t.Run("Add", func(t *testing.T) {
+		assert := assert.New(t)
+		collection := factory()
+
+		collection.Add()
+		assert.True(collection.IsEmpty())
+
+		collection.Add(3, 1, 2)
+		assert.False(collection.IsEmpty())
+		assert.Equal(3, collection.Size())
+		assert.ElementsMatch([]int{1, 2, 3}, collection.ToArray())
+		assert.True(collection.Contains(2))
+		assert.False(collection.Contains(4))
+	})
+
+	t.Run("AddAll", func(t *testing.T) {
+		assert := assert.New(t)
+		collection := factory()
+		other := factory()
+		collection.Add(1, 2)
+		other.Add(3, 4)
+
+		collection.AddAll(other)
+		assert.ElementsMatch([]int{1, 2, 3, 4}, collection.ToArray())
+		assert.ElementsMatch([]int{3, 4}, other.ToArray())
+
+		collection.AddAll(factory())
+		assert.Equal(4, collection.Size())
+	})
+
+	t.Run("At", func(t *testing.T) {
+		assert := assert.New(t)
+		collection := factory()
+		collection.Add(5, 7, 9)
+
+		for index, expected := range collection.ToArray() {
+			element, err := collection.At(index)
+			assert.Nil(err)
+			assert.Equal(expected, element)
+		}
+
+		_, err := collection.At(collection.Size())
+		assert.NotNil(err)
+		_, err = collection.At(-1)
+		assert.NotNil(err)
+	})
+
+	t.Run("Clear", func(t *testing.T) {
+		assert := assert.New(t)
+		collection := factory()
+		collection.Add(1, 2, 3)
+
+		collection.Clear()
+		assert.True(collection.IsEmpty())
+		assert.Empty(collection.ToArray())
+		assert.False(collection.Contains(1))
+
+		collection.Add(4)
+		assert.Equal([]int{4}, collection.ToArray())
+	})
+
+	t.Run("ContainsAll", func(t *testing.T) {
+		assert := assert.New(t)
+		collection := factory()
+		other := factory()
+		collection.Add(1, 2, 3)
+
+		assert.True(collection.ContainsAll(other))
+		other.Add(3, 1)
+		assert.True(collection.ContainsAll(other))
+		other.Add(4)
+		assert.False(collection.ContainsAll(other))
+		assert.True(factory().ContainsAll(factory()))
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		assert := assert.New(t)
+		collection := factory()
+		collection.Add(1, 2, 3)
+
+		collection.Remove(2)
+		assert.Equal(2, collection.Size())
+		assert.False(collection.Contains(2))
+		assert.ElementsMatch([]int{1, 3}, collection.ToArray())
+
+		collection.Remove(4)
+		assert.Equal(2, collection.Size())
+
+		collection.Remove(1)
+		collection.Remove(3)
+		assert.True(collection.IsEmpty())
+
+		collection.Add(5)
+		assert.Equal([]int{5}, collection.ToArray())
+	})
+
+	t.Run("ToArray", func(t *testing.T) {
+		assert := assert.New(t)
+		collection := factory()
+		collection.Add(1, 2)
+
+		elements := collection.ToArray()
+		elements[0] = 10
+		assert.False(collection.Contains(10))
+		assert.ElementsMatch([]int{1, 2}, collection.ToArray())
+	})
+}
+
+/*
+Run the conformance tests of the list.List contract, including the ones of
+collection.Collection. The list must keep the elements in their insertion
+order and accept duplicates
+*/
+func TestList(t *testing.T, factory func() list.List[int]) {
+	TestCollection(t, func() collection.Collection[int] {
+		return factory()
+	})
+
+	newList := func(elements ...int) list.List[int] {
+		list := factory()
+		list.Add(elements...)
+		return list
+	}
+
+	t.Run("Order", func(t *testing.T) {
+		assert := assert.New(t)
+		list := newList(3, 1, 3, 2)
+
+		assert.Equal([]int{3, 1, 3, 2}, list.ToArray())
+		assert.Equal(4, list.Size())
+
+		list.Add(0)
+		assert.Equal([]int{3, 1, 3, 2, 0}, list.ToArray())
+	})
+
+	t.Run("Iterators", func(t *testing.T) {
+		assert := assert.New(t)
+		list := newList(4, 5, 6)
+
+		indexes, values := []int{}, []int{}
+		for index, element := range list.All() {
+			indexes = append(indexes, index)
+			values = append(values, element)
+		}
+		assert.Equal([]int{0, 1, 2}, indexes)
+		assert.Equal([]int{4, 5, 6}, values)
+		assert.Equal([]int{4, 5, 6}, slices.Collect(list.Values()))
+
+		visited := []int{}
+		list.ForEach(func(element, index int) {
+			assert.Equal(len(visited), index)
+			visited = append(visited, element)
+		})
+		assert.Equal([]int{4, 5, 6}, visited)
+
+		count := 0
+		for range list.Values() {
+			count++
+			break
+		}
+		assert.Equal(1, count)
+
+		for range newList().All() {
+			assert.Fail("an empty list must not yield elements")
+		}
+	})
+
+	t.Run("IndexOf", func(t *testing.T) {
+		assert := assert.New(t)
+		list := newList(1, 2, 1)
+
+		assert.Equal(0, list.IndexOf(1))
+		assert.Equal(1, list.IndexOf(2))
+		assert.Equal(-1, list.IndexOf(3))
+		assert.Equal(-1, newList().IndexOf(1))
+	})
+
+	t.Run("RemoveFirstOccurrence", func(t *testing.T) {
+		assert := assert.New(t)
+		list := newList(1, 2, 1, 2)
+
+		list.Remove(2)
+		assert.Equal([]int{1, 1, 2}, list.ToArray())
+		list.Remove(1)
+		assert.Equal([]int{1, 2}, list.ToArray())
+	})
+
+	t.Run("RemoveAt", func(t *testing.T) {
+		assert := assert.New(t)
+		list := newList(1, 2, 3, 4)
+
+		assert.False(list.RemoveAt(-1))
+		assert.False(list.RemoveAt(4))
+		assert.True(list.RemoveAt(1))
+		assert.Equal([]int{1, 3, 4}, list.ToArray())
+		assert.True(list.RemoveAt(2))
+		assert.Equal([]int{1, 3}, list.ToArray())
+		assert.True(list.RemoveAt(0))
+		assert.True(list.RemoveAt(0))
+		assert.True(list.IsEmpty())
+		assert.False(list.RemoveAt(0))
+
+		list.Add(5, 6)
+		assert.Equal([]int{5, 6}, list.ToArray())
+	})
+
+	t.Run("ReplaceAt", func(t *testing.T) {
+		assert := assert.New(t)
+		list := newList(1, 2, 3)
+
+		assert.False(list.ReplaceAt(-1, 0))
+		assert.False(list.ReplaceAt(3, 0))
+		assert.False(newList().ReplaceAt(0, 0))
+		assert.True(list.ReplaceAt(0, 10))
+		assert.True(list.ReplaceAt(2, 30))
+		assert.Equal([]int{10, 2, 30}, list.ToArray())
+	})
+
+	t.Run("Reverse", func(t *testing.T) {
+		assert := assert.New(t)
+
+		list := newList()
+		list.Reverse()
+		assert.Empty(list.ToArray())
+
+		list = newList(1, 2, 3, 4)
+		list.Reverse()
+		assert.Equal([]int{4, 3, 2, 1}, list.ToArray())
+
+		list.Add(0)
+		assert.Equal([]int{4, 3, 2, 1, 0}, list.ToArray())
+	})
+
+	t.Run("Sort", func(t *testing.T) {
+		assert := assert.New(t)
+
+		list := newList()
+		assert.Nil(list.Sort())
+		assert.Empty(list.ToArray())
+
+		list = newList(3, -1, 2, 3, 0)
+		assert.Nil(list.Sort())
+		assert.Equal([]int{-1, 0, 2, 3, 3}, list.ToArray())
+
+		list.Add(1)
+		assert.Equal([]int{-1, 0, 2, 3, 3, 1}, list.ToArray())
+	})
+
+	t.Run("SubList", func(t *testing.T) {
+		assert := assert.New(t)
+		list := newList(1, 2, 3, 4)
+
+		assert.Equal([]int{2, 3}, list.SubList(1, 3).ToArray())
+		assert.Equal([]int{1, 2, 3, 4}, list.SubList(0, 4).ToArray())
+		assert.Empty(list.SubList(2, 2).ToArray())
+		assert.Empty(list.SubList(4, 4).ToArray())
+
+		subList := list.SubList(0, 2)
+		subList.Add(5)
+		assert.Equal([]int{1, 2, 3, 4}, list.ToArray())
+		assert.Equal([]int{1, 2, 5}, subList.ToArray())
+	})
+
+	t.Run("SubListOutOfBounds", func(t *testing.T) {
+		assert := assert.New(t)
+		list := newList(1, 2, 3)
+
+		assert.Equal([]int{1, 2, 3}, list.SubList(-1, 2).ToArray())
+		assert.Equal([]int{1, 2, 3}, list.SubList(0, 4).ToArray())
+		assert.Equal([]int{1, 2, 3}, list.SubList(2, 1).ToArray())
+		assert.Empty(newList().SubList(0, 0).ToArray())
+	})
+
+	t.Run("Copy", func(t *testing.T) {
+		assert := assert.New(t)
+		list := newList(1, 2)
+
+		duplicate := list.Copy()
+		assert.Equal([]int{1, 2}, duplicate.ToArray())
+
+		duplicate.Add(3)
+		list.RemoveAt(0)
+		assert.Equal([]int{2}, list.ToArray())
+		assert.Equal([]int{1, 2, 3}, duplicate.ToArray())
+		assert.Equal(2, duplicate.IndexOf(3))
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		assert := assert.New(t)
+		list := newList(3, 1, 2)
+
+		empty := list.Empty()
+		assert.True(empty.IsEmpty())
+		assert.IsType(list, empty)
+		assert.Equal(3, list.Size())
+
+		empty.Add(2, 1)
+		assert.True(empty.Contains(1))
+		assert.Nil(empty.Sort())
+		assert.Equal([]int{1, 2}, empty.ToArray())
+	})
+
+	t.Run("Filter", func(t *testing.T) {
+		assert := assert.New(t)
+		list := newList(1, 2, 3, 4, 2)
+
+		even := list.Filter(func(element int) bool { return element%2 == 0 })
+		assert.Equal([]int{2, 4, 2}, even.ToArray())
+		assert.Equal([]int{1, 2, 3, 4, 2}, list.ToArray())
+		assert.Empty(list.Filter(func(int) bool { return false }).ToArray())
+		assert.Empty(newList().Filter(func(int) bool { return true }).ToArray())
+	})
+
+	t.Run("EverySome", func(t *testing.T) {
+		assert := assert.New(t)
+		list := newList(2, 4, 5)
+		positive := func(element, _ int) bool { return element > 0 }
+		even := func(element, _ int) bool { return element%2 == 0 }
+		atIndex := func(element, index int) bool { return index == 2 && element == 5 }
+
+		assert.True(list.Every(positive))
+		assert.False(list.Every(even))
+		assert.True(list.Some(even))
+		assert.True(list.Some(atIndex))
+		assert.False(list.Some(func(element, _ int) bool { return element > 5 }))
+
+		assert.True(newList().Every(even))
+		assert.False(newList().Some(positive))
+	})
+
+	for _, seed := range seeds {
+		t.Run(fmt.Sprintf("Model/seed=%d", seed), func(t *testing.T) {
+			testListModel(t, factory(), seed)
+		})
+	}
+}
+
+/*
+Run the conformance tests of the set.BasicSet contract, including the ones of
+collection.Collection. The order of the elements of the set is not checked
+*/
+func TestSet(t *testing.T, factory func() set.BasicSet[int]) {
+	TestCollection(t, func() collection.Collection[int] {
+		return factory()
+	})
+
+	newSet := func(elements ...int) set.BasicSet[int] {
+		set := factory()
+		set.Add(elements...)
+		return set
+	}
+
+	t.Run("Duplicates", func(t *testing.T) {
+		assert := assert.New(t)
+		set := newSet(1, 2, 1)
+
+		assert.Equal(2, set.Size())
+		set.Add(2, 3, 3)
+		assert.ElementsMatch([]int{1, 2, 3}, set.ToArray())
+
+		set.Remove(1)
+		assert.False(set.Contains(1))
+		assert.Equal(2, set.Size())
+	})
+
+	t.Run("Iterators", func(t *testing.T) {
+		assert := assert.New(t)
+		set := newSet(4, 5, 6)
+		elements := set.ToArray()
+
+		for index, element := range set.All() {
+			assert.Equal(elements[index], element)
+			assert.Equal(index, set.IndexOf(element))
+		}
+		assert.Equal(elements, slices.Collect(set.Values()))
+
+		set.ForEach(func(element, index int) {
+			assert.Equal(elements[index], element)
+		})
+		assert.Equal(-1, set.IndexOf(7))
+	})
+
+	t.Run("Copy", func(t *testing.T) {
+		assert := assert.New(t)
+		set := newSet(1, 2)
+
+		duplicate := set.Copy()
+		duplicate.Add(3)
+		set.Remove(1)
+		assert.ElementsMatch([]int{2}, set.ToArray())
+		assert.ElementsMatch([]int{1, 2, 3}, duplicate.ToArray())
+	})
+
+	t.Run("Diff", func(t *testing.T) {
+		assert := assert.New(t)
+		a := newSet(1, 2, 3)
+		b := newSet(2, 4)
+
+		assert.ElementsMatch([]int{1, 3}, a.Diff(b).ToArray())
+		assert.ElementsMatch([]int{4}, b.Diff(a).ToArray())
+		assert.ElementsMatch([]int{1, 2, 3}, a.Diff(newSet()).ToArray())
+		assert.Empty(newSet().Diff(a).ToArray())
+		assert.ElementsMatch([]int{1, 2, 3}, a.ToArray())
+	})
+
+	t.Run("Intersection", func(t *testing.T) {
+		assert := assert.New(t)
+		a := newSet(1, 2, 3)
+		b := newSet(2, 3, 4)
+
+		assert.ElementsMatch([]int{2, 3}, a.Intersection(b).ToArray())
+		assert.ElementsMatch([]int{2, 3}, b.Intersection(a).ToArray())
+		assert.Empty(a.Intersection(newSet()).ToArray())
+		assert.ElementsMatch([]int{1, 2, 3}, a.ToArray())
+	})
+
+	t.Run("IsSubset", func(t *testing.T) {
+		assert := assert.New(t)
+		a := newSet(1, 2, 3)
+
+		assert.True(a.IsSubset(newSet(1, 3)))
+		assert.True(a.IsSubset(newSet()))
+		assert.True(a.IsSubset(a))
+		assert.False(a.IsSubset(newSet(1, 4)))
+		assert.False(newSet().IsSubset(a))
+	})
+
+	t.Run("Union", func(t *testing.T) {
+		assert := assert.New(t)
+		a := newSet(1, 2, 3)
+		b := newSet(3, 4)
+
+		assert.ElementsMatch([]int{1, 2, 3, 4}, a.Union(b).ToArray())
+		assert.ElementsMatch([]int{1, 2, 3, 4}, b.Union(a).ToArray())
+		assert.ElementsMatch([]int{1, 2, 3}, a.Union(newSet()).ToArray())
+		assert.ElementsMatch([]int{1, 2, 3}, a.Union(a).ToArray())
+		assert.ElementsMatch([]int{1, 2, 3}, a.ToArray())
+	})
+
+	for _, seed := range seeds {
+		t.Run(fmt.Sprintf("Model/seed=%d", seed), func(t *testing.T) {
+			testSetModel(t, factory, seed)
+		})
+	}
+}
+
+// Private functions
+
+// Apply random operations to the list and to a slice, and compare them after each operation
+func testListModel(t *testing.T, list list.List[int], seed uint64) {
+	random := rand.New(rand.NewPCG(seed, seed))
+	model := []int{}
+
+	for operation := range operations {
+		element := random.IntN(20)
+		index := random.IntN(len(model)+2) - 1
+		valid := index >= 0 && index < len(model)
+		var description string
+
+		switch random.IntN(10) {
+		case 0, 1, 2:
+			description = fmt.Sprintf("Add(%d)", element)
+			list.Add(element)
+			model = append(model, element)
+		case 3:
+			description = fmt.Sprintf("RemoveAt(%d)", index)
+			if list.RemoveAt(index) != valid {
+				t.Fatalf("seed %d, operation %d: %s returned %t", seed, operation, description, !valid)
+			}
+			if valid {
+				model = slices.Delete(model, index, index+1)
+			}
+		case 4:
+			description = fmt.Sprintf("Remove(%d)", element)
+			list.Remove(element)
+			if position := slices.Index(model, element); position >= 0 {
+				model = slices.Delete(model, position, position+1)
+			}
+		case 5:
+			description = fmt.Sprintf("ReplaceAt(%d, %d)", index, element)
+			if list.ReplaceAt(index, element) != valid {
+				t.Fatalf("seed %d, operation %d: %s returned %t", seed, operation, description, !valid)
+			}
+			if valid {
+				model[index] = element
+			}
+		case 6:
+			description = "Reverse()"
+			list.Reverse()
+			slices.Reverse(model)
+		case 7:
+			description = "Sort()"
+			if err := list.Sort(); err != nil {
+				t.Fatalf("seed %d, operation %d: %s returned %v", seed, operation, description, err)
+			}
+			slices.Sort(model)
+		case 8:
+			description = fmt.Sprintf("At(%d)", index)
+			value, err := list.At(index)
+			if valid && (err != nil || value != model[index]) || !valid && err == nil {
+				t.Fatalf("seed %d, operation %d: %s returned %d, %v", seed, operation, description, value, err)
+			}
+		default:
+			description = fmt.Sprintf("IndexOf(%d)", element)
+			if list.IndexOf(element) != slices.Index(model, element) {
+				t.Fatalf("seed %d, operation %d: %s returned %d", seed, operation, description, list.IndexOf(element))
+			}
+		}
+
+		if !slices.Equal(model, list.ToArray()) || list.Size() != len(model) {
+			t.Fatalf("seed %d, operation %d: after %s, expected %v, got %v (size %d)",
+				seed, operation, description, model, list.ToArray(), list.Size())
+		}
+	}
+}
+
+// Apply random operations to the set and to a map, and compare them after each operation
+func testSetModel(t *testing.T, factory func() set.BasicSet[int], seed uint64) {
+	random := rand.New(rand.NewPCG(seed, seed))
+	set := factory()
+	model := map[int]bool{}
+
+	for operation := range operations {
+		element := random.IntN(30)
+		var description string
+
+		switch random.IntN(6) {
+		case 0, 1, 2:
+			description = fmt.Sprintf("Add(%d)", element)
+			set.Add(element)
+			model[element] = true
+		case 3:
+			description = fmt.Sprintf("Remove(%d)", element)
+			set.Remove(element)
+			delete(model, element)
+		case 4:
+			description = fmt.Sprintf("Contains(%d)", element)
+			if set.Contains(element) != model[element] {
+				t.Fatalf("seed %d, operation %d: %s returned %t", seed, operation, description, !model[element])
+			}
+		default:
+			other := factory()
+			other.Add(element, element+1)
+			description = fmt.Sprintf("Union/Intersection/Diff with {%d, %d}", element, element+1)
+			if !sameElements(set.Union(other), union(model, element, element+1)) ||
+				!sameElements(set.Intersection(other), intersection(model, element, element+1)) ||
+				!sameElements(set.Diff(other), diff(model, element, element+1)) {
+				t.Fatalf("seed %d, operation %d: %s does not match the model %v", seed, operation, description, model)
+			}
+		}
+
+		if !sameElements(set, model) {
+			t.Fatalf("seed %d, operation %d: after %s, expected %v, got %v", seed, operation, description, model, set.ToArray())
+		}
+	}
+}
+
+// Return true if the set contains exactly the elements of the model
+func sameElements(set set.BasicSet[int], model map[int]bool) bool {
+	if set.Size() != len(model) {
+		return false
+	}
+
+	for _, element := range set.ToArray() {
+		if !model[element] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func union(model map[int]bool, elements ...int) map[int]bool {
+	result := map[int]bool{}
+	for element := range model {
+		result[element] = true
+	}
+	for _, element := range elements {
+		result[element] = true
+	}
+
+	return result
+}
+
+func intersection(model map[int]bool, elements ...int) map[int]bool {
+	result := map[int]bool{}
+	for _, element := range elements {
+		if model[element] {
+			result[element] = true
+		}
+	}
+
+	return result
+}
+
+func diff(model map[int]bool, elements ...int) map[int]bool {
+	result := map[int]bool{}
+	for element := range model {
+		if !slices.Contains(elements, element) {
+			result[element] = true
+		}
+	}
+
+	return result
+}
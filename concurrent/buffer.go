@@ -0,0 +1,351 @@
+package concurrent
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"sync"
+
+	"github.com/dterbah/gods/buffer/circular"
+	"github.com/dterbah/gods/collection"
+)
+
+/*
+Struct that wraps a CircularBuffer to make it safe for concurrent use, e.g. between
+the producers and the consumers of a bounded log. The reads are guarded by a shared
+lock and the writes by an exclusive one. The callbacks and the iterators work on a
+snapshot of the buffer, so they can safely call the methods of the buffer
+*/
+type CircularBuffer[T any] struct {
+	mutex  sync.RWMutex
+	buffer *circular.CircularBuffer[T]
+}
+
+/*
+Wrap a buffer to make it safe for concurrent use. The wrapped buffer
+must not be used directly anymore
+*/
+func NewCircularBuffer[T any](buffer *circular.CircularBuffer[T]) *CircularBuffer[T] {
+	return &CircularBuffer[T]{buffer: buffer}
+}
+
+/*
+Enqueue elements inside the buffer. When the buffer is full, the elements
+are dropped, unless the buffer is in overwrite mode
+*/
+func (buffer *CircularBuffer[T]) Add(elements ...T) {
+	buffer.mutex.Lock()
+	defer buffer.mutex.Unlock()
+
+	buffer.buffer.Add(elements...)
+}
+
+/*
+Enqueue all the elements of a snapshot of the collection
+*/
+func (buffer *CircularBuffer[T]) AddAll(elements collection.Collection[T]) {
+	snapshot := elements.ToArray()
+
+	buffer.mutex.Lock()
+	defer buffer.mutex.Unlock()
+
+	buffer.buffer.Add(snapshot...)
+}
+
+/*
+Return an iterator over a snapshot of the indexes and the elements of the buffer
+*/
+func (buffer *CircularBuffer[T]) All() iter.Seq2[int, T] {
+	return slices.All(buffer.ToArray())
+}
+
+/*
+Retrieve an element by its index, relative to the oldest element of the buffer.
+If the index is out of bound, the method will return an error
+*/
+func (buffer *CircularBuffer[T]) At(index int) (T, error) {
+	buffer.mutex.RLock()
+	defer buffer.mutex.RUnlock()
+
+	return buffer.buffer.At(index)
+}
+
+/*
+Return the maximum number of elements the buffer can store
+*/
+func (buffer *CircularBuffer[T]) Cap() int {
+	buffer.mutex.RLock()
+	defer buffer.mutex.RUnlock()
+
+	return buffer.buffer.Cap()
+}
+
+/*
+Remove all the elements of the buffer
+*/
+func (buffer *CircularBuffer[T]) Clear() {
+	buffer.mutex.Lock()
+	defer buffer.mutex.Unlock()
+
+	buffer.buffer.Clear()
+}
+
+/*
+Return true if the buffer contains at least one occurence of the element, else false
+*/
+func (buffer *CircularBuffer[T]) Contains(element T) bool {
+	buffer.mutex.RLock()
+	defer buffer.mutex.RUnlock()
+
+	return buffer.buffer.Contains(element)
+}
+
+/*
+Return true if all the elements of a snapshot of the collection are in the buffer, else false
+*/
+func (buffer *CircularBuffer[T]) ContainsAll(elements collection.Collection[T]) bool {
+	snapshot := elements.ToArray()
+
+	buffer.mutex.RLock()
+	defer buffer.mutex.RUnlock()
+
+	for _, element := range snapshot {
+		if !buffer.buffer.Contains(element) {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+Dequeue the oldest element of the buffer. If it is empty, it returns an error
+*/
+func (buffer *CircularBuffer[T]) Dequeue() (T, error) {
+	buffer.mutex.Lock()
+	defer buffer.mutex.Unlock()
+
+	return buffer.buffer.Dequeue()
+}
+
+/*
+Enqueue an element inside the buffer. If the buffer is full, this method will
+return an error, unless the buffer is in overwrite mode
+*/
+func (buffer *CircularBuffer[T]) Enqueue(element T) error {
+	buffer.mutex.Lock()
+	defer buffer.mutex.Unlock()
+
+	return buffer.buffer.Enqueue(element)
+}
+
+/*
+Call a function for each element of a snapshot of the buffer
+*/
+func (buffer *CircularBuffer[T]) ForEach(callback func(element T, index int)) {
+	for index, element := range buffer.All() {
+		callback(element, index)
+	}
+}
+
+/*
+Format the wrapped buffer for the fmt package, with the same verbs and flags
+*/
+func (buffer *CircularBuffer[T]) Format(state fmt.State, verb rune) {
+	buffer.mutex.RLock()
+	defer buffer.mutex.RUnlock()
+
+	fmt.Fprintf(state, fmt.FormatString(state, verb), buffer.buffer)
+}
+
+/*
+Decode the wrapped buffer from the gob encoding, see UnmarshalBinary
+*/
+func (buffer *CircularBuffer[T]) GobDecode(data []byte) error {
+	return buffer.UnmarshalBinary(data)
+}
+
+/*
+Encode the wrapped buffer for gob, see MarshalBinary
+*/
+func (buffer *CircularBuffer[T]) GobEncode() ([]byte, error) {
+	return buffer.MarshalBinary()
+}
+
+/*
+Return the index of the element, relative to the oldest element of the buffer.
+If the element is not present in the buffer, the method will return -1
+*/
+func (buffer *CircularBuffer[T]) IndexOf(element T) int {
+	buffer.mutex.RLock()
+	defer buffer.mutex.RUnlock()
+
+	return buffer.buffer.IndexOf(element)
+}
+
+/*
+Return true if the buffer has no elements, else false
+*/
+func (buffer *CircularBuffer[T]) IsEmpty() bool {
+	buffer.mutex.RLock()
+	defer buffer.mutex.RUnlock()
+
+	return buffer.buffer.IsEmpty()
+}
+
+/*
+Return true if the buffer is full, else false
+*/
+func (buffer *CircularBuffer[T]) IsFull() bool {
+	buffer.mutex.RLock()
+	defer buffer.mutex.RUnlock()
+
+	return buffer.buffer.IsFull()
+}
+
+/*
+Encode the wrapped buffer in its binary format
+*/
+func (buffer *CircularBuffer[T]) MarshalBinary() ([]byte, error) {
+	buffer.mutex.RLock()
+	defer buffer.mutex.RUnlock()
+
+	return buffer.buffer.MarshalBinary()
+}
+
+/*
+Encode the wrapped buffer in JSON
+*/
+func (buffer *CircularBuffer[T]) MarshalJSON() ([]byte, error) {
+	buffer.mutex.RLock()
+	defer buffer.mutex.RUnlock()
+
+	return buffer.buffer.MarshalJSON()
+}
+
+/*
+Return the oldest element of the buffer without removing it.
+If the buffer is empty, it returns an error
+*/
+func (buffer *CircularBuffer[T]) Peek() (T, error) {
+	buffer.mutex.RLock()
+	defer buffer.mutex.RUnlock()
+
+	return buffer.buffer.Peek()
+}
+
+/*
+Return the newest element of the buffer without removing it.
+If the buffer is empty, it returns an error
+*/
+func (buffer *CircularBuffer[T]) PeekLast() (T, error) {
+	buffer.mutex.RLock()
+	defer buffer.mutex.RUnlock()
+
+	return buffer.buffer.PeekLast()
+}
+
+func (buffer *CircularBuffer[T]) Print() {
+	buffer.mutex.RLock()
+	defer buffer.mutex.RUnlock()
+
+	buffer.buffer.Print()
+}
+
+/*
+Remove the first occurence of the element in the buffer
+*/
+func (buffer *CircularBuffer[T]) Remove(element T) {
+	buffer.mutex.Lock()
+	defer buffer.mutex.Unlock()
+
+	buffer.buffer.Remove(element)
+}
+
+/*
+Change the capacity of the buffer, keeping its elements in the same order.
+If the new size is invalid, this method will return an error
+*/
+func (buffer *CircularBuffer[T]) Resize(newSize int) error {
+	buffer.mutex.Lock()
+	defer buffer.mutex.Unlock()
+
+	return buffer.buffer.Resize(newSize)
+}
+
+/*
+Return the number of elements currently stored in the buffer
+*/
+func (buffer *CircularBuffer[T]) Size() int {
+	buffer.mutex.RLock()
+	defer buffer.mutex.RUnlock()
+
+	return buffer.buffer.Size()
+}
+
+/*
+Return the wrapped buffer as a string
+*/
+func (buffer *CircularBuffer[T]) String() string {
+	buffer.mutex.RLock()
+	defer buffer.mutex.RUnlock()
+
+	return buffer.buffer.String()
+}
+
+/*
+Return a snapshot of the elements of the buffer, from the oldest to the newest
+*/
+func (buffer *CircularBuffer[T]) ToArray() []T {
+	buffer.mutex.RLock()
+	defer buffer.mutex.RUnlock()
+
+	return buffer.buffer.ToArray()
+}
+
+/*
+Decode the binary format of the wrapped buffer, replacing its content.
+The wrapper must be created with NewCircularBuffer before
+*/
+func (buffer *CircularBuffer[T]) UnmarshalBinary(data []byte) error {
+	buffer.mutex.Lock()
+	defer buffer.mutex.Unlock()
+
+	if buffer.buffer == nil {
+		return errors.New("no wrapped buffer, create the wrapper with NewCircularBuffer before decoding")
+	}
+
+	return buffer.buffer.UnmarshalBinary(data)
+}
+
+/*
+Decode JSON into the wrapped buffer, replacing its content. The wrapper
+must be created with NewCircularBuffer before
+*/
+func (buffer *CircularBuffer[T]) UnmarshalJSON(data []byte) error {
+	buffer.mutex.Lock()
+	defer buffer.mutex.Unlock()
+
+	if buffer.buffer == nil {
+		return errors.New("no wrapped buffer, create the wrapper with NewCircularBuffer before decoding")
+	}
+
+	return buffer.buffer.UnmarshalJSON(data)
+}
+
+/*
+Return an iterator over a snapshot of the elements of the buffer
+*/
+func (buffer *CircularBuffer[T]) Values() iter.Seq[T] {
+	return slices.Values(buffer.ToArray())
+}
+
+/*
+Write the wrapped buffer, as returned by String, to the writer
+*/
+func (buffer *CircularBuffer[T]) WriteTo(writer io.Writer) (int64, error) {
+	count, err := io.WriteString(writer, buffer.String())
+	return int64(count), err
+}
@@ -0,0 +1,78 @@
+package concurrent
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/dterbah/gods/buffer/circular"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircularBufferBinary(t *testing.T) {
+	assert := assert.New(t)
+	safeBuffer := NewCircularBuffer(circular.New[int](3, circular.WithOverwrite[int]()))
+	safeBuffer.Add(1, 2, 3, 4)
+
+	data, err := safeBuffer.MarshalBinary()
+	assert.Nil(err)
+
+	decoded := NewCircularBuffer(circular.New[int](1))
+	assert.Nil(decoded.UnmarshalBinary(data))
+	assert.Equal([]int{2, 3, 4}, decoded.ToArray())
+	assert.Equal(3, decoded.Cap())
+
+	var unwrapped CircularBuffer[int]
+	assert.NotNil(unwrapped.UnmarshalBinary(data))
+	assert.NotNil(unwrapped.UnmarshalJSON([]byte("[1]")))
+}
+
+func TestCircularBufferConcurrentEnqueueDequeue(t *testing.T) {
+	assert := assert.New(t)
+	safeBuffer := NewCircularBuffer(circular.New[int](workers * iterations))
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				assert.Nil(safeBuffer.Enqueue(i))
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.True(safeBuffer.IsFull())
+	assert.NotNil(safeBuffer.Enqueue(0))
+
+	var mutex sync.Mutex
+	dequeued := 0
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				if _, err := safeBuffer.Dequeue(); err != nil {
+					return
+				}
+				mutex.Lock()
+				dequeued++
+				mutex.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(workers*iterations, dequeued)
+	assert.True(safeBuffer.IsEmpty())
+}
+
+func TestCircularBufferString(t *testing.T) {
+	assert := assert.New(t)
+	safeBuffer := NewCircularBuffer(circular.New[int](3))
+	safeBuffer.Add(1, 2)
+
+	assert.Equal("[1, 2]", safeBuffer.String())
+	element, _ := safeBuffer.PeekLast()
+	assert.Equal(2, element)
+}
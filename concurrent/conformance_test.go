@@ -0,0 +1,33 @@
+package concurrent_test
+
+import (
+	"testing"
+
+	"github.com/dterbah/gods/buffer/circular"
+	"github.com/dterbah/gods/collection"
+	"github.com/dterbah/gods/collection/collectiontest"
+	"github.com/dterbah/gods/concurrent"
+	"github.com/dterbah/gods/list"
+	"github.com/dterbah/gods/list/linkedlist"
+	"github.com/dterbah/gods/set"
+	"github.com/dterbah/gods/set/treeset"
+	comparator "github.com/dterbah/gods/utils"
+)
+
+func TestCircularBufferConformance(t *testing.T) {
+	collectiontest.TestCollection(t, func() collection.Collection[int] {
+		return concurrent.NewCircularBuffer(circular.New[int](1024))
+	})
+}
+
+func TestListConformance(t *testing.T) {
+	collectiontest.TestList(t, func() list.List[int] {
+		return concurrent.NewList[int](linkedlist.New(comparator.IntComparator))
+	})
+}
+
+func TestSetConformance(t *testing.T) {
+	collectiontest.TestSet(t, func() set.BasicSet[int] {
+		return concurrent.NewSet[int](treeset.New(comparator.IntComparator))
+	})
+}
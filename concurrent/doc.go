@@ -0,0 +1,9 @@
+/*
+Package concurrent wraps the containers of the module to make them safe for
+concurrent use, with a sync.RWMutex. It covers the lists (List), the sets (Set),
+the maps (Map), the Queue, the Stack and the CircularBuffer. The trees (BinaryTree,
+AVLTree, RedBlackTree, TreeMultiset), the Deque and the PriorityQueue are not wrapped:
+their users must guard them with their own lock. The lockfree package has a queue
+and a stack that do not take any lock
+*/
+package concurrent
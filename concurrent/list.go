@@ -0,0 +1,437 @@
+package concurrent
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"sync"
+
+	"github.com/dterbah/gods/collection"
+	"github.com/dterbah/gods/list"
+)
+
+/*
+Struct that wraps a list.List to make it safe for concurrent use.
+The reads are guarded by a shared lock and the writes by an exclusive one.
+The callbacks and the iterators work on a snapshot of the list, so they
+can safely call the methods of the list
+*/
+type List[T any] struct {
+	mutex sync.RWMutex
+	list  list.List[T]
+}
+
+/*
+Wrap a list to make it safe for concurrent use. The wrapped list
+must not be used directly anymore
+*/
+func NewList[T any](list list.List[T]) *List[T] {
+	return &List[T]{list: list}
+}
+
+/*
+Add elements at the end of the list
+*/
+func (list *List[T]) Add(elements ...T) {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	list.list.Add(elements...)
+}
+
+/*
+Add the element at the end of the list if it is not already present.
+Return true if the element was added, else false
+*/
+func (list *List[T]) AddIfAbsent(element T) bool {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	if list.list.Contains(element) {
+		return false
+	}
+
+	list.list.Add(element)
+	return true
+}
+
+/*
+Add all the elements of the collection at the end of the list
+*/
+func (list *List[T]) AddAll(elements collection.Collection[T]) {
+	snapshot := elements.ToArray()
+
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	list.list.Add(snapshot...)
+}
+
+/*
+Return an iterator over a snapshot of the indexes and the elements of the list
+*/
+func (list *List[T]) All() iter.Seq2[int, T] {
+	return slices.All(list.ToArray())
+}
+
+/*
+Retrieve an element by its index
+If the index is negative or greater than the list size, the method will return an error
+*/
+func (list *List[T]) At(index int) (T, error) {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.list.At(index)
+}
+
+/*
+Clear all the elements in the list
+*/
+func (list *List[T]) Clear() {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	list.list.Clear()
+}
+
+/*
+Replace the first occurence of the element by the result of the remapping function,
+called with the current value. Return true if the element was present, else false
+*/
+func (list *List[T]) ComputeIfPresent(element T, remapping func(current T) T) bool {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	index := list.list.IndexOf(element)
+	if index == -1 {
+		return false
+	}
+
+	current, _ := list.list.At(index)
+	list.list.ReplaceAt(index, remapping(current))
+	return true
+}
+
+/*
+Return true if the list contains at least one occurence of the element, else false
+*/
+func (list *List[T]) Contains(element T) bool {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.list.Contains(element)
+}
+
+/*
+Return true if the list contains all the elements of the collection, else false
+*/
+func (list *List[T]) ContainsAll(elements collection.Collection[T]) bool {
+	snapshot := elements.ToArray()
+
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	for _, element := range snapshot {
+		if !list.list.Contains(element) {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+Create a shallow copy of the list, also safe for concurrent use
+*/
+func (list *List[T]) Copy() list.List[T] {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return NewList(list.list.Copy())
+}
+
+/*
+Create an empty list of the same implementation as the wrapped one, also safe for concurrent use
+*/
+func (list *List[T]) Empty() list.List[T] {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return NewList(list.list.Empty())
+}
+
+/*
+Check if all the elements of a snapshot of the list match the callback
+*/
+func (list *List[T]) Every(callback func(element T, index int) bool) bool {
+	for index, element := range list.All() {
+		if !callback(element, index) {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+Return a new list, safe for concurrent use, with the elements of a snapshot
+of the list matching the callback
+*/
+func (list *List[T]) Filter(callback func(element T) bool) list.List[T] {
+	snapshot := list.ToArray()
+	newList := list.Empty()
+
+	for _, element := range snapshot {
+		if callback(element) {
+			newList.Add(element)
+		}
+	}
+
+	return newList
+}
+
+/*
+Call a function for each element of a snapshot of the list
+*/
+func (list *List[T]) ForEach(callback func(element T, index int)) {
+	for index, element := range list.All() {
+		callback(element, index)
+	}
+}
+
+/*
+Format the wrapped list for the fmt package, with the same verbs and flags
+*/
+func (list *List[T]) Format(state fmt.State, verb rune) {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	fmt.Fprintf(state, fmt.FormatString(state, verb), list.list)
+}
+
+/*
+Decode the wrapped list from the gob encoding, see UnmarshalBinary
+*/
+func (list *List[T]) GobDecode(data []byte) error {
+	return list.UnmarshalBinary(data)
+}
+
+/*
+Encode the wrapped list for gob, see MarshalBinary
+*/
+func (list *List[T]) GobEncode() ([]byte, error) {
+	return list.MarshalBinary()
+}
+
+/*
+Return the index in the list of the element, or -1 if it is not present
+*/
+func (list *List[T]) IndexOf(element T) int {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.list.IndexOf(element)
+}
+
+/*
+Return true if the list has no elements, else false
+*/
+func (list *List[T]) IsEmpty() bool {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.list.IsEmpty()
+}
+
+/*
+Encode the wrapped list in its binary format. If the list
+cannot be encoded in binary, this method will return an error
+*/
+func (list *List[T]) MarshalBinary() ([]byte, error) {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	marshaler, ok := any(list.list).(encoding.BinaryMarshaler)
+	if !ok {
+		return nil, errors.New("wrapped list cannot be encoded in binary")
+	}
+
+	return marshaler.MarshalBinary()
+}
+
+/*
+Encode the wrapped list in JSON
+*/
+func (list *List[T]) MarshalJSON() ([]byte, error) {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return json.Marshal(list.list)
+}
+
+func (list *List[T]) Print() {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	list.list.Print()
+}
+
+/*
+Remove the specified element if it exists in the list
+*/
+func (list *List[T]) Remove(element T) {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	list.list.Remove(element)
+}
+
+/*
+Remove the element at the specified index in the list.
+Return true if the element was removed, else false
+*/
+func (list *List[T]) RemoveAt(index int) bool {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	return list.list.RemoveAt(index)
+}
+
+/*
+Replace the element at the specified index.
+Return true if the element was replaced, else false
+*/
+func (list *List[T]) ReplaceAt(index int, element T) bool {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	return list.list.ReplaceAt(index, element)
+}
+
+/*
+Reverse the elements inside the list
+*/
+func (list *List[T]) Reverse() {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	list.list.Reverse()
+}
+
+/*
+Retrieve the list size
+*/
+func (list *List[T]) Size() int {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.list.Size()
+}
+
+/*
+Check if at least one element of a snapshot of the list matches the callback
+*/
+func (list *List[T]) Some(callback func(element T, index int) bool) bool {
+	for index, element := range list.All() {
+		if callback(element, index) {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+Sort the list. It returns the error of the wrapped list, if any
+*/
+func (list *List[T]) Sort() error {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	return list.list.Sort()
+}
+
+/*
+Return the wrapped list as a string
+*/
+func (list *List[T]) String() string {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return fmt.Sprint(list.list)
+}
+
+/*
+Return a new list, safe for concurrent use, with the elements in the range [start:end]
+*/
+func (list *List[T]) SubList(start, end int) list.List[T] {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return NewList(list.list.SubList(start, end))
+}
+
+/*
+Return a snapshot of the elements of the list
+*/
+func (list *List[T]) ToArray() []T {
+	list.mutex.RLock()
+	defer list.mutex.RUnlock()
+
+	return list.list.ToArray()
+}
+
+/*
+Decode the binary format of the wrapped list, replacing its content.
+The wrapper must be created with NewList before
+*/
+func (list *List[T]) UnmarshalBinary(data []byte) error {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	if list.list == nil {
+		return errors.New("no wrapped list, create the wrapper with NewList before decoding")
+	}
+
+	unmarshaler, ok := any(list.list).(encoding.BinaryUnmarshaler)
+	if !ok {
+		return errors.New("wrapped list cannot be decoded from binary")
+	}
+
+	return unmarshaler.UnmarshalBinary(data)
+}
+
+/*
+Decode JSON into the wrapped list, replacing its content. The implementation
+of the list cannot be guessed, so the wrapper must be created with NewList before
+*/
+func (list *List[T]) UnmarshalJSON(data []byte) error {
+	list.mutex.Lock()
+	defer list.mutex.Unlock()
+
+	if list.list == nil {
+		return errors.New("no wrapped list, create the wrapper with NewList before decoding")
+	}
+
+	return json.Unmarshal(data, list.list)
+}
+
+/*
+Return an iterator over a snapshot of the elements of the list
+*/
+func (list *List[T]) Values() iter.Seq[T] {
+	return slices.Values(list.ToArray())
+}
+
+/*
+Write the wrapped list, as returned by String, to the writer
+*/
+func (list *List[T]) WriteTo(writer io.Writer) (int64, error) {
+	count, err := io.WriteString(writer, list.String())
+	return int64(count), err
+}
@@ -0,0 +1,220 @@
+package concurrent
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dterbah/gods/list"
+	"github.com/dterbah/gods/list/arraylist"
+	"github.com/dterbah/gods/list/linkedlist"
+	comparator "github.com/dterbah/gods/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	workers    = 8
+	iterations = 200
+)
+
+func TestListBinary(t *testing.T) {
+	assert := assert.New(t)
+	safeList := NewList[int](arraylist.New(comparator.IntComparator, 1, 2))
+
+	data, err := safeList.MarshalBinary()
+	assert.Nil(err)
+
+	decoded := NewList[int](arraylist.New(comparator.IntComparator))
+	assert.Nil(decoded.UnmarshalBinary(data))
+	assert.Equal([]int{1, 2}, decoded.ToArray())
+
+	var unwrapped List[int]
+	assert.NotNil(unwrapped.UnmarshalBinary(data))
+}
+
+func TestListImplementsList(t *testing.T) {
+	assert := assert.New(t)
+	var safeList list.List[int] = NewList[int](arraylist.New(comparator.IntComparator, 1, 2, 3))
+
+	assert.Equal(3, safeList.Size())
+	assert.Equal([]int{1, 2, 3}, safeList.ToArray())
+}
+
+func TestListConcurrentAdd(t *testing.T) {
+	assert := assert.New(t)
+	safeList := NewList[int](linkedlist.New(comparator.IntComparator))
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				safeList.Add(i)
+				safeList.Contains(i)
+				safeList.Size()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(workers*iterations, safeList.Size())
+}
+
+func TestListAddIfAbsent(t *testing.T) {
+	assert := assert.New(t)
+	safeList := NewList[int](arraylist.New(comparator.IntComparator))
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				safeList.AddIfAbsent(i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(iterations, safeList.Size())
+	assert.False(safeList.AddIfAbsent(0))
+	assert.True(safeList.AddIfAbsent(-1))
+}
+
+func TestListComputeIfPresent(t *testing.T) {
+	assert := assert.New(t)
+	safeList := NewList[int](arraylist.New(comparator.IntComparator, 0))
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				// Every element is greater than or equal to 0, so the first one is always updated
+				safeList.ComputeIfPresent(safeList.ToArray()[0], func(current int) int { return current + 1 })
+			}
+		}()
+	}
+	wg.Wait()
+
+	value, _ := safeList.At(0)
+	assert.Equal(1, safeList.Size())
+	assert.Greater(value, 0)
+	assert.False(safeList.ComputeIfPresent(-1, func(current int) int { return current }))
+}
+
+func TestListCallbacksCanModify(t *testing.T) {
+	assert := assert.New(t)
+	safeList := NewList[int](arraylist.New(comparator.IntComparator, 1, 2, 3))
+
+	safeList.ForEach(func(element int, index int) {
+		safeList.Add(element * 10)
+	})
+	assert.Equal([]int{1, 2, 3, 10, 20, 30}, safeList.ToArray())
+
+	for element := range safeList.Values() {
+		safeList.Remove(element)
+	}
+	assert.True(safeList.IsEmpty())
+}
+
+func TestListAddAllSelf(t *testing.T) {
+	assert := assert.New(t)
+	safeList := NewList[int](arraylist.New(comparator.IntComparator, 1, 2))
+
+	safeList.AddAll(safeList)
+
+	assert.Equal([]int{1, 2, 1, 2}, safeList.ToArray())
+	assert.True(safeList.ContainsAll(safeList))
+}
+
+func TestListDerivedListsAreConcurrent(t *testing.T) {
+	assert := assert.New(t)
+	safeList := NewList[int](arraylist.New(comparator.IntComparator, 3, 1, 2))
+
+	_, ok := safeList.Copy().(*List[int])
+	assert.True(ok)
+	filtered := safeList.Filter(func(element int) bool { return element > 1 })
+	_, ok = filtered.(*List[int])
+	assert.True(ok)
+	assert.Equal([]int{3, 2}, filtered.ToArray())
+
+	safeList.Sort()
+	assert.Equal([]int{1, 2, 3}, safeList.ToArray())
+}
+
+func TestListFilterEqualElements(t *testing.T) {
+	assert := assert.New(t)
+	type entry struct {
+		key   int
+		value string
+	}
+	byKey := comparator.Comparing(func(element entry) int { return element.key }, comparator.IntComparator)
+	safeList := NewList[entry](arraylist.New(byKey, entry{1, "x"}, entry{1, "y"}))
+
+	filtered := safeList.Filter(func(element entry) bool { return element.value == "x" })
+	assert.Equal([]entry{{1, "x"}}, filtered.ToArray())
+	assert.Equal(2, safeList.Size())
+}
+
+func TestListConcurrentReadWrite(t *testing.T) {
+	safeList := NewList[int](arraylist.New(comparator.IntComparator))
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				safeList.Add(i)
+				safeList.RemoveAt(0)
+				safeList.ReplaceAt(0, i)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				safeList.Some(func(element int, index int) bool { return element == i })
+				safeList.IndexOf(i)
+				safeList.At(0)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestListJSON(t *testing.T) {
+	assert := assert.New(t)
+	safeList := NewList[int](arraylist.New(comparator.IntComparator, 1, 2))
+
+	data, err := json.Marshal(safeList)
+	assert.Nil(err)
+	assert.Equal("[1,2]", string(data))
+
+	assert.Nil(json.Unmarshal([]byte("[3]"), safeList))
+	assert.Equal([]int{3}, safeList.ToArray())
+
+	var unwrapped List[int]
+	assert.NotNil(json.Unmarshal(data, &unwrapped))
+}
+
+func TestListString(t *testing.T) {
+	assert := assert.New(t)
+	safeList := NewList[int](arraylist.New(comparator.IntComparator, 1, 2))
+
+	assert.Equal("[1, 2]", safeList.String())
+	assert.Equal("[1, 2]", fmt.Sprint(safeList))
+	assert.Equal("ArrayList(size=2) [1, 2]", fmt.Sprintf("%+v", safeList))
+	assert.Equal(`&arraylist.ArrayList[int]{1, 2}`, fmt.Sprintf("%#v", safeList))
+	assert.Equal("[01, 02]", fmt.Sprintf("%02d", safeList))
+
+	var builder strings.Builder
+	count, err := safeList.WriteTo(&builder)
+	assert.Nil(err)
+	assert.Equal(int64(len("[1, 2]")), count)
+	assert.Equal("[1, 2]", builder.String())
+}
@@ -0,0 +1,200 @@
+package lockfree
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync/atomic"
+
+	"github.com/dterbah/gods/internal/format"
+)
+
+/*
+Struct that represents a node of the Queue. The value is cleared when the node
+becomes the sentinel, so that the Queue does not retain the dequeued elements.
+As other goroutines may still read it, it is accessed atomically
+*/
+type queueNode[T any] struct {
+	value atomic.Pointer[T]
+	next  atomic.Pointer[queueNode[T]]
+}
+
+/*
+Struct that defines what a lock-free Queue is. It is a multi-producer,
+multi-consumer FIFO queue based on the algorithm of Michael and Scott:
+the elements are stored in a linked list updated with compare-and-swap,
+so no goroutine ever waits for a lock. The first node is a sentinel
+*/
+type Queue[T any] struct {
+	head        atomic.Pointer[queueNode[T]]
+	tail        atomic.Pointer[queueNode[T]]
+	size        atomic.Int64
+	zeroElement T
+}
+
+/*
+Create a new lock-free Queue
+*/
+func NewQueue[T any]() *Queue[T] {
+	queue := &Queue[T]{}
+	sentinel := &queueNode[T]{}
+	queue.head.Store(sentinel)
+	queue.tail.Store(sentinel)
+	return queue
+}
+
+/*
+Dequeue the first element of the Queue. If it is empty, it returns an error
+*/
+func (queue *Queue[T]) Dequeue() (T, error) {
+	for {
+		head := queue.head.Load()
+		tail := queue.tail.Load()
+		next := head.next.Load()
+
+		if head != queue.head.Load() {
+			continue
+		}
+
+		if head == tail {
+			if next == nil {
+				return queue.zeroElement, errors.New("queue empty")
+			}
+
+			// The tail is lagging behind, help the producer to move it
+			queue.tail.CompareAndSwap(tail, next)
+			continue
+		}
+
+		// Only the goroutine moving the head to next clears its value,
+		// so the value is still present if the swap succeeds
+		value := next.value.Load()
+		if queue.head.CompareAndSwap(head, next) {
+			next.value.Store(nil)
+			queue.size.Add(-1)
+			return *value, nil
+		}
+	}
+}
+
+/*
+Enqueue elements inside the Queue. Each element is enqueued separately,
+so the elements of concurrent calls can be interleaved
+*/
+func (queue *Queue[T]) Enqueue(elements ...T) {
+	for _, element := range elements {
+		queue.enqueue(element)
+	}
+}
+
+/*
+Format a snapshot of the Queue for the fmt package: %v writes its elements,
+%+v also writes its size, and %#v writes a Go-syntax representation.
+The other verbs are applied to each element
+*/
+func (queue *Queue[T]) Format(state fmt.State, verb rune) {
+	format.Format(state, verb, queue, queue.describe())
+}
+
+/*
+Return true if no element is present in the Queue, else false
+*/
+func (queue *Queue[T]) IsEmpty() bool {
+	return queue.head.Load().next.Load() == nil
+}
+
+/*
+Return the first element of the Queue without removing it.
+If it is empty, it returns an error
+*/
+func (queue *Queue[T]) Peek() (T, error) {
+	for {
+		next := queue.head.Load().next.Load()
+		if next == nil {
+			return queue.zeroElement, errors.New("queue empty")
+		}
+
+		// The value is cleared if the element has been dequeued in the meantime
+		if value := next.value.Load(); value != nil {
+			return *value, nil
+		}
+	}
+}
+
+/*
+Return the number of elements in the Queue. While other goroutines
+modify the Queue, the result is only an approximation
+*/
+func (queue *Queue[T]) Size() int {
+	return max(int(queue.size.Load()), 0)
+}
+
+/*
+Return a snapshot of the elements of the Queue as a string, e.g. [1, 2, 3] from
+the first to the last. While other goroutines modify the Queue, the snapshot may
+include some of their changes only
+*/
+func (queue *Queue[T]) String() string {
+	return format.String(queue.describe())
+}
+
+/*
+Write the elements of the Queue, as returned by String, to the writer
+*/
+func (queue *Queue[T]) WriteTo(writer io.Writer) (int64, error) {
+	return format.WriteTo(writer, queue.describe())
+}
+
+// Private methods
+
+// Describe a snapshot of the queue for the format package
+func (queue *Queue[T]) describe() format.Container[int, T] {
+	elements := queue.snapshot()
+	return format.Container[int, T]{
+		Name:  "Queue",
+		Open:  "[",
+		Close: "]",
+		All:   slices.All(elements),
+		Size:  len(elements),
+	}
+}
+
+func (queue *Queue[T]) enqueue(element T) {
+	node := &queueNode[T]{}
+	node.value.Store(&element)
+
+	for {
+		tail := queue.tail.Load()
+		next := tail.next.Load()
+
+		if tail != queue.tail.Load() {
+			continue
+		}
+
+		if next != nil {
+			// The tail is lagging behind, help the other producer to move it
+			queue.tail.CompareAndSwap(tail, next)
+			continue
+		}
+
+		if tail.next.CompareAndSwap(nil, node) {
+			queue.tail.CompareAndSwap(tail, node)
+			queue.size.Add(1)
+			return
+		}
+	}
+}
+
+// Return the elements of the queue from the first to the last. The elements
+// dequeued during the traversal are skipped, the ones enqueued may be included
+func (queue *Queue[T]) snapshot() []T {
+	elements := []T{}
+	for node := queue.head.Load().next.Load(); node != nil; node = node.next.Load() {
+		if value := node.value.Load(); value != nil {
+			elements = append(elements, *value)
+		}
+	}
+
+	return elements
+}
@@ -0,0 +1,163 @@
+package lockfree
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dterbah/gods/concurrent"
+	"github.com/dterbah/gods/queue"
+	comparator "github.com/dterbah/gods/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	workers    = 8
+	iterations = 1000
+)
+
+func TestQueueDequeueReleasesElement(t *testing.T) {
+	assert := assert.New(t)
+	queue := NewQueue[*int]()
+	element := 1
+	queue.Enqueue(&element)
+
+	_, err := queue.Dequeue()
+	assert.Nil(err)
+	// The node of the dequeued element is the new sentinel, it must not retain it
+	assert.Nil(queue.head.Load().value.Load())
+}
+
+func TestQueueEnqueueDequeue(t *testing.T) {
+	assert := assert.New(t)
+	queue := NewQueue[int]()
+
+	assert.True(queue.IsEmpty())
+	_, err := queue.Dequeue()
+	assert.NotNil(err)
+	_, err = queue.Peek()
+	assert.NotNil(err)
+
+	queue.Enqueue(1, 2, 3)
+	assert.Equal(3, queue.Size())
+	assert.False(queue.IsEmpty())
+
+	element, err := queue.Peek()
+	assert.Nil(err)
+	assert.Equal(1, element)
+
+	for _, expected := range []int{1, 2, 3} {
+		element, err = queue.Dequeue()
+		assert.Nil(err)
+		assert.Equal(expected, element)
+	}
+
+	assert.True(queue.IsEmpty())
+	assert.Equal(0, queue.Size())
+}
+
+func TestQueueStress(t *testing.T) {
+	assert := assert.New(t)
+	queue := NewQueue[int]()
+
+	var producers sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		producers.Add(1)
+		go func() {
+			defer producers.Done()
+			for i := 0; i < iterations; i++ {
+				queue.Enqueue(worker*iterations + i)
+			}
+		}()
+	}
+
+	var consumers sync.WaitGroup
+	var mutex sync.Mutex
+	seen := make(map[int]int)
+	done := make(chan struct{})
+	for worker := 0; worker < workers; worker++ {
+		consumers.Add(1)
+		go func() {
+			defer consumers.Done()
+			last := make(map[int]int)
+			for {
+				element, err := queue.Dequeue()
+				if err != nil {
+					select {
+					case <-done:
+						if queue.IsEmpty() {
+							return
+						}
+					default:
+					}
+					continue
+				}
+
+				// The elements of a single producer must come out in order
+				producer := element / iterations
+				if previous, ok := last[producer]; ok {
+					assert.Less(previous, element)
+				}
+				last[producer] = element
+
+				mutex.Lock()
+				seen[element]++
+				mutex.Unlock()
+			}
+		}()
+	}
+
+	producers.Wait()
+	close(done)
+	consumers.Wait()
+
+	assert.Len(seen, workers*iterations)
+	for _, count := range seen {
+		assert.Equal(1, count)
+	}
+	assert.True(queue.IsEmpty())
+	assert.Equal(0, queue.Size())
+}
+
+func BenchmarkQueueLockFree(b *testing.B) {
+	queue := NewQueue[int]()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			queue.Enqueue(1)
+			queue.Dequeue()
+		}
+	})
+}
+
+func BenchmarkQueueMutex(b *testing.B) {
+	queue := concurrent.NewQueue(queue.New(comparator.IntComparator))
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			queue.Enqueue(1)
+			queue.Dequeue()
+		}
+	})
+}
+
+func TestQueueString(t *testing.T) {
+	assert := assert.New(t)
+	queue := NewQueue[int]()
+	assert.Equal("[]", queue.String())
+
+	queue.Enqueue(1, 2, 3)
+	queue.Dequeue()
+
+	assert.Equal("[2, 3]", queue.String())
+	assert.Equal("[2, 3]", fmt.Sprint(queue))
+	assert.Equal("Queue(size=2) [2, 3]", fmt.Sprintf("%+v", queue))
+	assert.Equal(`&lockfree.Queue[int]{2, 3}`, fmt.Sprintf("%#v", queue))
+
+	var builder strings.Builder
+	count, err := queue.WriteTo(&builder)
+	assert.Nil(err)
+	assert.Equal(int64(len("[2, 3]")), count)
+	assert.Equal("[2, 3]", builder.String())
+}
@@ -0,0 +1,159 @@
+package lockfree
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"sync/atomic"
+
+	"github.com/dterbah/gods/internal/format"
+)
+
+/*
+Struct that represents a node of the Stack
+*/
+type stackNode[T any] struct {
+	value T
+	next  *stackNode[T]
+}
+
+/*
+Struct that defines what a lock-free Stack is. It is a Treiber stack:
+the elements are stored in a linked list whose top is replaced with
+compare-and-swap, so no goroutine ever waits for a lock
+*/
+type Stack[T any] struct {
+	top         atomic.Pointer[stackNode[T]]
+	size        atomic.Int64
+	zeroElement T
+}
+
+/*
+Create a new lock-free Stack
+*/
+func NewStack[T any]() *Stack[T] {
+	return &Stack[T]{}
+}
+
+/*
+Format a snapshot of the Stack for the fmt package: %v writes its elements,
+%+v also writes its size, and %#v writes a Go-syntax representation.
+The other verbs are applied to each element
+*/
+func (stack *Stack[T]) Format(state fmt.State, verb rune) {
+	format.Format(state, verb, stack, stack.describe())
+}
+
+/*
+Return true if the Stack is empty, else false
+*/
+func (stack *Stack[T]) IsEmpty() bool {
+	return stack.top.Load() == nil
+}
+
+/*
+Return the last element of the Stack without removing it. If the
+Stack is empty, this method will return an error
+*/
+func (stack *Stack[T]) Peek() (T, error) {
+	top := stack.top.Load()
+	if top == nil {
+		return stack.zeroElement, errors.New("empty stack")
+	}
+
+	return top.value, nil
+}
+
+/*
+Return the last element of the Stack and remove it from the Stack.
+It will return an error if the Stack is empty
+*/
+func (stack *Stack[T]) Pop() (T, error) {
+	for {
+		top := stack.top.Load()
+		if top == nil {
+			return stack.zeroElement, errors.New("empty stack")
+		}
+
+		if stack.top.CompareAndSwap(top, top.next) {
+			stack.size.Add(-1)
+			return top.value, nil
+		}
+	}
+}
+
+/*
+Push elements in the Stack. The elements of a single call are pushed
+atomically, the last one ending on the top
+*/
+func (stack *Stack[T]) Push(elements ...T) {
+	if len(elements) == 0 {
+		return
+	}
+
+	// Link the new nodes together, then publish them with a single swap
+	bottom := &stackNode[T]{value: elements[0]}
+	top := bottom
+	for _, element := range elements[1:] {
+		top = &stackNode[T]{value: element, next: top}
+	}
+
+	for {
+		current := stack.top.Load()
+		bottom.next = current
+		if stack.top.CompareAndSwap(current, top) {
+			stack.size.Add(int64(len(elements)))
+			return
+		}
+	}
+}
+
+/*
+Return the number of elements in the Stack. While other goroutines
+modify the Stack, the result is only an approximation
+*/
+func (stack *Stack[T]) Size() int {
+	return max(int(stack.size.Load()), 0)
+}
+
+/*
+Return a snapshot of the elements of the Stack as a string, e.g. [1, 2, 3]
+from the bottom to the top
+*/
+func (stack *Stack[T]) String() string {
+	return format.String(stack.describe())
+}
+
+/*
+Write the elements of the Stack, as returned by String, to the writer
+*/
+func (stack *Stack[T]) WriteTo(writer io.Writer) (int64, error) {
+	return format.WriteTo(writer, stack.describe())
+}
+
+// Private methods
+
+// Describe a snapshot of the stack for the format package
+func (stack *Stack[T]) describe() format.Container[int, T] {
+	elements := stack.snapshot()
+	return format.Container[int, T]{
+		Name:  "Stack",
+		Open:  "[",
+		Close: "]",
+		All:   slices.All(elements),
+		Size:  len(elements),
+	}
+}
+
+// Return the elements of the stack from the bottom to the top. The nodes
+// are never modified once pushed, so the snapshot is the stack at a given time
+func (stack *Stack[T]) snapshot() []T {
+	elements := []T{}
+	for node := stack.top.Load(); node != nil; node = node.next {
+		elements = append(elements, node.value)
+	}
+
+	slices.Reverse(elements)
+	return elements
+}
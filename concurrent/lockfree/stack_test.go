@@ -0,0 +1,150 @@
+package lockfree
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dterbah/gods/concurrent"
+	"github.com/dterbah/gods/stack"
+	comparator "github.com/dterbah/gods/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStackPushPop(t *testing.T) {
+	assert := assert.New(t)
+	stack := NewStack[int]()
+
+	assert.True(stack.IsEmpty())
+	_, err := stack.Pop()
+	assert.NotNil(err)
+	_, err = stack.Peek()
+	assert.NotNil(err)
+
+	stack.Push(1, 2, 3)
+	stack.Push()
+	assert.Equal(3, stack.Size())
+
+	element, err := stack.Peek()
+	assert.Nil(err)
+	assert.Equal(3, element)
+
+	for _, expected := range []int{3, 2, 1} {
+		element, err = stack.Pop()
+		assert.Nil(err)
+		assert.Equal(expected, element)
+	}
+
+	assert.True(stack.IsEmpty())
+	assert.Equal(0, stack.Size())
+}
+
+func TestStackStress(t *testing.T) {
+	assert := assert.New(t)
+	stack := NewStack[int]()
+
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	seen := make(map[int]int)
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				stack.Push(worker*iterations + i)
+				if i%2 == 0 {
+					continue
+				}
+
+				element, err := stack.Pop()
+				if assert.Nil(err) {
+					mutex.Lock()
+					seen[element]++
+					mutex.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	for {
+		element, err := stack.Pop()
+		if err != nil {
+			break
+		}
+		seen[element]++
+	}
+
+	assert.Len(seen, workers*iterations)
+	for _, count := range seen {
+		assert.Equal(1, count)
+	}
+	assert.Equal(0, stack.Size())
+}
+
+func TestStackPushIsAtomic(t *testing.T) {
+	assert := assert.New(t)
+	stack := NewStack[int]()
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				stack.Push(worker, worker)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// The two elements pushed by a single call are always adjacent
+	for !stack.IsEmpty() {
+		first, _ := stack.Pop()
+		second, _ := stack.Pop()
+		assert.Equal(first, second)
+	}
+}
+
+func BenchmarkStackLockFree(b *testing.B) {
+	stack := NewStack[int]()
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			stack.Push(1)
+			stack.Pop()
+		}
+	})
+}
+
+func BenchmarkStackMutex(b *testing.B) {
+	stack := concurrent.NewStack(stack.New(comparator.IntComparator))
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			stack.Push(1)
+			stack.Pop()
+		}
+	})
+}
+
+func TestStackString(t *testing.T) {
+	assert := assert.New(t)
+	stack := NewStack[int]()
+	assert.Equal("[]", stack.String())
+
+	stack.Push(1, 2, 3)
+	stack.Pop()
+
+	assert.Equal("[1, 2]", stack.String())
+	assert.Equal("[1, 2]", fmt.Sprint(stack))
+	assert.Equal("Stack(size=2) [1, 2]", fmt.Sprintf("%+v", stack))
+	assert.Equal(`&lockfree.Stack[int]{1, 2}`, fmt.Sprintf("%#v", stack))
+
+	var builder strings.Builder
+	count, err := stack.WriteTo(&builder)
+	assert.Nil(err)
+	assert.Equal(int64(len("[1, 2]")), count)
+	assert.Equal("[1, 2]", builder.String())
+}
@@ -0,0 +1,299 @@
+package concurrent
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"sync"
+
+	"github.com/dterbah/gods/list"
+	"github.com/dterbah/gods/maps"
+	"github.com/dterbah/gods/set"
+)
+
+/*
+Struct that wraps a maps.Map to make it safe for concurrent use.
+The reads are guarded by a shared lock and the writes by an exclusive one.
+The callbacks and the iterators work on a snapshot of the map, so they
+can safely call the methods of the map
+*/
+type Map[K any, V any] struct {
+	mutex   sync.RWMutex
+	entries maps.Map[K, V]
+}
+
+/*
+Wrap a map to make it safe for concurrent use. The wrapped map
+must not be used directly anymore
+*/
+func NewMap[K any, V any](entries maps.Map[K, V]) *Map[K, V] {
+	return &Map[K, V]{entries: entries}
+}
+
+/*
+Return an iterator over a snapshot of the keys and the values of the map
+*/
+func (safeMap *Map[K, V]) All() iter.Seq2[K, V] {
+	entries := safeMap.Entries()
+
+	return func(yield func(K, V) bool) {
+		for _, entry := range entries {
+			if !yield(entry.Key, entry.Value) {
+				return
+			}
+		}
+	}
+}
+
+/*
+Remove all the entries of the map
+*/
+func (safeMap *Map[K, V]) Clear() {
+	safeMap.mutex.Lock()
+	defer safeMap.mutex.Unlock()
+
+	safeMap.entries.Clear()
+}
+
+/*
+Replace the value associated to the key by the result of the remapping function,
+called with the key and the current value. Return the new value and true if the
+key was present, else a zero value and false
+*/
+func (safeMap *Map[K, V]) ComputeIfPresent(key K, remapping func(key K, current V) V) (V, bool) {
+	safeMap.mutex.Lock()
+	defer safeMap.mutex.Unlock()
+
+	current, err := safeMap.entries.Get(key)
+	if err != nil {
+		return current, false
+	}
+
+	value := remapping(key, current)
+	safeMap.entries.Put(key, value)
+	return value, true
+}
+
+/*
+Return true if the map contains an entry for the key, else false
+*/
+func (safeMap *Map[K, V]) ContainsKey(key K) bool {
+	safeMap.mutex.RLock()
+	defer safeMap.mutex.RUnlock()
+
+	return safeMap.entries.ContainsKey(key)
+}
+
+/*
+Return a snapshot of the entries of the map
+*/
+func (safeMap *Map[K, V]) Entries() []maps.Entry[K, V] {
+	safeMap.mutex.RLock()
+	defer safeMap.mutex.RUnlock()
+
+	return safeMap.entries.Entries()
+}
+
+/*
+Call a function for each entry of a snapshot of the map
+*/
+func (safeMap *Map[K, V]) ForEach(callback func(key K, value V)) {
+	for key, value := range safeMap.All() {
+		callback(key, value)
+	}
+}
+
+/*
+Format the wrapped map for the fmt package, with the same verbs and flags
+*/
+func (safeMap *Map[K, V]) Format(state fmt.State, verb rune) {
+	safeMap.mutex.RLock()
+	defer safeMap.mutex.RUnlock()
+
+	fmt.Fprintf(state, fmt.FormatString(state, verb), safeMap.entries)
+}
+
+/*
+Retrieve the value associated to the key.
+If the key is not present in the map, the method will return an error
+*/
+func (safeMap *Map[K, V]) Get(key K) (V, error) {
+	safeMap.mutex.RLock()
+	defer safeMap.mutex.RUnlock()
+
+	return safeMap.entries.Get(key)
+}
+
+/*
+Decode the wrapped map from the gob encoding, see UnmarshalBinary
+*/
+func (safeMap *Map[K, V]) GobDecode(data []byte) error {
+	return safeMap.UnmarshalBinary(data)
+}
+
+/*
+Encode the wrapped map for gob, see MarshalBinary
+*/
+func (safeMap *Map[K, V]) GobEncode() ([]byte, error) {
+	return safeMap.MarshalBinary()
+}
+
+/*
+Return true if the map has no entries, else false
+*/
+func (safeMap *Map[K, V]) IsEmpty() bool {
+	safeMap.mutex.RLock()
+	defer safeMap.mutex.RUnlock()
+
+	return safeMap.entries.IsEmpty()
+}
+
+/*
+Return a snapshot of the keys of the map in a Set
+*/
+func (safeMap *Map[K, V]) Keys() set.BasicSet[K] {
+	safeMap.mutex.RLock()
+	defer safeMap.mutex.RUnlock()
+
+	return safeMap.entries.Keys()
+}
+
+/*
+Encode the wrapped map in its binary format. If the map
+cannot be encoded in binary, this method will return an error
+*/
+func (safeMap *Map[K, V]) MarshalBinary() ([]byte, error) {
+	safeMap.mutex.RLock()
+	defer safeMap.mutex.RUnlock()
+
+	marshaler, ok := any(safeMap.entries).(encoding.BinaryMarshaler)
+	if !ok {
+		return nil, errors.New("wrapped map cannot be encoded in binary")
+	}
+
+	return marshaler.MarshalBinary()
+}
+
+/*
+Encode the wrapped map in JSON
+*/
+func (safeMap *Map[K, V]) MarshalJSON() ([]byte, error) {
+	safeMap.mutex.RLock()
+	defer safeMap.mutex.RUnlock()
+
+	return json.Marshal(safeMap.entries)
+}
+
+/*
+Associate the value to the key. If the key is already present in
+the map, its value is replaced
+*/
+func (safeMap *Map[K, V]) Put(key K, value V) {
+	safeMap.mutex.Lock()
+	defer safeMap.mutex.Unlock()
+
+	safeMap.entries.Put(key, value)
+}
+
+/*
+Associate the value to the key if the key is not already present in the map.
+Return the value associated to the key and true if the value was put, else false
+*/
+func (safeMap *Map[K, V]) PutIfAbsent(key K, value V) (V, bool) {
+	safeMap.mutex.Lock()
+	defer safeMap.mutex.Unlock()
+
+	if current, err := safeMap.entries.Get(key); err == nil {
+		return current, false
+	}
+
+	safeMap.entries.Put(key, value)
+	return value, true
+}
+
+/*
+Remove the entry of the key. Return true if the entry was removed, else false
+*/
+func (safeMap *Map[K, V]) Remove(key K) bool {
+	safeMap.mutex.Lock()
+	defer safeMap.mutex.Unlock()
+
+	return safeMap.entries.Remove(key)
+}
+
+/*
+Return the number of entries in the map
+*/
+func (safeMap *Map[K, V]) Size() int {
+	safeMap.mutex.RLock()
+	defer safeMap.mutex.RUnlock()
+
+	return safeMap.entries.Size()
+}
+
+/*
+Return the wrapped map as a string
+*/
+func (safeMap *Map[K, V]) String() string {
+	safeMap.mutex.RLock()
+	defer safeMap.mutex.RUnlock()
+
+	return fmt.Sprint(safeMap.entries)
+}
+
+/*
+Decode the binary format of the wrapped map, replacing its content.
+The wrapper must be created with NewMap before
+*/
+func (safeMap *Map[K, V]) UnmarshalBinary(data []byte) error {
+	safeMap.mutex.Lock()
+	defer safeMap.mutex.Unlock()
+
+	if safeMap.entries == nil {
+		return errors.New("no wrapped map, create the wrapper with NewMap before decoding")
+	}
+
+	unmarshaler, ok := any(safeMap.entries).(encoding.BinaryUnmarshaler)
+	if !ok {
+		return errors.New("wrapped map cannot be decoded from binary")
+	}
+
+	return unmarshaler.UnmarshalBinary(data)
+}
+
+/*
+Decode JSON into the wrapped map, replacing its content. The implementation
+of the map cannot be guessed, so the wrapper must be created with NewMap before
+*/
+func (safeMap *Map[K, V]) UnmarshalJSON(data []byte) error {
+	safeMap.mutex.Lock()
+	defer safeMap.mutex.Unlock()
+
+	if safeMap.entries == nil {
+		return errors.New("no wrapped map, create the wrapper with NewMap before decoding")
+	}
+
+	return json.Unmarshal(data, safeMap.entries)
+}
+
+/*
+Return a snapshot of the values of the map in a List. The returned list has no
+comparator, so the methods relying on it (Contains, IndexOf, Remove, Sort) must not be used
+*/
+func (safeMap *Map[K, V]) Values() list.List[V] {
+	safeMap.mutex.RLock()
+	defer safeMap.mutex.RUnlock()
+
+	return safeMap.entries.Values()
+}
+
+/*
+Write the wrapped map, as returned by String, to the writer
+*/
+func (safeMap *Map[K, V]) WriteTo(writer io.Writer) (int64, error) {
+	count, err := io.WriteString(writer, safeMap.String())
+	return int64(count), err
+}
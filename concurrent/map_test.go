@@ -0,0 +1,115 @@
+package concurrent
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/dterbah/gods/maps"
+	"github.com/dterbah/gods/maps/hashmap"
+	"github.com/dterbah/gods/maps/treemap"
+	comparator "github.com/dterbah/gods/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapImplementsMap(t *testing.T) {
+	assert := assert.New(t)
+	var safeMap maps.Map[string, int] = NewMap[string, int](hashmap.New[string, int]())
+	safeMap.Put("a", 1)
+
+	value, err := safeMap.Get("a")
+	assert.Nil(err)
+	assert.Equal(1, value)
+}
+
+func TestMapJSON(t *testing.T) {
+	assert := assert.New(t)
+	safeMap := NewMap[int, int](treemap.New[int, int](comparator.IntComparator))
+	safeMap.Put(1, 10)
+
+	data, err := json.Marshal(safeMap)
+	assert.Nil(err)
+	assert.Equal(`[{"key":1,"value":10}]`, string(data))
+
+	assert.Nil(json.Unmarshal([]byte(`[{"key":2,"value":20}]`), safeMap))
+	assert.False(safeMap.ContainsKey(1))
+	assert.True(safeMap.ContainsKey(2))
+}
+
+func TestMapPutIfAbsent(t *testing.T) {
+	assert := assert.New(t)
+	safeMap := NewMap[int, int](treemap.New[int, int](comparator.IntComparator))
+
+	value, ok := safeMap.PutIfAbsent(1, 10)
+	assert.True(ok)
+	assert.Equal(10, value)
+
+	value, ok = safeMap.PutIfAbsent(1, 20)
+	assert.False(ok)
+	assert.Equal(10, value)
+}
+
+func TestMapComputeIfPresent(t *testing.T) {
+	assert := assert.New(t)
+	safeMap := NewMap[string, int](hashmap.New[string, int]())
+	safeMap.Put("counter", 0)
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				safeMap.ComputeIfPresent("counter", func(key string, current int) int { return current + 1 })
+			}
+		}()
+	}
+	wg.Wait()
+
+	value, _ := safeMap.Get("counter")
+	assert.Equal(workers*iterations, value)
+
+	_, ok := safeMap.ComputeIfPresent("missing", func(key string, current int) int { return current })
+	assert.False(ok)
+	assert.False(safeMap.ContainsKey("missing"))
+}
+
+func TestMapCallbacksCanModify(t *testing.T) {
+	assert := assert.New(t)
+	safeMap := NewMap[int, int](treemap.New[int, int](comparator.IntComparator))
+	safeMap.Put(1, 1)
+	safeMap.Put(2, 2)
+
+	safeMap.ForEach(func(key int, value int) {
+		safeMap.Remove(key)
+	})
+
+	assert.True(safeMap.IsEmpty())
+}
+
+func TestMapConcurrentReadWrite(t *testing.T) {
+	safeMap := NewMap[int, int](hashmap.New[int, int]())
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				safeMap.Put(i, i)
+				safeMap.Remove(i - 1)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				safeMap.Get(i)
+				safeMap.Keys()
+				safeMap.Values()
+				for range safeMap.All() {
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,278 @@
+package concurrent
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"sync"
+
+	"github.com/dterbah/gods/queue"
+)
+
+/*
+Struct that wraps a Queue to make it safe for concurrent use.
+The reads are guarded by a shared lock and the writes by an exclusive one.
+The callbacks and the iterators work on a snapshot of the queue, so they
+can safely call the methods of the queue
+*/
+type Queue[T any] struct {
+	mutex sync.RWMutex
+	queue *queue.Queue[T]
+}
+
+/*
+Wrap a queue to make it safe for concurrent use. The wrapped queue
+must not be used directly anymore
+*/
+func NewQueue[T any](queue *queue.Queue[T]) *Queue[T] {
+	return &Queue[T]{queue: queue}
+}
+
+/*
+Return an iterator over a snapshot of the indexes and the elements of the queue
+*/
+func (queue *Queue[T]) All() iter.Seq2[int, T] {
+	return slices.All(queue.ToArray())
+}
+
+/*
+Remove all the elements of the queue
+*/
+func (queue *Queue[T]) Clear() {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	queue.queue.Clear()
+}
+
+/*
+Return true if the element is present inside the queue, else false
+*/
+func (queue *Queue[T]) Contains(element T) bool {
+	queue.mutex.RLock()
+	defer queue.mutex.RUnlock()
+
+	return queue.queue.Contains(element)
+}
+
+/*
+Create a copy of the queue, also safe for concurrent use
+*/
+func (queue *Queue[T]) Copy() *Queue[T] {
+	queue.mutex.RLock()
+	defer queue.mutex.RUnlock()
+
+	return NewQueue(queue.queue.Copy())
+}
+
+/*
+Dequeue the first element of the queue. If it is empty, it returns an error
+*/
+func (queue *Queue[T]) Dequeue() (T, error) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	return queue.queue.Dequeue()
+}
+
+/*
+Enqueue elements inside the queue
+*/
+func (queue *Queue[T]) Enqueue(elements ...T) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	queue.queue.Enqueue(elements...)
+}
+
+/*
+Enqueue the element if it is not already present inside the queue.
+Return true if the element was enqueued, else false
+*/
+func (queue *Queue[T]) EnqueueIfAbsent(element T) bool {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	if queue.queue.Contains(element) {
+		return false
+	}
+
+	queue.queue.Enqueue(element)
+	return true
+}
+
+/*
+Call a function for each element of a snapshot of the queue
+*/
+func (queue *Queue[T]) ForEach(callback func(element T, index int)) {
+	for index, element := range queue.All() {
+		callback(element, index)
+	}
+}
+
+/*
+Format the wrapped queue for the fmt package, with the same verbs and flags
+*/
+func (queue *Queue[T]) Format(state fmt.State, verb rune) {
+	queue.mutex.RLock()
+	defer queue.mutex.RUnlock()
+
+	fmt.Fprintf(state, fmt.FormatString(state, verb), queue.queue)
+}
+
+/*
+Decode the wrapped queue from the gob encoding, see UnmarshalBinary
+*/
+func (queue *Queue[T]) GobDecode(data []byte) error {
+	return queue.UnmarshalBinary(data)
+}
+
+/*
+Encode the wrapped queue for gob, see MarshalBinary
+*/
+func (queue *Queue[T]) GobEncode() ([]byte, error) {
+	return queue.MarshalBinary()
+}
+
+/*
+Return true if no element is present in the queue, else false
+*/
+func (queue *Queue[T]) IsEmpty() bool {
+	queue.mutex.RLock()
+	defer queue.mutex.RUnlock()
+
+	return queue.queue.IsEmpty()
+}
+
+/*
+Encode the wrapped queue in its binary format. If the queue
+cannot be encoded in binary, this method will return an error
+*/
+func (queue *Queue[T]) MarshalBinary() ([]byte, error) {
+	queue.mutex.RLock()
+	defer queue.mutex.RUnlock()
+
+	marshaler, ok := any(queue.queue).(encoding.BinaryMarshaler)
+	if !ok {
+		return nil, errors.New("wrapped queue cannot be encoded in binary")
+	}
+
+	return marshaler.MarshalBinary()
+}
+
+/*
+Encode the wrapped queue in JSON
+*/
+func (queue *Queue[T]) MarshalJSON() ([]byte, error) {
+	queue.mutex.RLock()
+	defer queue.mutex.RUnlock()
+
+	return json.Marshal(queue.queue)
+}
+
+/*
+Return the first element of the queue without removing it.
+If the queue is empty, it returns an error
+*/
+func (queue *Queue[T]) Peek() (T, error) {
+	queue.mutex.RLock()
+	defer queue.mutex.RUnlock()
+
+	return queue.queue.Peek()
+}
+
+func (queue *Queue[T]) Print() {
+	queue.mutex.RLock()
+	defer queue.mutex.RUnlock()
+
+	queue.queue.Print()
+}
+
+/*
+Return the current size of the queue
+*/
+func (queue *Queue[T]) Size() int {
+	queue.mutex.RLock()
+	defer queue.mutex.RUnlock()
+
+	return queue.queue.Size()
+}
+
+/*
+Return the wrapped queue as a string
+*/
+func (queue *Queue[T]) String() string {
+	queue.mutex.RLock()
+	defer queue.mutex.RUnlock()
+
+	return fmt.Sprint(queue.queue)
+}
+
+/*
+Return a snapshot of the elements of the queue, from the first to the last
+*/
+func (queue *Queue[T]) ToArray() []T {
+	queue.mutex.RLock()
+	defer queue.mutex.RUnlock()
+
+	elements := make([]T, 0, queue.queue.Size())
+	for element := range queue.queue.Values() {
+		elements = append(elements, element)
+	}
+
+	return elements
+}
+
+/*
+Decode the binary format of the wrapped queue, replacing its content.
+The wrapper must be created with NewQueue before
+*/
+func (queue *Queue[T]) UnmarshalBinary(data []byte) error {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	if queue.queue == nil {
+		return errors.New("no wrapped queue, create the wrapper with NewQueue before decoding")
+	}
+
+	unmarshaler, ok := any(queue.queue).(encoding.BinaryUnmarshaler)
+	if !ok {
+		return errors.New("wrapped queue cannot be decoded from binary")
+	}
+
+	return unmarshaler.UnmarshalBinary(data)
+}
+
+/*
+Decode JSON into the wrapped queue, replacing its content. The wrapper
+must be created with NewQueue before
+*/
+func (queue *Queue[T]) UnmarshalJSON(data []byte) error {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	if queue.queue == nil {
+		return errors.New("no wrapped queue, create the wrapper with NewQueue before decoding")
+	}
+
+	return json.Unmarshal(data, queue.queue)
+}
+
+/*
+Return an iterator over a snapshot of the elements of the queue
+*/
+func (queue *Queue[T]) Values() iter.Seq[T] {
+	return slices.Values(queue.ToArray())
+}
+
+/*
+Write the wrapped queue, as returned by String, to the writer
+*/
+func (queue *Queue[T]) WriteTo(writer io.Writer) (int64, error) {
+	count, err := io.WriteString(writer, queue.String())
+	return int64(count), err
+}
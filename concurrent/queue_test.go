@@ -0,0 +1,80 @@
+package concurrent
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/dterbah/gods/queue"
+	comparator "github.com/dterbah/gods/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueueBinary(t *testing.T) {
+	assert := assert.New(t)
+	safeQueue := NewQueue(queue.New(comparator.IntComparator))
+	safeQueue.Enqueue(1, 2)
+
+	data, err := safeQueue.MarshalBinary()
+	assert.Nil(err)
+
+	decoded := NewQueue(queue.New(comparator.IntComparator))
+	assert.Nil(decoded.UnmarshalBinary(data))
+	assert.Equal([]int{1, 2}, decoded.ToArray())
+
+	var unwrapped Queue[int]
+	assert.NotNil(unwrapped.UnmarshalBinary(data))
+}
+
+func TestQueueConcurrentEnqueueDequeue(t *testing.T) {
+	assert := assert.New(t)
+	safeQueue := NewQueue(queue.New(comparator.IntComparator))
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				safeQueue.Enqueue(i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(workers*iterations, safeQueue.Size())
+
+	var mutex sync.Mutex
+	dequeued := 0
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				if _, err := safeQueue.Dequeue(); err != nil {
+					return
+				}
+				mutex.Lock()
+				dequeued++
+				mutex.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(workers*iterations, dequeued)
+	assert.True(safeQueue.IsEmpty())
+}
+
+func TestQueueEnqueueIfAbsent(t *testing.T) {
+	assert := assert.New(t)
+	safeQueue := NewQueue(queue.New(comparator.IntComparator))
+
+	assert.True(safeQueue.EnqueueIfAbsent(1))
+	assert.False(safeQueue.EnqueueIfAbsent(1))
+	assert.True(safeQueue.EnqueueIfAbsent(2))
+
+	element, _ := safeQueue.Peek()
+	assert.Equal(1, element)
+	assert.Equal([]int{1, 2}, safeQueue.ToArray())
+	assert.Equal([]int{1, 2}, safeQueue.Copy().ToArray())
+}
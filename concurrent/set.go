@@ -0,0 +1,366 @@
+package concurrent
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"sync"
+
+	"github.com/dterbah/gods/collection"
+	"github.com/dterbah/gods/set"
+)
+
+/*
+Struct that wraps a set.BasicSet to make it safe for concurrent use.
+The reads are guarded by a shared lock and the writes by an exclusive one.
+The callbacks and the iterators work on a snapshot of the set, so they
+can safely call the methods of the set
+*/
+type Set[T any] struct {
+	mutex sync.RWMutex
+	set   set.BasicSet[T]
+}
+
+/*
+Wrap a set to make it safe for concurrent use. The wrapped set
+must not be used directly anymore
+*/
+func NewSet[T any](set set.BasicSet[T]) *Set[T] {
+	return &Set[T]{set: set}
+}
+
+/*
+Add elements in the set. If some elements are already
+present in the set, they won't be include a second time
+*/
+func (set *Set[T]) Add(elements ...T) {
+	set.mutex.Lock()
+	defer set.mutex.Unlock()
+
+	set.set.Add(elements...)
+}
+
+/*
+Add the element in the set if it is not already present.
+Return true if the element was added, else false
+*/
+func (set *Set[T]) AddIfAbsent(element T) bool {
+	set.mutex.Lock()
+	defer set.mutex.Unlock()
+
+	if set.set.Contains(element) {
+		return false
+	}
+
+	set.set.Add(element)
+	return true
+}
+
+/*
+Add all elements present in the collection
+*/
+func (set *Set[T]) AddAll(elements collection.Collection[T]) {
+	snapshot := elements.ToArray()
+
+	set.mutex.Lock()
+	defer set.mutex.Unlock()
+
+	set.set.Add(snapshot...)
+}
+
+/*
+Return an iterator over a snapshot of the indexes and the elements of the set
+*/
+func (set *Set[T]) All() iter.Seq2[int, T] {
+	return slices.All(set.ToArray())
+}
+
+/*
+Retrieve an element by its index
+If the index is negative or greater than the set size, the method will return an error
+*/
+func (set *Set[T]) At(index int) (T, error) {
+	set.mutex.RLock()
+	defer set.mutex.RUnlock()
+
+	return set.set.At(index)
+}
+
+/*
+Clear all the elements in the set
+*/
+func (set *Set[T]) Clear() {
+	set.mutex.Lock()
+	defer set.mutex.Unlock()
+
+	set.set.Clear()
+}
+
+/*
+Return true if the set contains the element, else false
+*/
+func (set *Set[T]) Contains(element T) bool {
+	set.mutex.RLock()
+	defer set.mutex.RUnlock()
+
+	return set.set.Contains(element)
+}
+
+/*
+Return true if the set contains all the elements of the collection, else false
+*/
+func (set *Set[T]) ContainsAll(elements collection.Collection[T]) bool {
+	snapshot := elements.ToArray()
+
+	set.mutex.RLock()
+	defer set.mutex.RUnlock()
+
+	for _, element := range snapshot {
+		if !set.set.Contains(element) {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+Create a copy of the set, also safe for concurrent use
+*/
+func (set *Set[T]) Copy() set.BasicSet[T] {
+	set.mutex.RLock()
+	defer set.mutex.RUnlock()
+
+	return NewSet(set.set.Copy())
+}
+
+/*
+Create a new set, safe for concurrent use, with all elements in the current set
+that are not present on the set passed in param
+*/
+func (set *Set[T]) Diff(otherSet set.BasicSet[T]) set.BasicSet[T] {
+	snapshot := otherSet.Copy()
+
+	set.mutex.RLock()
+	defer set.mutex.RUnlock()
+
+	return NewSet(set.set.Diff(snapshot))
+}
+
+/*
+Call a function for each element of a snapshot of the set
+*/
+func (set *Set[T]) ForEach(callback func(element T, index int)) {
+	for index, element := range set.All() {
+		callback(element, index)
+	}
+}
+
+/*
+Format the wrapped set for the fmt package, with the same verbs and flags
+*/
+func (set *Set[T]) Format(state fmt.State, verb rune) {
+	set.mutex.RLock()
+	defer set.mutex.RUnlock()
+
+	fmt.Fprintf(state, fmt.FormatString(state, verb), set.set)
+}
+
+/*
+Decode the wrapped set from the gob encoding, see UnmarshalBinary
+*/
+func (set *Set[T]) GobDecode(data []byte) error {
+	return set.UnmarshalBinary(data)
+}
+
+/*
+Encode the wrapped set for gob, see MarshalBinary
+*/
+func (set *Set[T]) GobEncode() ([]byte, error) {
+	return set.MarshalBinary()
+}
+
+/*
+Return the position of the element in the set, or -1 if it is not present
+*/
+func (set *Set[T]) IndexOf(element T) int {
+	set.mutex.RLock()
+	defer set.mutex.RUnlock()
+
+	return set.set.IndexOf(element)
+}
+
+/*
+Compute the intersection, safe for concurrent use, between the current set
+and the one passed in parameter
+*/
+func (set *Set[T]) Intersection(otherSet set.BasicSet[T]) set.BasicSet[T] {
+	snapshot := otherSet.Copy()
+
+	set.mutex.RLock()
+	defer set.mutex.RUnlock()
+
+	return NewSet(set.set.Intersection(snapshot))
+}
+
+/*
+Check if the set is empty or not. Return true if it is empty, otherwise false
+*/
+func (set *Set[T]) IsEmpty() bool {
+	set.mutex.RLock()
+	defer set.mutex.RUnlock()
+
+	return set.set.IsEmpty()
+}
+
+/*
+Return true if the set passed in param is a subset of the current set, else false
+*/
+func (set *Set[T]) IsSubset(otherSet set.BasicSet[T]) bool {
+	snapshot := otherSet.Copy()
+
+	set.mutex.RLock()
+	defer set.mutex.RUnlock()
+
+	return set.set.IsSubset(snapshot)
+}
+
+/*
+Encode the wrapped set in its binary format. If the set
+cannot be encoded in binary, this method will return an error
+*/
+func (set *Set[T]) MarshalBinary() ([]byte, error) {
+	set.mutex.RLock()
+	defer set.mutex.RUnlock()
+
+	marshaler, ok := any(set.set).(encoding.BinaryMarshaler)
+	if !ok {
+		return nil, errors.New("wrapped set cannot be encoded in binary")
+	}
+
+	return marshaler.MarshalBinary()
+}
+
+/*
+Encode the wrapped set in JSON
+*/
+func (set *Set[T]) MarshalJSON() ([]byte, error) {
+	set.mutex.RLock()
+	defer set.mutex.RUnlock()
+
+	return json.Marshal(set.set)
+}
+
+func (set *Set[T]) Print() {
+	set.mutex.RLock()
+	defer set.mutex.RUnlock()
+
+	set.set.Print()
+}
+
+/*
+Remove the element from the set if it exists
+*/
+func (set *Set[T]) Remove(element T) {
+	set.mutex.Lock()
+	defer set.mutex.Unlock()
+
+	set.set.Remove(element)
+}
+
+/*
+Return the size of the set
+*/
+func (set *Set[T]) Size() int {
+	set.mutex.RLock()
+	defer set.mutex.RUnlock()
+
+	return set.set.Size()
+}
+
+/*
+Return the wrapped set as a string
+*/
+func (set *Set[T]) String() string {
+	set.mutex.RLock()
+	defer set.mutex.RUnlock()
+
+	return fmt.Sprint(set.set)
+}
+
+/*
+Return a snapshot of the elements of the set
+*/
+func (set *Set[T]) ToArray() []T {
+	set.mutex.RLock()
+	defer set.mutex.RUnlock()
+
+	return set.set.ToArray()
+}
+
+/*
+Compute the union, safe for concurrent use, between the current set
+and the one passed in parameter
+*/
+func (set *Set[T]) Union(otherSet set.BasicSet[T]) set.BasicSet[T] {
+	snapshot := otherSet.Copy()
+
+	set.mutex.RLock()
+	defer set.mutex.RUnlock()
+
+	return NewSet(set.set.Union(snapshot))
+}
+
+/*
+Decode the binary format of the wrapped set, replacing its content.
+The wrapper must be created with NewSet before
+*/
+func (set *Set[T]) UnmarshalBinary(data []byte) error {
+	set.mutex.Lock()
+	defer set.mutex.Unlock()
+
+	if set.set == nil {
+		return errors.New("no wrapped set, create the wrapper with NewSet before decoding")
+	}
+
+	unmarshaler, ok := any(set.set).(encoding.BinaryUnmarshaler)
+	if !ok {
+		return errors.New("wrapped set cannot be decoded from binary")
+	}
+
+	return unmarshaler.UnmarshalBinary(data)
+}
+
+/*
+Decode JSON into the wrapped set, replacing its content. The implementation
+of the set cannot be guessed, so the wrapper must be created with NewSet before
+*/
+func (set *Set[T]) UnmarshalJSON(data []byte) error {
+	set.mutex.Lock()
+	defer set.mutex.Unlock()
+
+	if set.set == nil {
+		return errors.New("no wrapped set, create the wrapper with NewSet before decoding")
+	}
+
+	return json.Unmarshal(data, set.set)
+}
+
+/*
+Return an iterator over a snapshot of the elements of the set
+*/
+func (set *Set[T]) Values() iter.Seq[T] {
+	return slices.Values(set.ToArray())
+}
+
+/*
+Write the wrapped set, as returned by String, to the writer
+*/
+func (set *Set[T]) WriteTo(writer io.Writer) (int64, error) {
+	count, err := io.WriteString(writer, set.String())
+	return int64(count), err
+}
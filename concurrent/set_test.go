@@ -0,0 +1,88 @@
+package concurrent
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/dterbah/gods/set"
+	"github.com/dterbah/gods/set/treeset"
+	comparator "github.com/dterbah/gods/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetImplementsBasicSet(t *testing.T) {
+	assert := assert.New(t)
+	var safeSet set.BasicSet[int] = NewSet[int](set.New(comparator.IntComparator, 1, 2, 2))
+
+	assert.Equal(2, safeSet.Size())
+}
+
+func TestSetConcurrentAdd(t *testing.T) {
+	assert := assert.New(t)
+	safeSet := NewSet[int](treeset.New(comparator.IntComparator))
+
+	var wg sync.WaitGroup
+	added := make([]int, workers)
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				if safeSet.AddIfAbsent(i) {
+					added[worker]++
+				}
+				safeSet.Contains(i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	total := 0
+	for _, count := range added {
+		total += count
+	}
+
+	assert.Equal(iterations, safeSet.Size())
+	assert.Equal(iterations, total)
+}
+
+func TestSetOperationsWithSelf(t *testing.T) {
+	assert := assert.New(t)
+	safeSet := NewSet[int](treeset.New(comparator.IntComparator, 1, 2, 3))
+
+	assert.Equal([]int{1, 2, 3}, safeSet.Union(safeSet).ToArray())
+	assert.Equal([]int{1, 2, 3}, safeSet.Intersection(safeSet).ToArray())
+	assert.True(safeSet.Diff(safeSet).IsEmpty())
+	assert.True(safeSet.IsSubset(safeSet))
+	assert.True(safeSet.ContainsAll(safeSet))
+
+	_, ok := safeSet.Copy().(*Set[int])
+	assert.True(ok)
+}
+
+func TestSetConcurrentReadWrite(t *testing.T) {
+	safeSet := NewSet[int](treeset.New(comparator.IntComparator))
+	other := NewSet[int](treeset.New(comparator.IntComparator, 1, 2, 3))
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				safeSet.Add(i)
+				other.Add(i)
+				safeSet.Remove(i - 1)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				safeSet.Union(other)
+				other.Intersection(safeSet)
+				safeSet.ForEach(func(element int, index int) {})
+			}
+		}()
+	}
+	wg.Wait()
+}
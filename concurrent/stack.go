@@ -0,0 +1,280 @@
+package concurrent
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"sync"
+
+	"github.com/dterbah/gods/stack"
+)
+
+/*
+Struct that wraps a Stack to make it safe for concurrent use.
+The reads are guarded by a shared lock and the writes by an exclusive one.
+The callbacks and the iterators work on a snapshot of the stack, so they
+can safely call the methods of the stack
+*/
+type Stack[T any] struct {
+	mutex sync.RWMutex
+	stack *stack.Stack[T]
+}
+
+/*
+Wrap a stack to make it safe for concurrent use. The wrapped stack
+must not be used directly anymore
+*/
+func NewStack[T any](stack *stack.Stack[T]) *Stack[T] {
+	return &Stack[T]{stack: stack}
+}
+
+/*
+Return an iterator over a snapshot of the indexes and the elements of the stack
+*/
+func (stack *Stack[T]) All() iter.Seq2[int, T] {
+	return slices.All(stack.ToArray())
+}
+
+/*
+Clear all the elements in the stack
+*/
+func (stack *Stack[T]) Clear() {
+	stack.mutex.Lock()
+	defer stack.mutex.Unlock()
+
+	stack.stack.Clear()
+}
+
+/*
+Returns true if the stack contains the specified element,
+else false
+*/
+func (stack *Stack[T]) Contains(element T) bool {
+	stack.mutex.RLock()
+	defer stack.mutex.RUnlock()
+
+	return stack.stack.Contains(element)
+}
+
+/*
+Create a copy of the stack, also safe for concurrent use
+*/
+func (stack *Stack[T]) Copy() *Stack[T] {
+	stack.mutex.RLock()
+	defer stack.mutex.RUnlock()
+
+	return NewStack(stack.stack.Copy())
+}
+
+/*
+Call a function for each element of a snapshot of the stack
+*/
+func (stack *Stack[T]) ForEach(callback func(element T, index int)) {
+	for index, element := range stack.All() {
+		callback(element, index)
+	}
+}
+
+/*
+Format the wrapped stack for the fmt package, with the same verbs and flags
+*/
+func (stack *Stack[T]) Format(state fmt.State, verb rune) {
+	stack.mutex.RLock()
+	defer stack.mutex.RUnlock()
+
+	fmt.Fprintf(state, fmt.FormatString(state, verb), stack.stack)
+}
+
+/*
+Decode the wrapped stack from the gob encoding, see UnmarshalBinary
+*/
+func (stack *Stack[T]) GobDecode(data []byte) error {
+	return stack.UnmarshalBinary(data)
+}
+
+/*
+Encode the wrapped stack for gob, see MarshalBinary
+*/
+func (stack *Stack[T]) GobEncode() ([]byte, error) {
+	return stack.MarshalBinary()
+}
+
+/*
+Return true if the stack is empty, else false
+*/
+func (stack *Stack[T]) IsEmpty() bool {
+	stack.mutex.RLock()
+	defer stack.mutex.RUnlock()
+
+	return stack.stack.IsEmpty()
+}
+
+/*
+Encode the wrapped stack in its binary format. If the stack
+cannot be encoded in binary, this method will return an error
+*/
+func (stack *Stack[T]) MarshalBinary() ([]byte, error) {
+	stack.mutex.RLock()
+	defer stack.mutex.RUnlock()
+
+	marshaler, ok := any(stack.stack).(encoding.BinaryMarshaler)
+	if !ok {
+		return nil, errors.New("wrapped stack cannot be encoded in binary")
+	}
+
+	return marshaler.MarshalBinary()
+}
+
+/*
+Encode the wrapped stack in JSON
+*/
+func (stack *Stack[T]) MarshalJSON() ([]byte, error) {
+	stack.mutex.RLock()
+	defer stack.mutex.RUnlock()
+
+	return json.Marshal(stack.stack)
+}
+
+/*
+Return the last element of the stack without removing it. If the
+stack is empty, this method will return an error
+*/
+func (stack *Stack[T]) Peek() (T, error) {
+	stack.mutex.RLock()
+	defer stack.mutex.RUnlock()
+
+	return stack.stack.Peek()
+}
+
+/*
+Return the last element of the stack and remove it from the stack.
+It will return an error if the stack is empty
+*/
+func (stack *Stack[T]) Pop() (T, error) {
+	stack.mutex.Lock()
+	defer stack.mutex.Unlock()
+
+	return stack.stack.Pop()
+}
+
+func (stack *Stack[T]) Print() {
+	stack.mutex.RLock()
+	defer stack.mutex.RUnlock()
+
+	stack.stack.Print()
+}
+
+/*
+Push elements in the stack
+*/
+func (stack *Stack[T]) Push(elements ...T) {
+	stack.mutex.Lock()
+	defer stack.mutex.Unlock()
+
+	stack.stack.Push(elements...)
+}
+
+/*
+Push the element in the stack if it is not already present.
+Return true if the element was pushed, else false
+*/
+func (stack *Stack[T]) PushIfAbsent(element T) bool {
+	stack.mutex.Lock()
+	defer stack.mutex.Unlock()
+
+	if stack.stack.Contains(element) {
+		return false
+	}
+
+	stack.stack.Push(element)
+	return true
+}
+
+/*
+Return the number of elements in the stack
+*/
+func (stack *Stack[T]) Size() int {
+	stack.mutex.RLock()
+	defer stack.mutex.RUnlock()
+
+	return stack.stack.Size()
+}
+
+/*
+Return the wrapped stack as a string
+*/
+func (stack *Stack[T]) String() string {
+	stack.mutex.RLock()
+	defer stack.mutex.RUnlock()
+
+	return fmt.Sprint(stack.stack)
+}
+
+/*
+Return a snapshot of the elements of the stack, from the bottom to the top
+*/
+func (stack *Stack[T]) ToArray() []T {
+	stack.mutex.RLock()
+	defer stack.mutex.RUnlock()
+
+	elements := make([]T, 0, stack.stack.Size())
+	for element := range stack.stack.Values() {
+		elements = append(elements, element)
+	}
+
+	return elements
+}
+
+/*
+Decode the binary format of the wrapped stack, replacing its content.
+The wrapper must be created with NewStack before
+*/
+func (stack *Stack[T]) UnmarshalBinary(data []byte) error {
+	stack.mutex.Lock()
+	defer stack.mutex.Unlock()
+
+	if stack.stack == nil {
+		return errors.New("no wrapped stack, create the wrapper with NewStack before decoding")
+	}
+
+	unmarshaler, ok := any(stack.stack).(encoding.BinaryUnmarshaler)
+	if !ok {
+		return errors.New("wrapped stack cannot be decoded from binary")
+	}
+
+	return unmarshaler.UnmarshalBinary(data)
+}
+
+/*
+Decode JSON into the wrapped stack, replacing its content. The wrapper
+must be created with NewStack before
+*/
+func (stack *Stack[T]) UnmarshalJSON(data []byte) error {
+	stack.mutex.Lock()
+	defer stack.mutex.Unlock()
+
+	if stack.stack == nil {
+		return errors.New("no wrapped stack, create the wrapper with NewStack before decoding")
+	}
+
+	return json.Unmarshal(data, stack.stack)
+}
+
+/*
+Return an iterator over a snapshot of the elements of the stack
+*/
+func (stack *Stack[T]) Values() iter.Seq[T] {
+	return slices.Values(stack.ToArray())
+}
+
+/*
+Write the wrapped stack, as returned by String, to the writer
+*/
+func (stack *Stack[T]) WriteTo(writer io.Writer) (int64, error) {
+	count, err := io.WriteString(writer, stack.String())
+	return int64(count), err
+}
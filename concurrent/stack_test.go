@@ -0,0 +1,52 @@
+package concurrent
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/dterbah/gods/stack"
+	comparator "github.com/dterbah/gods/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStackConcurrentPushPop(t *testing.T) {
+	assert := assert.New(t)
+	safeStack := NewStack(stack.New(comparator.IntComparator))
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				safeStack.Push(i)
+				safeStack.Peek()
+				safeStack.Pop()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.True(safeStack.IsEmpty())
+}
+
+func TestStackPushIfAbsent(t *testing.T) {
+	assert := assert.New(t)
+	safeStack := NewStack(stack.New(comparator.IntComparator))
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < workers; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				safeStack.PushIfAbsent(i)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(iterations, safeStack.Size())
+	assert.False(safeStack.PushIfAbsent(0))
+	assert.Equal(iterations, safeStack.Copy().Size())
+}
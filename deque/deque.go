@@ -0,0 +1,451 @@
+package deque
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/dterbah/gods/collection"
+	"github.com/dterbah/gods/internal/format"
+	"github.com/dterbah/gods/internal/serialization"
+	"github.com/dterbah/gods/iterable"
+	comparator "github.com/dterbah/gods/utils"
+)
+
+/*
+Struct that represents what is a Deque (double-ended queue).
+The elements are stored in a ring buffer that grows when needed, so
+pushing and popping at both ends run in amortized O(1), as well as
+the indexed access
+*/
+type Deque[T any] struct {
+	elements    []T
+	head        int
+	size        int
+	zeroElement T
+	comparator  comparator.Comparator[T]
+}
+
+// Constants
+const minCapacity = 8
+
+/*
+Create a new Deque. The elements are pushed at the back of the deque
+*/
+func New[T any](comparator comparator.Comparator[T], elements ...T) *Deque[T] {
+	var zero T
+	deque := &Deque[T]{zeroElement: zero, comparator: comparator}
+	deque.PushBack(elements...)
+	return deque
+}
+
+/*
+Create a Deque from an iterable object
+*/
+func FromIterable[T any](iterable iterable.Iterable[T], comparator comparator.Comparator[T]) *Deque[T] {
+	deque := New(comparator)
+	iterable.ForEach(func(element T, index int) {
+		deque.PushBack(element)
+	})
+
+	return deque
+}
+
+/*
+Add elements at the back of the deque
+*/
+func (deque *Deque[T]) Add(elements ...T) {
+	deque.PushBack(elements...)
+}
+
+/*
+Add all the elements of the collection at the back of the deque
+*/
+func (deque *Deque[T]) AddAll(elements collection.Collection[T]) {
+	for i := 0; i < elements.Size(); i++ {
+		element, _ := elements.At(i)
+		deque.PushBack(element)
+	}
+}
+
+/*
+Return an iterator over the indexes and the elements of the deque, from the front to the back.
+The iteration stops as soon as the loop body breaks
+*/
+func (deque *Deque[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for index := 0; index < deque.size; index++ {
+			if !yield(index, deque.elements[deque.physicalIndex(index)]) {
+				return
+			}
+		}
+	}
+}
+
+/*
+Retrieve an element by its index, starting from the front of the deque
+If the index is negative or greater than the deque size, the method will return an error
+*/
+func (deque *Deque[T]) At(index int) (T, error) {
+	if deque.isOutOfBounds(index) {
+		return deque.zeroElement, errors.New("index out of bound")
+	}
+
+	return deque.elements[deque.physicalIndex(index)], nil
+}
+
+/*
+Remove all the elements of the deque
+*/
+func (deque *Deque[T]) Clear() {
+	deque.elements = nil
+	deque.head = 0
+	deque.size = 0
+}
+
+/*
+Return true if the deque contains at least one occurence of the element, else false
+*/
+func (deque *Deque[T]) Contains(element T) bool {
+	return deque.IndexOf(element) != -1
+}
+
+func (deque *Deque[T]) ContainsAll(collection collection.Collection[T]) bool {
+	for index := 0; index < collection.Size(); index++ {
+		value, _ := collection.At(index)
+		if !deque.Contains(value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+Create a copy of the current deque
+*/
+func (deque *Deque[T]) Copy() *Deque[T] {
+	newDeque := New(deque.comparator)
+	newDeque.PushBack(deque.ToArray()...)
+	return newDeque
+}
+
+/*
+Apply a function for each element of the deque, from the front to the back
+*/
+func (deque *Deque[T]) ForEach(callback func(element T, index int)) {
+	for index, element := range deque.All() {
+		callback(element, index)
+	}
+}
+
+/*
+Format the deque for the fmt package: %v writes its elements, %+v also writes
+its size, and %#v writes a Go-syntax representation.
+The other verbs are applied to each element
+*/
+func (deque *Deque[T]) Format(state fmt.State, verb rune) {
+	format.Format(state, verb, deque, deque.describe())
+}
+
+/*
+Decode the deque from the gob encoding, see UnmarshalBinary
+*/
+func (deque *Deque[T]) GobDecode(data []byte) error {
+	return deque.UnmarshalBinary(data)
+}
+
+/*
+Encode the deque for gob, see MarshalBinary
+*/
+func (deque *Deque[T]) GobEncode() ([]byte, error) {
+	return deque.MarshalBinary()
+}
+
+/*
+Return the index in the deque of the element (if the element exists in the deque)
+If the element is not present in the deque, the method will return -1.
+If the deque has no comparator, the elements are compared as in comparator.ResolveEquality
+*/
+func (deque *Deque[T]) IndexOf(element T) int {
+	equal := comparator.ResolveEquality(deque.comparator)
+	for index, value := range deque.All() {
+		if equal(value, element) == 0 {
+			return index
+		}
+	}
+
+	return -1
+}
+
+/*
+Return true if the deque has no elements, else false
+*/
+func (deque *Deque[T]) IsEmpty() bool {
+	return deque.size == 0
+}
+
+/*
+Encode the deque in a binary format made of a versioned header
+followed by the gob encoding of its elements
+*/
+func (deque *Deque[T]) MarshalBinary() ([]byte, error) {
+	return serialization.Marshal(deque.ToArray())
+}
+
+/*
+Encode the deque as a JSON array of its elements, from the front to the back
+*/
+func (deque *Deque[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(deque.ToArray())
+}
+
+/*
+Return the element at the back of the deque without removing it.
+If the deque is empty, it returns an error
+*/
+func (deque *Deque[T]) PeekBack() (T, error) {
+	if deque.IsEmpty() {
+		return deque.zeroElement, errors.New("deque empty")
+	}
+
+	return deque.elements[deque.physicalIndex(deque.size-1)], nil
+}
+
+/*
+Return the element at the front of the deque without removing it.
+If the deque is empty, it returns an error
+*/
+func (deque *Deque[T]) PeekFront() (T, error) {
+	if deque.IsEmpty() {
+		return deque.zeroElement, errors.New("deque empty")
+	}
+
+	return deque.elements[deque.head], nil
+}
+
+/*
+Remove and return the element at the back of the deque.
+If the deque is empty, it returns an error
+*/
+func (deque *Deque[T]) PopBack() (T, error) {
+	if deque.IsEmpty() {
+		return deque.zeroElement, errors.New("deque empty")
+	}
+
+	index := deque.physicalIndex(deque.size - 1)
+	element := deque.elements[index]
+	deque.elements[index] = deque.zeroElement
+	deque.size--
+
+	return element, nil
+}
+
+/*
+Remove and return the element at the front of the deque.
+If the deque is empty, it returns an error
+*/
+func (deque *Deque[T]) PopFront() (T, error) {
+	if deque.IsEmpty() {
+		return deque.zeroElement, errors.New("deque empty")
+	}
+
+	element := deque.elements[deque.head]
+	deque.elements[deque.head] = deque.zeroElement
+	deque.head = (deque.head + 1) % len(deque.elements)
+	deque.size--
+
+	return element, nil
+}
+
+func (deque *Deque[T]) Print() {
+	fmt.Println(deque.String())
+}
+
+/*
+Add elements at the back of the deque
+*/
+func (deque *Deque[T]) PushBack(elements ...T) {
+	deque.growIfNeeded(len(elements))
+
+	for _, element := range elements {
+		deque.elements[deque.physicalIndex(deque.size)] = element
+		deque.size++
+	}
+}
+
+/*
+Add elements at the front of the deque. They are pushed one after the other,
+so the last element passed in parameter becomes the front of the deque
+*/
+func (deque *Deque[T]) PushFront(elements ...T) {
+	deque.growIfNeeded(len(elements))
+
+	for _, element := range elements {
+		deque.head = (deque.head - 1 + len(deque.elements)) % len(deque.elements)
+		deque.elements[deque.head] = element
+		deque.size++
+	}
+}
+
+/*
+Remove the first occurence of the element in the deque
+*/
+func (deque *Deque[T]) Remove(element T) {
+	index := deque.IndexOf(element)
+	if index != -1 {
+		deque.RemoveAt(index)
+	}
+}
+
+/*
+Remove the element at the specified index in the deque.
+The elements on the shortest side of the index are shifted.
+If the element is correctly removed, it will return true. Otherwise, false
+*/
+func (deque *Deque[T]) RemoveAt(index int) bool {
+	if deque.isOutOfBounds(index) {
+		return false
+	}
+
+	if index < deque.size/2 {
+		for i := index; i > 0; i-- {
+			deque.elements[deque.physicalIndex(i)] = deque.elements[deque.physicalIndex(i-1)]
+		}
+		deque.PopFront()
+	} else {
+		for i := index; i < deque.size-1; i++ {
+			deque.elements[deque.physicalIndex(i)] = deque.elements[deque.physicalIndex(i+1)]
+		}
+		deque.PopBack()
+	}
+
+	return true
+}
+
+/*
+Return the number of elements in the deque
+*/
+func (deque *Deque[T]) Size() int {
+	return deque.size
+}
+
+/*
+Return the elements of the deque as a string, e.g. [1, 2, 3] from the front to the back
+*/
+func (deque *Deque[T]) String() string {
+	return format.String(deque.describe())
+}
+
+/*
+Return the elements of the deque, from the front to the back
+*/
+func (deque *Deque[T]) ToArray() []T {
+	elements := make([]T, 0, deque.size)
+	for _, element := range deque.All() {
+		elements = append(elements, element)
+	}
+
+	return elements
+}
+
+/*
+Decode the binary format written by MarshalBinary into the deque, replacing its content.
+The front of the encoded deque stays at the front. The comparator of the deque is kept,
+or the one registered for the type of the elements is used
+*/
+func (deque *Deque[T]) UnmarshalBinary(data []byte) error {
+	var elements []T
+	if err := serialization.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	return deque.load(elements)
+}
+
+/*
+Decode a JSON array of elements, from the front to the back, into the deque,
+replacing its content. The comparator of the deque is kept. If the deque has no
+comparator, the one registered for the type of the elements is used
+*/
+func (deque *Deque[T]) UnmarshalJSON(data []byte) error {
+	var elements []T
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	return deque.load(elements)
+}
+
+/*
+Return an iterator over the elements of the deque, from the front to the back.
+The iteration stops as soon as the loop body breaks
+*/
+func (deque *Deque[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, element := range deque.All() {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+/*
+Write the elements of the deque, as returned by String, to the writer
+*/
+func (deque *Deque[T]) WriteTo(writer io.Writer) (int64, error) {
+	return format.WriteTo(writer, deque.describe())
+}
+
+// Private methods
+
+// Describe the deque for the format package
+func (deque *Deque[T]) describe() format.Container[int, T] {
+	return format.Container[int, T]{
+		Name:  "Deque",
+		Open:  "[",
+		Close: "]",
+		All:   deque.All(),
+		Size:  deque.size,
+	}
+}
+
+// Grow the ring buffer so that n more elements can be stored
+func (deque *Deque[T]) growIfNeeded(n int) {
+	currentCapacity := len(deque.elements)
+	if deque.size+n <= currentCapacity {
+		return
+	}
+
+	newCapacity := max(minCapacity, 2*currentCapacity, deque.size+n)
+	newElements := make([]T, newCapacity)
+	for index := 0; index < deque.size; index++ {
+		newElements[index] = deque.elements[deque.physicalIndex(index)]
+	}
+
+	deque.elements = newElements
+	deque.head = 0
+}
+
+func (deque *Deque[T]) isOutOfBounds(index int) bool {
+	return index < 0 || index >= deque.size
+}
+
+// Replace the content of the deque by the elements, resolving the comparator if needed
+func (deque *Deque[T]) load(elements []T) error {
+	comparator, err := comparator.Resolve(deque.comparator)
+	if err != nil {
+		return err
+	}
+
+	*deque = *New(comparator, elements...)
+	return nil
+}
+
+// Convert a logical index (relative to the front) into an index of the ring buffer
+func (deque *Deque[T]) physicalIndex(index int) int {
+	return (deque.head + index) % len(deque.elements)
+}
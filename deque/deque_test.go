@@ -0,0 +1,308 @@
+package deque
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/dterbah/gods/collection"
+	"github.com/dterbah/gods/iterable"
+	"github.com/dterbah/gods/list/arraylist"
+	comparator "github.com/dterbah/gods/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDequeAdd(t *testing.T) {
+	assert := assert.New(t)
+	deque := New(comparator.IntComparator)
+
+	deque.Add(1, 2, 3)
+	assert.Equal([]int{1, 2, 3}, deque.ToArray())
+
+	deque.AddAll(arraylist.New(comparator.IntComparator, 4, 5))
+	assert.Equal([]int{1, 2, 3, 4, 5}, deque.ToArray())
+}
+
+func TestDequeAt(t *testing.T) {
+	assert := assert.New(t)
+	deque := New(comparator.IntComparator, 2, 3)
+	deque.PushFront(1)
+
+	value, err := deque.At(0)
+	assert.Nil(err)
+	assert.Equal(1, value)
+
+	value, err = deque.At(2)
+	assert.Nil(err)
+	assert.Equal(3, value)
+
+	_, err = deque.At(3)
+	assert.NotNil(err)
+	_, err = deque.At(-1)
+	assert.NotNil(err)
+}
+
+func TestDequeBinary(t *testing.T) {
+	assert := assert.New(t)
+	original := New(comparator.IntComparator, 2, 3)
+	original.PushFront(1)
+
+	data, err := original.MarshalBinary()
+	assert.Nil(err)
+
+	var decoded Deque[int]
+	assert.Nil(decoded.UnmarshalBinary(data))
+	assert.Equal([]int{1, 2, 3}, decoded.ToArray())
+
+	// Containers can be embedded in gob encoded structs
+	var buffer bytes.Buffer
+	assert.Nil(gob.NewEncoder(&buffer).Encode(struct{ Container *Deque[int] }{original}))
+
+	var checkpoint struct{ Container *Deque[int] }
+	assert.Nil(gob.NewDecoder(&buffer).Decode(&checkpoint))
+	decoded = *checkpoint.Container
+	assert.Equal([]int{1, 2, 3}, decoded.ToArray())
+
+	assert.NotNil(decoded.UnmarshalBinary([]byte("invalid")))
+}
+
+func TestDequeClear(t *testing.T) {
+	assert := assert.New(t)
+	deque := New(comparator.IntComparator, 1, 2)
+	deque.Clear()
+
+	assert.True(deque.IsEmpty())
+	assert.Equal(0, deque.Size())
+
+	deque.PushFront(1)
+	assert.Equal([]int{1}, deque.ToArray())
+}
+
+func TestDequeContains(t *testing.T) {
+	assert := assert.New(t)
+	deque := New(comparator.IntComparator, 1, 2, 3)
+
+	assert.True(deque.Contains(2))
+	assert.False(deque.Contains(4))
+	assert.True(deque.ContainsAll(arraylist.New(comparator.IntComparator, 1, 3)))
+	assert.False(deque.ContainsAll(arraylist.New(comparator.IntComparator, 1, 4)))
+}
+
+func TestDequeCopy(t *testing.T) {
+	assert := assert.New(t)
+	deque := New(comparator.IntComparator, 1, 2)
+	copy := deque.Copy()
+	copy.PushBack(3)
+
+	assert.Equal([]int{1, 2}, deque.ToArray())
+	assert.Equal([]int{1, 2, 3}, copy.ToArray())
+}
+
+func TestDequeFromIterable(t *testing.T) {
+	assert := assert.New(t)
+	deque := FromIterable(arraylist.New(comparator.IntComparator, 1, 2, 3), comparator.IntComparator)
+
+	assert.Equal([]int{1, 2, 3}, deque.ToArray())
+}
+
+func TestDequeImplementsInterfaces(t *testing.T) {
+	var collection collection.Collection[int] = New(comparator.IntComparator, 1)
+	var iterable iterable.Iterable[int] = New(comparator.IntComparator, 1)
+
+	assert.Equal(t, 1, collection.Size())
+	assert.Equal(t, 0, iterable.IndexOf(1))
+}
+
+func TestDequeIndexOf(t *testing.T) {
+	assert := assert.New(t)
+	deque := New(comparator.IntComparator, 1, 2, 3)
+
+	assert.Equal(1, deque.IndexOf(2))
+	assert.Equal(-1, deque.IndexOf(4))
+
+	// Without comparator, the elements are compared with ==
+	type point struct{ x, y int }
+	points := New[point](nil, point{1, 2}, point{3, 4})
+	assert.Equal(1, points.IndexOf(point{3, 4}))
+	assert.Equal(-1, points.IndexOf(point{4, 3}))
+
+	var zero Deque[int]
+	assert.Equal(-1, zero.IndexOf(1))
+}
+
+func TestDequeJSON(t *testing.T) {
+	assert := assert.New(t)
+	deque := New(comparator.IntComparator, 2, 3)
+	deque.PushFront(1)
+
+	data, err := json.Marshal(deque)
+	assert.Nil(err)
+	assert.Equal("[1,2,3]", string(data))
+
+	data, err = json.Marshal(New(comparator.IntComparator))
+	assert.Nil(err)
+	assert.Equal("[]", string(data))
+
+	var decoded Deque[int]
+	assert.Nil(json.Unmarshal([]byte("[1,2,3]"), &decoded))
+	element, _ := decoded.PopBack()
+	assert.Equal(3, element)
+	assert.True(decoded.Contains(1))
+}
+
+func TestDequePeek(t *testing.T) {
+	assert := assert.New(t)
+	deque := New(comparator.IntComparator)
+
+	_, err := deque.PeekFront()
+	assert.NotNil(err)
+	_, err = deque.PeekBack()
+	assert.NotNil(err)
+
+	deque.PushBack(1, 2, 3)
+
+	value, err := deque.PeekFront()
+	assert.Nil(err)
+	assert.Equal(1, value)
+
+	value, err = deque.PeekBack()
+	assert.Nil(err)
+	assert.Equal(3, value)
+	assert.Equal(3, deque.Size())
+}
+
+func TestDequePop(t *testing.T) {
+	assert := assert.New(t)
+	deque := New(comparator.IntComparator)
+
+	_, err := deque.PopFront()
+	assert.NotNil(err)
+	_, err = deque.PopBack()
+	assert.NotNil(err)
+
+	deque.PushBack(1, 2, 3)
+
+	value, err := deque.PopFront()
+	assert.Nil(err)
+	assert.Equal(1, value)
+
+	value, err = deque.PopBack()
+	assert.Nil(err)
+	assert.Equal(3, value)
+
+	assert.Equal([]int{2}, deque.ToArray())
+}
+
+func TestDequePrint(t *testing.T) {
+	deque := New(comparator.IntComparator, 1, 2, 3)
+	deque.Print()
+}
+
+func TestDequePushFront(t *testing.T) {
+	assert := assert.New(t)
+	deque := New(comparator.IntComparator)
+
+	deque.PushFront(1, 2, 3)
+	assert.Equal([]int{3, 2, 1}, deque.ToArray())
+}
+
+func TestDequeRemove(t *testing.T) {
+	assert := assert.New(t)
+	deque := New(comparator.IntComparator, 1, 2, 3, 4, 5, 2)
+
+	deque.Remove(2)
+	assert.Equal([]int{1, 3, 4, 5, 2}, deque.ToArray())
+
+	deque.Remove(10)
+	assert.Equal(5, deque.Size())
+
+	assert.True(deque.RemoveAt(3))
+	assert.Equal([]int{1, 3, 4, 2}, deque.ToArray())
+	assert.False(deque.RemoveAt(4))
+}
+
+func TestDequeRandomOperations(t *testing.T) {
+	assert := assert.New(t)
+	deque := New(comparator.IntComparator)
+	random := rand.New(rand.NewSource(42))
+	expected := []int{}
+
+	for i := 0; i < 5000; i++ {
+		value := random.Intn(100)
+		switch random.Intn(5) {
+		case 0:
+			deque.PushFront(value)
+			expected = append([]int{value}, expected...)
+		case 1:
+			deque.PushBack(value)
+			expected = append(expected, value)
+		case 2:
+			element, err := deque.PopFront()
+			if len(expected) == 0 {
+				assert.NotNil(err)
+			} else {
+				assert.Equal(expected[0], element)
+				expected = expected[1:]
+			}
+		case 3:
+			element, err := deque.PopBack()
+			if len(expected) == 0 {
+				assert.NotNil(err)
+			} else {
+				assert.Equal(expected[len(expected)-1], element)
+				expected = expected[:len(expected)-1]
+			}
+		default:
+			if len(expected) > 0 {
+				index := random.Intn(len(expected))
+				deque.RemoveAt(index)
+				expected = append(expected[:index], expected[index+1:]...)
+			}
+		}
+	}
+
+	assert.Equal(expected, deque.ToArray())
+}
+
+func TestDequeString(t *testing.T) {
+	assert := assert.New(t)
+	deque := New(comparator.IntComparator, 2)
+	deque.PushFront(1)
+
+	assert.Equal("[1, 2]", deque.String())
+	assert.Equal("[1, 2]", fmt.Sprint(deque))
+	assert.Equal("Deque(size=2) [1, 2]", fmt.Sprintf("%+v", deque))
+	assert.Equal(`&deque.Deque[int]{1, 2}`, fmt.Sprintf("%#v", deque))
+
+	var builder strings.Builder
+	count, err := deque.WriteTo(&builder)
+	assert.Nil(err)
+	assert.Equal(int64(len("[1, 2]")), count)
+	assert.Equal("[1, 2]", builder.String())
+}
+
+func TestDequeValues(t *testing.T) {
+	assert := assert.New(t)
+	deque := New(comparator.IntComparator, 2, 3, 4)
+	deque.PushFront(1)
+
+	values := []int{}
+	for value := range deque.Values() {
+		if value == 3 {
+			break
+		}
+		values = append(values, value)
+	}
+
+	assert.Equal([]int{1, 2}, values)
+
+	indexes := []int{}
+	deque.ForEach(func(element, index int) {
+		indexes = append(indexes, index)
+	})
+	assert.Equal([]int{0, 1, 2, 3}, indexes)
+}
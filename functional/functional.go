@@ -0,0 +1,203 @@
+package functional
+
+import (
+	"errors"
+	"iter"
+
+	"github.com/dterbah/gods/iterable"
+	"github.com/dterbah/gods/list"
+	"github.com/dterbah/gods/list/arraylist"
+	"github.com/dterbah/gods/list/linkedlist"
+	"github.com/dterbah/gods/maps"
+	"github.com/dterbah/gods/maps/linkedhashmap"
+	comparator "github.com/dterbah/gods/utils"
+)
+
+/*
+Split the list into lists of at most size elements, in their order.
+Every chunk has the same implementation and comparator as the source list,
+and only the last one can be smaller. If size is not positive, nil is returned
+*/
+func Chunk[T any](source list.List[T], size int) []list.List[T] {
+	if size <= 0 {
+		return nil
+	}
+
+	chunks := []list.List[T]{}
+	var chunk list.List[T]
+
+	for index, element := range source.All() {
+		if index%size == 0 {
+			chunk = source.Empty()
+			chunks = append(chunks, chunk)
+		}
+		chunk.Add(element)
+	}
+
+	return chunks
+}
+
+/*
+Return a new list with the elements of the source list without their duplicates,
+keeping the first occurrence of each one. The elements are compared with the
+comparator of the source list, so this function runs in O(n²)
+*/
+func Distinct[T any](source list.List[T]) list.List[T] {
+	result := source.Empty()
+
+	for element := range source.Values() {
+		if !result.Contains(element) {
+			result.Add(element)
+		}
+	}
+
+	return result
+}
+
+/*
+Return the first element matching the predicate.
+If no element matches, this function will return an error
+*/
+func Find[T any](source iterable.Iterable[T], predicate func(element T, index int) bool) (T, error) {
+	for index, element := range source.All() {
+		if predicate(element, index) {
+			return element, nil
+		}
+	}
+
+	var zero T
+	return zero, errors.New("no element matches the predicate")
+}
+
+/*
+Return the index of the first element matching the predicate.
+If no element matches, this function will return -1
+*/
+func FindIndex[T any](source iterable.Iterable[T], predicate func(element T, index int) bool) int {
+	for index, element := range source.All() {
+		if predicate(element, index) {
+			return index
+		}
+	}
+
+	return -1
+}
+
+/*
+Map each element of the source to a slice of elements, and return a new list
+with all of them, in order. A LinkedList source gives a LinkedList, any other
+source gives an ArrayList using the comparator in parameter
+*/
+func FlatMap[T, U any](source iterable.Iterable[T], mapper func(element T, index int) []U,
+	comparator comparator.Comparator[U]) list.List[U] {
+	result := newList(source, comparator)
+
+	for index, element := range source.All() {
+		result.Add(mapper(element, index)...)
+	}
+
+	return result
+}
+
+/*
+Group the elements of the list by the key returned by keyOf. Each group is a list
+with the same implementation and comparator as the source list. The groups are
+stored in a LinkedHashMap, in the order in which their first element appears
+*/
+func GroupBy[T any, K comparable](source list.List[T], keyOf func(element T) K) maps.Map[K, list.List[T]] {
+	groups := linkedhashmap.New[K, list.List[T]]()
+
+	for element := range source.Values() {
+		key := keyOf(element)
+		group, err := groups.Get(key)
+		if err != nil {
+			group = source.Empty()
+			groups.Put(key, group)
+		}
+		group.Add(element)
+	}
+
+	return groups
+}
+
+/*
+Return a new list with the result of the mapper for each element of the source.
+A LinkedList source gives a LinkedList, any other source gives an ArrayList
+using the comparator in parameter
+*/
+func Map[T, U any](source iterable.Iterable[T], mapper func(element T, index int) U,
+	comparator comparator.Comparator[U]) list.List[U] {
+	result := newList(source, comparator)
+
+	for index, element := range source.All() {
+		result.Add(mapper(element, index))
+	}
+
+	return result
+}
+
+/*
+Split the list in two: the elements matching the predicate, and the others.
+Both lists have the same implementation and comparator as the source list
+*/
+func Partition[T any](source list.List[T], predicate func(element T) bool) (list.List[T], list.List[T]) {
+	matching := source.Empty()
+	others := source.Empty()
+
+	for element := range source.Values() {
+		if predicate(element) {
+			matching.Add(element)
+		} else {
+			others.Add(element)
+		}
+	}
+
+	return matching, others
+}
+
+/*
+Reduce the elements of the source to a single value, calling the reducer for
+each element with the value accumulated so far, starting from initial
+*/
+func Reduce[T, U any](source iterable.Iterable[T], reducer func(accumulator U, element T, index int) U, initial U) U {
+	accumulator := initial
+
+	for index, element := range source.All() {
+		accumulator = reducer(accumulator, element, index)
+	}
+
+	return accumulator
+}
+
+/*
+Combine the elements of first and second at the same index with the zipper, and
+return a new list with the results. The result stops at the end of the shortest
+source. Its implementation is chosen from first, as in Map
+*/
+func Zip[T, U, R any](first iterable.Iterable[T], second iterable.Iterable[U], zipper func(a T, b U) R,
+	comparator comparator.Comparator[R]) list.List[R] {
+	result := newList(first, comparator)
+	next, stop := iter.Pull(second.Values())
+	defer stop()
+
+	for element := range first.Values() {
+		other, ok := next()
+		if !ok {
+			break
+		}
+		result.Add(zipper(element, other))
+	}
+
+	return result
+}
+
+// Private functions
+
+// Create an empty list for the results of a transformation of the source
+func newList[T, U any](source iterable.Iterable[T], comparator comparator.Comparator[U]) list.List[U] {
+	if _, ok := source.(*linkedlist.LinkedList[T]); ok {
+		return linkedlist.New(comparator)
+	}
+
+	return arraylist.New(comparator)
+}
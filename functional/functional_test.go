@@ -0,0 +1,155 @@
+package functional
+
+import (
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/dterbah/gods/list/arraylist"
+	"github.com/dterbah/gods/list/linkedlist"
+	"github.com/dterbah/gods/set/treeset"
+	comparator "github.com/dterbah/gods/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunk(t *testing.T) {
+	assert := assert.New(t)
+	source := linkedlist.New(comparator.IntComparator, 1, 2, 3, 4, 5)
+
+	chunks := Chunk[int](source, 2)
+	assert.Equal(3, len(chunks))
+	assert.Equal([]int{1, 2}, chunks[0].ToArray())
+	assert.Equal([]int{3, 4}, chunks[1].ToArray())
+	assert.Equal([]int{5}, chunks[2].ToArray())
+	assert.IsType(&linkedlist.LinkedList[int]{}, chunks[0])
+
+	assert.Empty(Chunk[int](arraylist.New(comparator.IntComparator), 2))
+	assert.Nil(Chunk[int](source, 0))
+}
+
+func TestDistinct(t *testing.T) {
+	assert := assert.New(t)
+	source := arraylist.New(comparator.IntComparator, 3, 1, 3, 2, 1)
+
+	result := Distinct[int](source)
+	assert.Equal([]int{3, 1, 2}, result.ToArray())
+	assert.IsType(&arraylist.ArrayList[int]{}, result)
+	assert.Equal(5, source.Size())
+}
+
+func TestFind(t *testing.T) {
+	assert := assert.New(t)
+	source := arraylist.New(comparator.IntComparator, 1, 2, 3, 4)
+
+	element, err := Find[int](source, func(element, _ int) bool { return element > 2 })
+	assert.Nil(err)
+	assert.Equal(3, element)
+
+	element, err = Find[int](source, func(element, _ int) bool { return element > 4 })
+	assert.NotNil(err)
+	assert.Equal(0, element)
+
+	element, err = Find[int](treeset.New(comparator.IntComparator, 5, 1), func(_, index int) bool { return index == 1 })
+	assert.Nil(err)
+	assert.Equal(5, element)
+}
+
+func TestFindIndex(t *testing.T) {
+	assert := assert.New(t)
+	source := linkedlist.New(comparator.StringComparator, "a", "bb", "ccc")
+
+	assert.Equal(1, FindIndex[string](source, func(element string, _ int) bool { return len(element) == 2 }))
+	assert.Equal(-1, FindIndex[string](source, func(element string, _ int) bool { return element == "d" }))
+}
+
+func TestFlatMap(t *testing.T) {
+	assert := assert.New(t)
+	source := arraylist.New(comparator.IntComparator, 1, 2, 3)
+
+	result := FlatMap(source, func(element, _ int) []string {
+		return slices.Repeat([]string{strconv.Itoa(element)}, element)
+	}, comparator.StringComparator)
+
+	assert.Equal([]string{"1", "2", "2", "3", "3", "3"}, result.ToArray())
+	assert.IsType(&arraylist.ArrayList[string]{}, result)
+}
+
+func TestGroupBy(t *testing.T) {
+	assert := assert.New(t)
+	source := linkedlist.New(comparator.StringComparator, "bb", "a", "cc", "d", "eee")
+
+	groups := GroupBy[string](source, func(element string) int { return len(element) })
+	assert.Equal(3, groups.Size())
+
+	keys := []int{}
+	for key, group := range groups.All() {
+		keys = append(keys, key)
+		assert.IsType(&linkedlist.LinkedList[string]{}, group)
+	}
+	assert.Equal([]int{2, 1, 3}, keys)
+
+	group, err := groups.Get(2)
+	assert.Nil(err)
+	assert.Equal([]string{"bb", "cc"}, group.ToArray())
+}
+
+func TestMap(t *testing.T) {
+	assert := assert.New(t)
+
+	result := Map(arraylist.New(comparator.IntComparator, 1, 2, 3), func(element, index int) string {
+		return strconv.Itoa(element * index)
+	}, comparator.StringComparator)
+	assert.Equal([]string{"0", "2", "6"}, result.ToArray())
+	assert.IsType(&arraylist.ArrayList[string]{}, result)
+
+	result = Map(linkedlist.New(comparator.IntComparator, 1), func(element, _ int) string {
+		return strconv.Itoa(element)
+	}, comparator.StringComparator)
+	assert.IsType(&linkedlist.LinkedList[string]{}, result)
+
+	result = Map(treeset.New(comparator.IntComparator, 2, 1), func(element, _ int) string {
+		return strconv.Itoa(element)
+	}, comparator.StringComparator)
+	assert.Equal([]string{"1", "2"}, result.ToArray())
+	assert.True(result.Contains("2"))
+}
+
+func TestPartition(t *testing.T) {
+	assert := assert.New(t)
+	source := arraylist.New(comparator.IntComparator, 1, 2, 3, 4, 5)
+
+	even, odd := Partition[int](source, func(element int) bool { return element%2 == 0 })
+	assert.Equal([]int{2, 4}, even.ToArray())
+	assert.Equal([]int{1, 3, 5}, odd.ToArray())
+	assert.IsType(&arraylist.ArrayList[int]{}, odd)
+}
+
+func TestReduce(t *testing.T) {
+	assert := assert.New(t)
+	source := arraylist.New(comparator.IntComparator, 1, 2, 3, 4)
+
+	sum := Reduce(source, func(accumulator, element, _ int) int { return accumulator + element }, 0)
+	assert.Equal(10, sum)
+
+	joined := Reduce(source, func(accumulator string, element, _ int) string {
+		return accumulator + strconv.Itoa(element)
+	}, ">")
+	assert.Equal(">1234", joined)
+
+	assert.Equal(7, Reduce(arraylist.New(comparator.IntComparator), func(accumulator, element, _ int) int {
+		return accumulator + element
+	}, 7))
+}
+
+func TestZip(t *testing.T) {
+	assert := assert.New(t)
+	numbers := linkedlist.New(comparator.IntComparator, 1, 2, 3)
+	letters := arraylist.New(comparator.StringComparator, "a", "b")
+
+	result := Zip(numbers, letters, func(number int, letter string) string {
+		return letter + strconv.Itoa(number)
+	}, comparator.StringComparator)
+
+	assert.Equal([]string{"a1", "b2"}, result.ToArray())
+	assert.IsType(&linkedlist.LinkedList[string]{}, result)
+}
@@ -0,0 +1,95 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+)
+
+/*
+Description of a container, used to write it as text.
+For the containers that are not maps, the keys of All are ignored
+*/
+type Container[K any, V any] struct {
+	// Name of the container, written by %+v
+	Name string
+	// Delimiters written around the elements
+	Open, Close string
+	// Elements of the container
+	All iter.Seq2[K, V]
+	// True if the keys of All must be written with the values
+	Keyed bool
+	// Number of elements in the container
+	Size int
+	// Maximum number of elements, written by %+v if it is positive
+	Capacity int
+}
+
+/*
+Return the elements of the container, formatted with %v, between its delimiters
+*/
+func String[K any, V any](container Container[K, V]) string {
+	var builder strings.Builder
+	container.writeElements(&builder, "%v")
+	return builder.String()
+}
+
+/*
+Write the container according to the verb:
+  - %v writes the elements between the delimiters, like String
+  - %+v writes the name, the size and the capacity of the container before its elements
+  - %#v writes a Go-syntax representation of the container, value being the container itself
+  - the other verbs, with their flags, are applied to each element
+*/
+func Format[K any, V any](state fmt.State, verb rune, value any, container Container[K, V]) {
+	switch {
+	case verb == 'v' && state.Flag('#'):
+		fmt.Fprintf(state, "&%s", strings.TrimPrefix(fmt.Sprintf("%T", value), "*"))
+		container.Open, container.Close = "{", "}"
+		container.writeElements(state, "%#v")
+	case verb == 'v' && state.Flag('+'):
+		fmt.Fprintf(state, "%s(size=%d", container.Name, container.Size)
+		if container.Capacity > 0 {
+			fmt.Fprintf(state, ", capacity=%d", container.Capacity)
+		}
+		io.WriteString(state, ") ")
+		container.writeElements(state, "%+v")
+	case verb == 'v':
+		container.writeElements(state, "%v")
+	default:
+		container.writeElements(state, fmt.FormatString(state, verb))
+	}
+}
+
+/*
+Write the elements of the container, formatted with %v, between its delimiters.
+Return the number of bytes written and the first error encountered
+*/
+func WriteTo[K any, V any](writer io.Writer, container Container[K, V]) (int64, error) {
+	count, err := io.WriteString(writer, String(container))
+	return int64(count), err
+}
+
+// Private methods
+
+// Write the elements between the delimiters, each one formatted with the directive
+func (container Container[K, V]) writeElements(writer io.Writer, directive string) {
+	io.WriteString(writer, container.Open)
+
+	first := true
+	for key, value := range container.All {
+		if !first {
+			io.WriteString(writer, ", ")
+		}
+		first = false
+
+		if container.Keyed {
+			fmt.Fprintf(writer, directive+": "+directive, key, value)
+		} else {
+			fmt.Fprintf(writer, directive, value)
+		}
+	}
+
+	io.WriteString(writer, container.Close)
+}
@@ -0,0 +1,71 @@
+package format
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type container struct {
+	elements []string
+}
+
+func (c *container) Format(state fmt.State, verb rune) {
+	Format(state, verb, c, c.describe())
+}
+
+func (c *container) describe() Container[int, string] {
+	return Container[int, string]{
+		Name:     "Container",
+		Open:     "[",
+		Close:    "]",
+		All:      slices.All(c.elements),
+		Size:     len(c.elements),
+		Capacity: 4,
+	}
+}
+
+func TestString(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal("[a, b]", String((&container{[]string{"a", "b"}}).describe()))
+	assert.Equal("[]", String((&container{}).describe()))
+}
+
+func TestFormat(t *testing.T) {
+	assert := assert.New(t)
+	value := &container{[]string{"a", "b"}}
+
+	assert.Equal("[a, b]", fmt.Sprintf("%v", value))
+	assert.Equal("Container(size=2, capacity=4) [a, b]", fmt.Sprintf("%+v", value))
+	assert.Equal(`&format.container{"a", "b"}`, fmt.Sprintf("%#v", value))
+	assert.Equal(`["a", "b"]`, fmt.Sprintf("%q", value))
+	assert.Equal("[  a,   b]", fmt.Sprintf("%3s", value))
+}
+
+func TestFormatKeyed(t *testing.T) {
+	assert := assert.New(t)
+	entries := Container[string, int]{
+		Open:  "{",
+		Close: "}",
+		All:   maps.All(map[string]int{"a": 1}),
+		Keyed: true,
+		Size:  1,
+	}
+
+	assert.Equal("{a: 1}", String(entries))
+}
+
+func TestWriteTo(t *testing.T) {
+	assert := assert.New(t)
+	var builder strings.Builder
+
+	count, err := WriteTo(&builder, (&container{[]string{"a"}}).describe())
+	assert.Nil(err)
+	assert.Equal(int64(3), count)
+	assert.Equal("[a]", builder.String())
+}
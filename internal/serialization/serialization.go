@@ -0,0 +1,53 @@
+package serialization
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+)
+
+/*
+Version of the binary format written by Marshal. It must be increased
+each time the payload of a container changes in an incompatible way
+*/
+const Version byte = 1
+
+// Bytes written at the start of every binary encoded container
+var magic = [2]byte{'g', 'd'}
+
+// Size of the header: the magic bytes followed by the version
+const headerSize = len(magic) + 1
+
+/*
+Encode the value in the binary format of the containers: a header made
+of magic bytes and of the format version, followed by the gob encoding of the value
+*/
+func Marshal(value any) ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.Write(magic[:])
+	buffer.WriteByte(Version)
+
+	if err := gob.NewEncoder(&buffer).Encode(value); err != nil {
+		return nil, err
+	}
+
+	return buffer.Bytes(), nil
+}
+
+/*
+Decode data written by Marshal into the value, which must be a pointer.
+If the header is invalid or if the data has been written by a more recent
+version of the format, this function will return an error
+*/
+func Unmarshal(data []byte, value any) error {
+	if len(data) < headerSize || data[0] != magic[0] || data[1] != magic[1] {
+		return errors.New("invalid binary header")
+	}
+
+	if version := data[2]; version == 0 || version > Version {
+		return fmt.Errorf("unsupported binary format version %d", version)
+	}
+
+	return gob.NewDecoder(bytes.NewReader(data[headerSize:])).Decode(value)
+}
@@ -0,0 +1,55 @@
+package serialization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarshalUnmarshal(t *testing.T) {
+	assert := assert.New(t)
+
+	data, err := Marshal([]int{1, 2, 3})
+	assert.Nil(err)
+	assert.Equal([]byte{'g', 'd', Version}, data[:3])
+
+	var elements []int
+	assert.Nil(Unmarshal(data, &elements))
+	assert.Equal([]int{1, 2, 3}, elements)
+}
+
+func TestMarshalEmpty(t *testing.T) {
+	assert := assert.New(t)
+
+	data, err := Marshal([]string{})
+	assert.Nil(err)
+
+	var elements []string
+	assert.Nil(Unmarshal(data, &elements))
+	assert.Empty(elements)
+}
+
+func TestUnmarshalInvalidHeader(t *testing.T) {
+	assert := assert.New(t)
+	data, _ := Marshal([]int{1})
+	var elements []int
+
+	assert.NotNil(Unmarshal(nil, &elements))
+	assert.NotNil(Unmarshal([]byte("xx"), &elements))
+	assert.NotNil(Unmarshal(append([]byte{'x', 'x'}, data[2:]...), &elements))
+
+	future := append([]byte{}, data...)
+	future[2] = Version + 1
+	assert.NotNil(Unmarshal(future, &elements))
+
+	future[2] = 0
+	assert.NotNil(Unmarshal(future, &elements))
+}
+
+func TestUnmarshalWrongType(t *testing.T) {
+	assert := assert.New(t)
+	data, _ := Marshal([]string{"a"})
+
+	var elements []int
+	assert.NotNil(Unmarshal(data, &elements))
+}
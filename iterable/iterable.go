@@ -1,6 +1,14 @@
 package iterable
 
+import "iter"
+
 type Iterable[T any] interface {
+	/*
+		Return an iterator over the index and the value of each element.
+		It can be used with a range-over-func loop and supports early break
+	*/
+	All() iter.Seq2[int, T]
+
 	/*
 		Call a function for each element of the list
 	*/
@@ -11,4 +19,10 @@ type Iterable[T any] interface {
 		If the element is not present in the list, the method will return -1
 	*/
 	IndexOf(element T) int
+
+	/*
+		Return an iterator over the values of each element.
+		It can be used with a range-over-func loop and supports early break
+	*/
+	Values() iter.Seq[T]
 }
@@ -1,11 +1,16 @@
 package arraylist
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"iter"
 	"sort"
 
 	"github.com/dterbah/gods/collection"
+	"github.com/dterbah/gods/internal/format"
+	"github.com/dterbah/gods/internal/serialization"
 	"github.com/dterbah/gods/iterable"
 	"github.com/dterbah/gods/list"
 	comparator "github.com/dterbah/gods/utils"
@@ -21,6 +26,8 @@ type ArrayList[T any] struct {
 	size        int
 	zeroElement T
 	comparator  comparator.Comparator[T]
+	// True if the comparator only reports the equality of the elements
+	unordered bool
 }
 
 // Constants
@@ -46,6 +53,24 @@ func New[T any](comparator comparator.Comparator[T], elements ...T) *ArrayList[T
 	return list
 }
 
+/*
+Create a new ArrayList comparing its elements with the == operator.
+As its elements have no ordering, the list cannot be sorted
+*/
+func NewComparable[T comparable](elements ...T) *ArrayList[T] {
+	return NewWithEqualer(comparator.Equal[T], elements...)
+}
+
+/*
+Create a new ArrayList comparing its elements with the equaler.
+As its elements have no ordering, the list cannot be sorted
+*/
+func NewWithEqualer[T any](equaler comparator.Equaler[T], elements ...T) *ArrayList[T] {
+	list := New(comparator.FromEqualer(equaler), elements...)
+	list.unordered = true
+	return list
+}
+
 /*
 Add elements at the end of the list
 */
@@ -65,6 +90,20 @@ func (list *ArrayList[T]) AddAll(elements collection.Collection[T]) {
 	}
 }
 
+/*
+Return an iterator over the indexes and the elements of the list.
+The iteration stops as soon as the loop body breaks
+*/
+func (list *ArrayList[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for index, element := range list.elements[:list.size] {
+			if !yield(index, element) {
+				return
+			}
+		}
+	}
+}
+
 /*
 Retrieve an element by its index
 If the index is negative or greater than the list size, the method will return an error
@@ -87,16 +126,11 @@ func (list *ArrayList[T]) Clear() {
 }
 
 /*
-Return true if the list contains at least one occurence of the element, else false
+Return true if the list contains at least one occurence of the element, else false.
+If the list has no comparator, the elements are compared as in comparator.ResolveEquality
 */
 func (list ArrayList[T]) Contains(element T) bool {
-	for _, currentElement := range list.elements[:list.size] {
-		if list.comparator(currentElement, element) == 0 {
-			return true
-		}
-	}
-
-	return false
+	return list.IndexOf(element) != -1
 }
 
 func (list ArrayList[T]) ContainsAll(collection collection.Collection[T]) bool {
@@ -114,7 +148,7 @@ func (list ArrayList[T]) ContainsAll(collection collection.Collection[T]) bool {
 Create a copy of the current list
 */
 func (list *ArrayList[T]) Copy() list.List[T] {
-	newList := New[T](list.comparator)
+	newList := list.emptyCopy()
 
 	list.ForEach(func(element T, _ int) {
 		newList.Add(element)
@@ -123,6 +157,13 @@ func (list *ArrayList[T]) Copy() list.List[T] {
 	return newList
 }
 
+/*
+Create an empty ArrayList with the same comparator, without copying the elements
+*/
+func (list *ArrayList[T]) Empty() list.List[T] {
+	return list.emptyCopy()
+}
+
 func (list ArrayList[T]) Every(callback func(element T, index int) bool) bool {
 	result := true
 
@@ -138,7 +179,7 @@ Filter the list according to the specified callback passed in parameter.
 It will return a new List that match the filter
 */
 func (list ArrayList[T]) Filter(callback func(element T) bool) list.List[T] {
-	newList := New[T](list.comparator)
+	newList := list.emptyCopy()
 
 	for _, element := range list.elements[:list.size] {
 		if callback(element) {
@@ -158,17 +199,42 @@ func (list *ArrayList[T]) ForEach(callback func(element T, index int)) {
 	}
 }
 
+/*
+Format the list for the fmt package: %v writes its elements, %+v also writes
+its size, and %#v writes a Go-syntax representation.
+The other verbs are applied to each element
+*/
+func (list *ArrayList[T]) Format(state fmt.State, verb rune) {
+	format.Format(state, verb, list, list.describe())
+}
+
+/*
+Decode the list from the gob encoding, see UnmarshalBinary
+*/
+func (list *ArrayList[T]) GobDecode(data []byte) error {
+	return list.UnmarshalBinary(data)
+}
+
+/*
+Encode the list for gob, see MarshalBinary
+*/
+func (list *ArrayList[T]) GobEncode() ([]byte, error) {
+	return list.MarshalBinary()
+}
+
 func (list ArrayList[T]) Index(i int) T {
 	return list.elements[i]
 }
 
 /*
 Return the index in the list of the element (if the element exists in the list)
-If the element is not present in the list, the method will return -1
+If the element is not present in the list, the method will return -1.
+If the list has no comparator, the elements are compared as in comparator.ResolveEquality
 */
 func (list ArrayList[T]) IndexOf(element T) int {
+	equal := comparator.ResolveEquality(list.comparator)
 	for index, currentElement := range list.elements[:list.size] {
-		if list.comparator(currentElement, element) == 0 {
+		if equal(currentElement, element) == 0 {
 			return index
 		}
 	}
@@ -194,17 +260,23 @@ func (list ArrayList[T]) Less(i, j int) bool {
 	return list.comparator(list.elements[i], list.elements[j]) == -1
 }
 
-func (list ArrayList[T]) Print() {
-	fmt.Print("[")
+/*
+Encode the list in a binary format made of a versioned header
+followed by the gob encoding of its elements
+*/
+func (list *ArrayList[T]) MarshalBinary() ([]byte, error) {
+	return serialization.Marshal(list.ToArray())
+}
 
-	for index := 0; index < list.size; index++ {
-		fmt.Print(list.elements[index])
-		if index < list.size-1 {
-			fmt.Print(", ")
-		}
-	}
+/*
+Encode the list as a JSON array of its elements
+*/
+func (list *ArrayList[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(list.ToArray())
+}
 
-	fmt.Println("]")
+func (list ArrayList[T]) Print() {
+	fmt.Println(list.String())
 }
 
 /*
@@ -228,19 +300,11 @@ func (list *ArrayList[T]) RemoveAt(index int) bool {
 }
 
 /*
-Remove specified element if it exist in the list
+Remove the first occurrence of the element if it exists in the list
 */
 func (list *ArrayList[T]) Remove(element T) {
-	index := 0
-	elementsRemoved := 0
-
-	for index < list.size {
-		if list.comparator(list.elements[index], element) == 0 {
-			list.RemoveAt(index)
-			elementsRemoved++
-		} else {
-			index++
-		}
+	if index := list.IndexOf(element); index != -1 {
+		list.RemoveAt(index)
 	}
 }
 
@@ -278,10 +342,32 @@ func (list ArrayList[T]) Some(callback func(element T, index int) bool) bool {
 }
 
 /*
-Sort the list
+Sort the list. If the list was created with an equaler, it has no ordering
+and this method will return an error. If the list has no comparator, the one
+registered for the type of the elements is used, else it returns comparator.ErrNoComparator
 */
-func (list *ArrayList[T]) Sort() {
+func (list *ArrayList[T]) Sort() error {
+	if list.comparator == nil {
+		resolved, err := comparator.Default[T]()
+		if err != nil {
+			return comparator.ErrNoComparator
+		}
+		list.comparator = resolved
+	}
+
+	if list.unordered {
+		return comparator.ErrUnordered
+	}
+
 	sort.Sort(list)
+	return nil
+}
+
+/*
+Return the elements of the list as a string, e.g. [1, 2, 3]
+*/
+func (list *ArrayList[T]) String() string {
+	return format.String(list.describe())
 }
 
 /*
@@ -298,7 +384,7 @@ func (list *ArrayList[T]) SubList(start, end int) list.List[T] {
 		return list
 	}
 
-	newList := New[T](list.comparator)
+	newList := list.emptyCopy()
 	for _, element := range list.elements[start:end] {
 		newList.Add(element)
 	}
@@ -322,8 +408,88 @@ func (list *ArrayList[T]) ToArray() []T {
 	return elements
 }
 
+/*
+Decode the binary format written by MarshalBinary into the list, replacing its content.
+The comparator of the list is kept. If the list has no comparator, the one registered for
+the type of the elements is used, else this method will return an error
+*/
+func (list *ArrayList[T]) UnmarshalBinary(data []byte) error {
+	var elements []T
+	if err := serialization.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	return list.load(elements)
+}
+
+/*
+Decode a JSON array of elements into the list, replacing its content.
+The comparator of the list is kept. If the list has no comparator,
+the one registered for the type of the elements is used
+*/
+func (list *ArrayList[T]) UnmarshalJSON(data []byte) error {
+	var elements []T
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	return list.load(elements)
+}
+
+/*
+Return an iterator over the elements of the list.
+The iteration stops as soon as the loop body breaks
+*/
+func (list *ArrayList[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, element := range list.elements[:list.size] {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+/*
+Write the elements of the list, as returned by String, to the writer
+*/
+func (list *ArrayList[T]) WriteTo(writer io.Writer) (int64, error) {
+	return format.WriteTo(writer, list.describe())
+}
+
 // Private methods
 
+// Describe the list for the format package
+func (list *ArrayList[T]) describe() format.Container[int, T] {
+	return format.Container[int, T]{
+		Name:  "ArrayList",
+		Open:  "[",
+		Close: "]",
+		All:   list.All(),
+		Size:  list.size,
+	}
+}
+
+// Create an empty list with the same comparator as the list
+func (list *ArrayList[T]) emptyCopy() *ArrayList[T] {
+	newList := New(list.comparator)
+	newList.unordered = list.unordered
+	return newList
+}
+
+// Replace the content of the list by the elements, resolving the comparator if needed
+func (list *ArrayList[T]) load(elements []T) error {
+	comparator, err := comparator.Resolve(list.comparator)
+	if err != nil {
+		return err
+	}
+
+	unordered := list.unordered
+	*list = *New(comparator, elements...)
+	list.unordered = unordered
+	return nil
+}
+
 // Resize the size of the list
 func (list *ArrayList[T]) resize(cap int) {
 	newElements := make([]T, cap)
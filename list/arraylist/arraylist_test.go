@@ -1,6 +1,12 @@
 package arraylist
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/dterbah/gods/list/linkedlist"
@@ -52,6 +58,29 @@ func TestArrayListAt(t *testing.T) {
 	assert.NotEqual(err, nil)
 }
 
+func TestArrayListBinary(t *testing.T) {
+	assert := assert.New(t)
+	original := New(comparator.IntComparator, 3, 1, 2)
+
+	data, err := original.MarshalBinary()
+	assert.Nil(err)
+
+	var decoded ArrayList[int]
+	assert.Nil(decoded.UnmarshalBinary(data))
+	assert.Equal([]int{3, 1, 2}, decoded.ToArray())
+
+	// Containers can be embedded in gob encoded structs
+	var buffer bytes.Buffer
+	assert.Nil(gob.NewEncoder(&buffer).Encode(struct{ Container *ArrayList[int] }{original}))
+
+	var checkpoint struct{ Container *ArrayList[int] }
+	assert.Nil(gob.NewDecoder(&buffer).Decode(&checkpoint))
+	decoded = *checkpoint.Container
+	assert.Equal([]int{3, 1, 2}, decoded.ToArray())
+
+	assert.NotNil(decoded.UnmarshalBinary([]byte("invalid")))
+}
+
 func TestArrayListClear(t *testing.T) {
 	assert := assert.New(t)
 	list := New[int](comparator.IntComparator)
@@ -195,6 +224,37 @@ func TestArrayListIsEmpty(t *testing.T) {
 	assert.False(list.IsEmpty())
 }
 
+func TestArrayListJSON(t *testing.T) {
+	assert := assert.New(t)
+	list := New(comparator.IntComparator, 3, 1, 2)
+
+	data, err := json.Marshal(list)
+	assert.Nil(err)
+	assert.Equal("[3,1,2]", string(data))
+
+	data, err = json.Marshal(New(comparator.IntComparator))
+	assert.Nil(err)
+	assert.Equal("[]", string(data))
+
+	// Without comparator, the registered one is used
+	var decoded ArrayList[int]
+	assert.Nil(json.Unmarshal([]byte("[3,1,2]"), &decoded))
+	assert.Equal([]int{3, 1, 2}, decoded.ToArray())
+	assert.True(decoded.Contains(1))
+
+	// The comparator of an existing list is kept
+	reversed := New(func(a, b int) int { return comparator.IntComparator(b, a) }, 10)
+	assert.Nil(json.Unmarshal(data, reversed))
+	assert.True(reversed.IsEmpty())
+	assert.Nil(json.Unmarshal([]byte("[1,3,2]"), reversed))
+	reversed.Sort()
+	assert.Equal([]int{3, 2, 1}, reversed.ToArray())
+
+	var unknown ArrayList[[]int]
+	assert.NotNil(json.Unmarshal([]byte("[[1]]"), &unknown))
+	assert.NotNil(json.Unmarshal([]byte("{}"), &decoded))
+}
+
 func TestArrayListNew(t *testing.T) {
 	assert := assert.New(t)
 
@@ -210,11 +270,87 @@ func TestArrayListNew(t *testing.T) {
 	}
 }
 
+func TestArrayListNewComparable(t *testing.T) {
+	assert := assert.New(t)
+	type point struct{ x, y int }
+	list := NewComparable(point{1, 2}, point{3, 4}, point{5, 6})
+
+	assert.True(list.Contains(point{3, 4}))
+	assert.False(list.Contains(point{4, 3}))
+	assert.Equal(2, list.IndexOf(point{5, 6}))
+
+	list.Remove(point{1, 2})
+	assert.Equal([]point{{3, 4}, {5, 6}}, list.ToArray())
+
+	assert.ErrorIs(list.Sort(), comparator.ErrUnordered)
+	assert.ErrorIs(list.Copy().Sort(), comparator.ErrUnordered)
+	assert.ErrorIs(list.Filter(func(point) bool { return true }).Sort(), comparator.ErrUnordered)
+	assert.ErrorIs(list.SubList(0, 1).Sort(), comparator.ErrUnordered)
+
+	decoded := NewComparable[string]()
+	assert.Nil(json.Unmarshal([]byte(`["a", "b"]`), decoded))
+	assert.True(decoded.Contains("b"))
+	assert.ErrorIs(decoded.Sort(), comparator.ErrUnordered)
+}
+
+func TestArrayListNewWithEqualer(t *testing.T) {
+	assert := assert.New(t)
+	list := NewWithEqualer(slices.Equal[[]int], []int{1, 2}, []int{3})
+
+	assert.True(list.Contains([]int{3}))
+	assert.Equal(0, list.IndexOf([]int{1, 2}))
+	assert.Equal(-1, list.IndexOf([]int{2, 1}))
+	assert.ErrorIs(list.Sort(), comparator.ErrUnordered)
+
+	sorted := New(comparator.IntComparator, 2, 1)
+	assert.Nil(sorted.Sort())
+	assert.Equal([]int{1, 2}, sorted.ToArray())
+}
+
+func TestArrayListNoComparator(t *testing.T) {
+	assert := assert.New(t)
+	type point struct{ x, y int }
+	list := New[point](nil, point{3, 4}, point{1, 2})
+
+	assert.True(list.Contains(point{1, 2}))
+	assert.False(list.Contains(point{2, 1}))
+	assert.Equal(1, list.IndexOf(point{1, 2}))
+	assert.ErrorIs(list.Sort(), comparator.ErrNoComparator)
+	list.Remove(point{3, 4})
+	assert.Equal([]point{{1, 2}}, list.ToArray())
+
+	// The registered comparator is used to sort
+	numbers := New[int](nil, 3, 1)
+	assert.Nil(numbers.Sort())
+	assert.Equal([]int{1, 3}, numbers.ToArray())
+
+	// Elements that are not comparable are compared by their content
+	nested := New[[]int](nil, []int{1, 2})
+	assert.Equal(0, nested.IndexOf([]int{1, 2}))
+}
+
 func TestArrayListPrint(t *testing.T) {
 	list := New(comparator.IntComparator, 1, 2, 3)
 	list.Print()
 }
 
+func TestArrayListString(t *testing.T) {
+	assert := assert.New(t)
+	list := New(comparator.IntComparator, 1, 2, 3)
+
+	assert.Equal("[1, 2, 3]", list.String())
+	assert.Equal("[1, 2, 3]", fmt.Sprint(list))
+	assert.Equal("ArrayList(size=3) [1, 2, 3]", fmt.Sprintf("%+v", list))
+	assert.Equal(`&arraylist.ArrayList[int]{1, 2, 3}`, fmt.Sprintf("%#v", list))
+	assert.Equal("[01, 02, 03]", fmt.Sprintf("%02d", list))
+
+	var builder strings.Builder
+	count, err := list.WriteTo(&builder)
+	assert.Nil(err)
+	assert.Equal(int64(len("[1, 2, 3]")), count)
+	assert.Equal("[1, 2, 3]", builder.String())
+}
+
 func TestArryListRemove(t *testing.T) {
 	assert := assert.New(t)
 	list := New[int](comparator.IntComparator)
@@ -364,3 +500,41 @@ func TestArrayListToArray(t *testing.T) {
 		assert.Equal(item, element)
 	}
 }
+
+func TestArrayListAll(t *testing.T) {
+	assert := assert.New(t)
+	list := New(comparator.IntComparator, 1, 2, 3, 4)
+
+	indexes := []int{}
+	values := []int{}
+	for index, value := range list.All() {
+		if index == 2 {
+			break
+		}
+		indexes = append(indexes, index)
+		values = append(values, value)
+	}
+
+	assert.Equal([]int{0, 1}, indexes)
+	assert.Equal([]int{1, 2}, values)
+}
+
+func TestArrayListValues(t *testing.T) {
+	assert := assert.New(t)
+	list := New(comparator.IntComparator, 1, 2, 3, 4)
+
+	values := []int{}
+	for value := range list.Values() {
+		values = append(values, value)
+	}
+	assert.Equal([]int{1, 2, 3, 4}, values)
+
+	values = []int{}
+	for value := range list.Values() {
+		if value == 3 {
+			break
+		}
+		values = append(values, value)
+	}
+	assert.Equal([]int{1, 2}, values)
+}
@@ -0,0 +1,16 @@
+package arraylist_test
+
+import (
+	"testing"
+
+	"github.com/dterbah/gods/collection/collectiontest"
+	"github.com/dterbah/gods/list"
+	"github.com/dterbah/gods/list/arraylist"
+	comparator "github.com/dterbah/gods/utils"
+)
+
+func TestArrayListConformance(t *testing.T) {
+	collectiontest.TestList(t, func() list.List[int] {
+		return arraylist.New(comparator.IntComparator)
+	})
+}
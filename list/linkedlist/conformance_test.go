@@ -0,0 +1,16 @@
+package linkedlist_test
+
+import (
+	"testing"
+
+	"github.com/dterbah/gods/collection/collectiontest"
+	"github.com/dterbah/gods/list"
+	"github.com/dterbah/gods/list/linkedlist"
+	comparator "github.com/dterbah/gods/utils"
+)
+
+func TestLinkedListConformance(t *testing.T) {
+	collectiontest.TestList(t, func() list.List[int] {
+		return linkedlist.New(comparator.IntComparator)
+	})
+}
@@ -1,10 +1,15 @@
 package linkedlist
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"iter"
 
 	"github.com/dterbah/gods/collection"
+	"github.com/dterbah/gods/internal/format"
+	"github.com/dterbah/gods/internal/serialization"
 	"github.com/dterbah/gods/iterable"
 	"github.com/dterbah/gods/list"
 	comparator "github.com/dterbah/gods/utils"
@@ -25,6 +30,8 @@ type LinkedList[T any] struct {
 	size        int
 	zeroElement T
 	comparator  comparator.Comparator[T]
+	// True if the comparator only reports the equality of the elements
+	unordered bool
 }
 
 func New[T any](comparator comparator.Comparator[T], elements ...T) *LinkedList[T] {
@@ -49,6 +56,24 @@ func FromIterable[T any](iterable iterable.Iterable[T],
 	return list
 }
 
+/*
+Create a new LinkedList comparing its elements with the == operator.
+As its elements have no ordering, the list cannot be sorted
+*/
+func NewComparable[T comparable](elements ...T) *LinkedList[T] {
+	return NewWithEqualer(comparator.Equal[T], elements...)
+}
+
+/*
+Create a new LinkedList comparing its elements with the equaler.
+As its elements have no ordering, the list cannot be sorted
+*/
+func NewWithEqualer[T any](equaler comparator.Equaler[T], elements ...T) *LinkedList[T] {
+	list := New(comparator.FromEqualer(equaler), elements...)
+	list.unordered = true
+	return list
+}
+
 /*
 Add elements at the end of the list
 */
@@ -77,6 +102,22 @@ func (list *LinkedList[T]) AddAll(elements collection.Collection[T]) {
 	}
 }
 
+/*
+Return an iterator over the indexes and the elements of the list.
+The iteration stops as soon as the loop body breaks
+*/
+func (list *LinkedList[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		index := 0
+		for node := list.head; node != nil; node = node.next {
+			if !yield(index, node.value) {
+				return
+			}
+			index++
+		}
+	}
+}
+
 /*
 Retrieve an element by its index
 If the index is negative or greater than the list size, the method will return an error
@@ -115,13 +156,18 @@ func (list *LinkedList[T]) Clear() {
 	list.size = 0
 }
 
+/*
+Return true if the list contains at least one occurence of the element, else false.
+If the list has no comparator, the elements are compared as in comparator.ResolveEquality
+*/
 func (list LinkedList[T]) Contains(element T) bool {
 	if list.head == nil {
 		return false
 	}
 
+	equal := comparator.ResolveEquality(list.comparator)
 	for node := list.head; node != nil; node = node.next {
-		if list.comparator(node.value, element) == 0 {
+		if equal(node.value, element) == 0 {
 			return true
 		}
 	}
@@ -141,7 +187,7 @@ func (list LinkedList[T]) ContainsAll(collection collection.Collection[T]) bool
 }
 
 func (list *LinkedList[T]) Copy() list.List[T] {
-	newList := New[T](list.comparator)
+	newList := list.emptyCopy()
 
 	list.ForEach(func(element T, index int) {
 		newList.Add(element)
@@ -150,6 +196,13 @@ func (list *LinkedList[T]) Copy() list.List[T] {
 	return newList
 }
 
+/*
+Create an empty LinkedList with the same comparator, without copying the elements
+*/
+func (list *LinkedList[T]) Empty() list.List[T] {
+	return list.emptyCopy()
+}
+
 func (list LinkedList[T]) Every(callback func(element T, index int) bool) bool {
 	result := true
 
@@ -164,7 +217,7 @@ func (list LinkedList[T]) Every(callback func(element T, index int) bool) bool {
 Return new list with elements matching the function passed in parameter
 */
 func (list LinkedList[T]) Filter(callback func(element T) bool) list.List[T] {
-	newList := New(list.comparator)
+	newList := list.emptyCopy()
 
 	for node := list.head; node != nil; node = node.next {
 		if callback(node.value) {
@@ -188,6 +241,29 @@ func (list LinkedList[T]) ForEach(callback func(element T, index int)) {
 	}
 }
 
+/*
+Format the list for the fmt package: %v writes its elements, %+v also writes
+its size, and %#v writes a Go-syntax representation.
+The other verbs are applied to each element
+*/
+func (list *LinkedList[T]) Format(state fmt.State, verb rune) {
+	format.Format(state, verb, list, list.describe())
+}
+
+/*
+Decode the list from the gob encoding, see UnmarshalBinary
+*/
+func (list *LinkedList[T]) GobDecode(data []byte) error {
+	return list.UnmarshalBinary(data)
+}
+
+/*
+Encode the list for gob, see MarshalBinary
+*/
+func (list *LinkedList[T]) GobEncode() ([]byte, error) {
+	return list.MarshalBinary()
+}
+
 /*
 Return the value of the list's head
 */
@@ -201,13 +277,14 @@ func (list *LinkedList[T]) Head() T {
 
 /*
 Return the index of the specified element if present in the list.
-If not, return -1
+If not, return -1. If the list has no comparator, the elements are compared as in comparator.ResolveEquality
 */
 func (list LinkedList[T]) IndexOf(element T) int {
 	index := 0
+	equal := comparator.ResolveEquality(list.comparator)
 
 	for node := list.head; node != nil; node = node.next {
-		if list.comparator(element, node.value) == 0 {
+		if equal(element, node.value) == 0 {
 			return index
 		}
 
@@ -221,26 +298,36 @@ func (list LinkedList[T]) IsEmpty() bool {
 	return list.head == nil && list.tail == nil
 }
 
+/*
+Encode the list in a binary format made of a versioned header
+followed by the gob encoding of its elements
+*/
+func (list *LinkedList[T]) MarshalBinary() ([]byte, error) {
+	return serialization.Marshal(list.ToArray())
+}
+
+/*
+Encode the list as a JSON array of its elements
+*/
+func (list *LinkedList[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(list.ToArray())
+}
+
 func (list LinkedList[T]) Print() {
-	fmt.Print("[")
-	for current := list.head; current != nil; current = current.next {
-		fmt.Print(current.value)
-		if current.next != nil {
-			fmt.Print(", ")
-		}
-	}
-	fmt.Println("]")
+	fmt.Println(list.String())
 }
 
 /*
-Remove the first occurence of element in the list
+Remove the first occurence of element in the list.
+If the list has no comparator, the elements are compared as in comparator.ResolveEquality
 */
 func (list *LinkedList[T]) Remove(element T) {
 	if list.head == nil {
 		return
 	}
 
-	if list.comparator(list.head.value, element) == 0 {
+	equal := comparator.ResolveEquality(list.comparator)
+	if equal(list.head.value, element) == 0 {
 		list.head = list.head.next
 		if list.head == nil {
 			list.tail = nil
@@ -253,7 +340,7 @@ func (list *LinkedList[T]) Remove(element T) {
 	current := list.head.next
 
 	for current != nil {
-		if list.comparator(current.value, element) == 0 {
+		if equal(current.value, element) == 0 {
 			previous.next = current.next
 			if current == list.tail {
 				list.tail = previous
@@ -317,18 +404,18 @@ func (list *LinkedList[T]) Reverse() {
 		return
 	}
 
-	newHead := newNode(list.tail.value)
-	var currentNode *Node[T] = newHead
+	var previous *Node[T]
+	current := list.head
+	list.tail = list.head
 
-	for i := list.size - 2; i >= 0; i-- {
-		value, _ := list.At(i)
-		node := newNode(value)
-		currentNode.next = node
-		currentNode = node
+	for current != nil {
+		next := current.next
+		current.next = previous
+		previous = current
+		current = next
 	}
 
-	list.tail = currentNode
-	list.head = newHead
+	list.head = previous
 }
 
 func (list LinkedList[T]) Some(callback func(element T, index int) bool) bool {
@@ -363,6 +450,13 @@ func splitList[T any](head *Node[T]) (*Node[T], *Node[T]) {
 	return head, slow
 }
 
+/*
+Return the elements of the list as a string, e.g. [1, 2, 3]
+*/
+func (list *LinkedList[T]) String() string {
+	return format.String(list.describe())
+}
+
 func (list *LinkedList[T]) ToArray() []T {
 	elements := []T{}
 
@@ -402,8 +496,31 @@ func mergeSort[T any](head *Node[T], comparator comparator.Comparator[T]) *Node[
 	return mergeLists(left, right, comparator)
 }
 
-func (list *LinkedList[T]) Sort() {
+/*
+Sort the list. If the list was created with an equaler, it has no ordering
+and this method will return an error. If the list has no comparator, the one
+registered for the type of the elements is used, else it returns comparator.ErrNoComparator
+*/
+func (list *LinkedList[T]) Sort() error {
+	if list.comparator == nil {
+		resolved, err := comparator.Default[T]()
+		if err != nil {
+			return comparator.ErrNoComparator
+		}
+		list.comparator = resolved
+	}
+
+	if list.unordered {
+		return comparator.ErrUnordered
+	}
+
 	list.head = mergeSort[T](list.head, list.comparator)
+	list.tail = list.head
+	for list.tail != nil && list.tail.next != nil {
+		list.tail = list.tail.next
+	}
+
+	return nil
 }
 
 func (list *LinkedList[T]) SubList(start, end int) list.List[T] {
@@ -415,11 +532,11 @@ func (list *LinkedList[T]) SubList(start, end int) list.List[T] {
 		return list
 	}
 
-	newList := New(list.comparator)
+	newList := list.emptyCopy()
 	index := 0
 
 	for node := list.head; node != nil; node = node.next {
-		if index >= start && index <= end {
+		if index >= start && index < end {
 			newList.Add(node.value)
 		}
 		index++
@@ -446,7 +563,88 @@ func (list *LinkedList[T]) Size() int {
 	return list.size
 }
 
+/*
+Decode the binary format written by MarshalBinary into the list, replacing its content
+with new nodes. The comparator of the list is kept. If the list has no comparator,
+the one registered for the type of the elements is used, else this method will return an error
+*/
+func (list *LinkedList[T]) UnmarshalBinary(data []byte) error {
+	var elements []T
+	if err := serialization.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	return list.load(elements)
+}
+
+/*
+Decode a JSON array of elements into the list, replacing its content.
+The comparator of the list is kept. If the list has no comparator,
+the one registered for the type of the elements is used
+*/
+func (list *LinkedList[T]) UnmarshalJSON(data []byte) error {
+	var elements []T
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	return list.load(elements)
+}
+
+/*
+Return an iterator over the elements of the list.
+The iteration stops as soon as the loop body breaks
+*/
+func (list *LinkedList[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for node := list.head; node != nil; node = node.next {
+			if !yield(node.value) {
+				return
+			}
+		}
+	}
+}
+
+/*
+Write the elements of the list, as returned by String, to the writer
+*/
+func (list *LinkedList[T]) WriteTo(writer io.Writer) (int64, error) {
+	return format.WriteTo(writer, list.describe())
+}
+
 // Private functions
+
+// Describe the list for the format package
+func (list *LinkedList[T]) describe() format.Container[int, T] {
+	return format.Container[int, T]{
+		Name:  "LinkedList",
+		Open:  "[",
+		Close: "]",
+		All:   list.All(),
+		Size:  list.size,
+	}
+}
+
+// Create an empty list with the same comparator as the list
+func (list *LinkedList[T]) emptyCopy() *LinkedList[T] {
+	newList := New(list.comparator)
+	newList.unordered = list.unordered
+	return newList
+}
+
 func (list *LinkedList[T]) isOutOfBound(index int) bool {
 	return index < 0 || index >= list.size
 }
+
+// Replace the content of the list by the elements, resolving the comparator if needed
+func (list *LinkedList[T]) load(elements []T) error {
+	comparator, err := comparator.Resolve(list.comparator)
+	if err != nil {
+		return err
+	}
+
+	unordered := list.unordered
+	*list = *New(comparator, elements...)
+	list.unordered = unordered
+	return nil
+}
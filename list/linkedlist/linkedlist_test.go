@@ -1,6 +1,12 @@
 package linkedlist
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/dterbah/gods/list/arraylist"
@@ -62,6 +68,29 @@ func TestLinkedListAt(t *testing.T) {
 	assert.NotNil(err)
 }
 
+func TestLinkedListBinary(t *testing.T) {
+	assert := assert.New(t)
+	original := New(comparator.IntComparator, 3, 1, 2)
+
+	data, err := original.MarshalBinary()
+	assert.Nil(err)
+
+	var decoded LinkedList[int]
+	assert.Nil(decoded.UnmarshalBinary(data))
+	assert.Equal([]int{3, 1, 2}, decoded.ToArray())
+
+	// Containers can be embedded in gob encoded structs
+	var buffer bytes.Buffer
+	assert.Nil(gob.NewEncoder(&buffer).Encode(struct{ Container *LinkedList[int] }{original}))
+
+	var checkpoint struct{ Container *LinkedList[int] }
+	assert.Nil(gob.NewDecoder(&buffer).Decode(&checkpoint))
+	decoded = *checkpoint.Container
+	assert.Equal([]int{3, 1, 2}, decoded.ToArray())
+
+	assert.NotNil(decoded.UnmarshalBinary([]byte("invalid")))
+}
+
 func TestLinkedListClear(t *testing.T) {
 	assert := assert.New(t)
 	list := New[int](comparator.IntComparator, 1, 2, 3)
@@ -197,6 +226,75 @@ func TestLinkedListIsEmpty(t *testing.T) {
 	assert.False(list.IsEmpty())
 }
 
+func TestLinkedListJSON(t *testing.T) {
+	assert := assert.New(t)
+	list := New(comparator.StringComparator, "b", "a")
+
+	data, err := json.Marshal(list)
+	assert.Nil(err)
+	assert.Equal(`["b","a"]`, string(data))
+
+	decoded := &LinkedList[string]{}
+	assert.Nil(json.Unmarshal(data, decoded))
+	assert.Equal([]string{"b", "a"}, decoded.ToArray())
+	assert.Equal(1, decoded.IndexOf("a"))
+}
+
+func TestLinkedListNewComparable(t *testing.T) {
+	assert := assert.New(t)
+	type point struct{ x, y int }
+	list := NewComparable(point{1, 2}, point{3, 4}, point{5, 6})
+
+	assert.True(list.Contains(point{3, 4}))
+	assert.False(list.Contains(point{4, 3}))
+	assert.Equal(2, list.IndexOf(point{5, 6}))
+
+	list.Remove(point{1, 2})
+	assert.Equal([]point{{3, 4}, {5, 6}}, list.ToArray())
+
+	assert.ErrorIs(list.Sort(), comparator.ErrUnordered)
+	assert.ErrorIs(list.Copy().Sort(), comparator.ErrUnordered)
+	assert.ErrorIs(list.Filter(func(point) bool { return true }).Sort(), comparator.ErrUnordered)
+	assert.ErrorIs(list.SubList(0, 1).Sort(), comparator.ErrUnordered)
+
+	decoded := NewComparable[string]()
+	assert.Nil(json.Unmarshal([]byte(`["a", "b"]`), decoded))
+	assert.True(decoded.Contains("b"))
+	assert.ErrorIs(decoded.Sort(), comparator.ErrUnordered)
+}
+
+func TestLinkedListNewWithEqualer(t *testing.T) {
+	assert := assert.New(t)
+	list := NewWithEqualer(slices.Equal[[]int], []int{1, 2}, []int{3})
+
+	assert.True(list.Contains([]int{3}))
+	assert.Equal(0, list.IndexOf([]int{1, 2}))
+	assert.Equal(-1, list.IndexOf([]int{2, 1}))
+	assert.ErrorIs(list.Sort(), comparator.ErrUnordered)
+
+	sorted := New(comparator.IntComparator, 2, 1)
+	assert.Nil(sorted.Sort())
+	assert.Equal([]int{1, 2}, sorted.ToArray())
+}
+
+func TestLinkedListNoComparator(t *testing.T) {
+	assert := assert.New(t)
+	type point struct{ x, y int }
+	list := New[point](nil, point{3, 4}, point{1, 2})
+
+	assert.True(list.Contains(point{1, 2}))
+	assert.False(list.Contains(point{2, 1}))
+	assert.Equal(1, list.IndexOf(point{1, 2}))
+	assert.ErrorIs(list.Sort(), comparator.ErrNoComparator)
+	list.Remove(point{3, 4})
+	assert.Equal([]point{{1, 2}}, list.ToArray())
+
+	// The registered comparator is used to sort
+	numbers := New[int](nil, 3, 1)
+	assert.Nil(numbers.Sort())
+	assert.Equal([]int{1, 3}, numbers.ToArray())
+}
+
 func TestLinkedListNodeAt(t *testing.T) {
 	assert := assert.New(t)
 	list := New(comparator.IntComparator, 1, 2, 3)
@@ -254,6 +352,22 @@ func TestLinkedListRemove(t *testing.T) {
 	})
 }
 
+func TestLinkedListString(t *testing.T) {
+	assert := assert.New(t)
+	list := New(comparator.StringComparator, "a", "b")
+
+	assert.Equal("[a, b]", list.String())
+	assert.Equal("[a, b]", fmt.Sprint(list))
+	assert.Equal("LinkedList(size=2) [a, b]", fmt.Sprintf("%+v", list))
+	assert.Equal(`&linkedlist.LinkedList[string]{"a", "b"}`, fmt.Sprintf("%#v", list))
+
+	var builder strings.Builder
+	count, err := list.WriteTo(&builder)
+	assert.Nil(err)
+	assert.Equal(int64(len("[a, b]")), count)
+	assert.Equal("[a, b]", builder.String())
+}
+
 func TestLinkedTestRemoveAt(t *testing.T) {
 	assert := assert.New(t)
 
@@ -355,7 +469,7 @@ func TestLinkedListSubList(t *testing.T) {
 	assert.Equal(list, subList)
 
 	// normal cases
-	subList = list.SubList(1, 2)
+	subList = list.SubList(1, 3)
 	assert.Equal(2, subList.Size())
 
 	value, err := subList.At(0)
@@ -392,3 +506,41 @@ func TestLinkedListToArray(t *testing.T) {
 		assert.Equal(el, elements[index])
 	}
 }
+
+func TestLinkedListAll(t *testing.T) {
+	assert := assert.New(t)
+	list := New(comparator.IntComparator, 1, 2, 3, 4)
+
+	indexes := []int{}
+	values := []int{}
+	for index, value := range list.All() {
+		if index == 2 {
+			break
+		}
+		indexes = append(indexes, index)
+		values = append(values, value)
+	}
+
+	assert.Equal([]int{0, 1}, indexes)
+	assert.Equal([]int{1, 2}, values)
+}
+
+func TestLinkedListValues(t *testing.T) {
+	assert := assert.New(t)
+	list := New(comparator.IntComparator, 1, 2, 3, 4)
+
+	values := []int{}
+	for value := range list.Values() {
+		values = append(values, value)
+	}
+	assert.Equal([]int{1, 2, 3, 4}, values)
+
+	values = []int{}
+	for value := range list.Values() {
+		if value == 3 {
+			break
+		}
+		values = append(values, value)
+	}
+	assert.Equal([]int{1, 2}, values)
+}
@@ -18,6 +18,11 @@ type List[T any] interface {
 	*/
 	Copy() List[T]
 
+	/*
+		Create an empty list of the same implementation, with the same comparator
+	*/
+	Empty() List[T]
+
 	/*
 		Check if all the elements matchs with the callback in parameter
 	*/
@@ -52,12 +57,14 @@ type List[T any] interface {
 	Some(callback func(element T, index int) bool) bool
 
 	/*
-		Sort the list
+		Sort the list. If the list only knows the equality of its elements,
+		e.g. when created with an equaler, it will return an error
 	*/
-	Sort()
+	Sort() error
 
 	/*
-		Return a list with the elements between in the range of start:end
+		Return a list with the elements between in the range of start:end,
+		the element at the index end being excluded
 	*/
 	SubList(start, end int) List[T]
 }
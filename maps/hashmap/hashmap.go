@@ -0,0 +1,261 @@
+package hashmap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/dterbah/gods/internal/format"
+	"github.com/dterbah/gods/internal/serialization"
+	"github.com/dterbah/gods/list"
+	"github.com/dterbah/gods/list/arraylist"
+	"github.com/dterbah/gods/maps"
+	"github.com/dterbah/gods/set"
+	"github.com/dterbah/gods/set/hashset"
+	comparator "github.com/dterbah/gods/utils"
+)
+
+/*
+Struct that defines what is a HashMap. The entries are stored in a hash table,
+so Put, Get and Remove run in O(1) on average. The iteration order is not specified
+*/
+type HashMap[K comparable, V any] struct {
+	entries   map[K]V
+	zeroValue V
+}
+
+/*
+Create a new HashMap
+*/
+func New[K comparable, V any]() *HashMap[K, V] {
+	var zero V
+	return &HashMap[K, V]{entries: make(map[K]V), zeroValue: zero}
+}
+
+/*
+Return an iterator over the keys and the values of the map.
+The iteration stops as soon as the loop body breaks
+*/
+func (hashMap *HashMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for key, value := range hashMap.entries {
+			if !yield(key, value) {
+				return
+			}
+		}
+	}
+}
+
+/*
+Remove all the entries of the map
+*/
+func (hashMap *HashMap[K, V]) Clear() {
+	hashMap.entries = make(map[K]V)
+}
+
+/*
+Return true if the map contains an entry for the key, else false
+*/
+func (hashMap *HashMap[K, V]) ContainsKey(key K) bool {
+	_, exists := hashMap.entries[key]
+	return exists
+}
+
+/*
+Return the entries of the map
+*/
+func (hashMap *HashMap[K, V]) Entries() []maps.Entry[K, V] {
+	entries := make([]maps.Entry[K, V], 0, len(hashMap.entries))
+	for key, value := range hashMap.entries {
+		entries = append(entries, maps.Entry[K, V]{Key: key, Value: value})
+	}
+
+	return entries
+}
+
+/*
+Call a function for each entry of the map
+*/
+func (hashMap *HashMap[K, V]) ForEach(callback func(key K, value V)) {
+	for key, value := range hashMap.entries {
+		callback(key, value)
+	}
+}
+
+/*
+Format the map for the fmt package: %v writes its entries, %+v also writes
+its size, and %#v writes a Go-syntax representation.
+The other verbs are applied to each key and value
+*/
+func (hashMap *HashMap[K, V]) Format(state fmt.State, verb rune) {
+	format.Format(state, verb, hashMap, hashMap.describe())
+}
+
+/*
+Retrieve the value associated to the key.
+If the key is not present in the map, the method will return an error
+*/
+func (hashMap *HashMap[K, V]) Get(key K) (V, error) {
+	value, exists := hashMap.entries[key]
+	if !exists {
+		return hashMap.zeroValue, errors.New("key not found")
+	}
+
+	return value, nil
+}
+
+/*
+Decode the map from the gob encoding, see UnmarshalBinary
+*/
+func (hashMap *HashMap[K, V]) GobDecode(data []byte) error {
+	return hashMap.UnmarshalBinary(data)
+}
+
+/*
+Encode the map for gob, see MarshalBinary
+*/
+func (hashMap *HashMap[K, V]) GobEncode() ([]byte, error) {
+	return hashMap.MarshalBinary()
+}
+
+/*
+Return true if the map has no entries, else false
+*/
+func (hashMap *HashMap[K, V]) IsEmpty() bool {
+	return len(hashMap.entries) == 0
+}
+
+/*
+Return the keys of the map in a HashSet
+*/
+func (hashMap *HashMap[K, V]) Keys() set.BasicSet[K] {
+	keys := hashset.New[K]()
+	for key := range hashMap.entries {
+		keys.Add(key)
+	}
+
+	return keys
+}
+
+/*
+Encode the map in a binary format made of a versioned header
+followed by the gob encoding of its entries
+*/
+func (hashMap *HashMap[K, V]) MarshalBinary() ([]byte, error) {
+	return serialization.Marshal(hashMap.Entries())
+}
+
+/*
+Encode the map as a JSON array of objects with a "key" and a "value" field, in no particular order
+*/
+func (hashMap *HashMap[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hashMap.Entries())
+}
+
+/*
+Associate the value to the key. If the key is already present in
+the map, its value is replaced
+*/
+func (hashMap *HashMap[K, V]) Put(key K, value V) {
+	hashMap.entries[key] = value
+}
+
+/*
+Remove the entry of the key. Return true if the entry was removed, else false
+*/
+func (hashMap *HashMap[K, V]) Remove(key K) bool {
+	if _, exists := hashMap.entries[key]; !exists {
+		return false
+	}
+
+	delete(hashMap.entries, key)
+	return true
+}
+
+/*
+Return the number of entries in the map
+*/
+func (hashMap *HashMap[K, V]) Size() int {
+	return len(hashMap.entries)
+}
+
+/*
+Return the entries of the map as a string, e.g. {a: 1, b: 2}
+*/
+func (hashMap *HashMap[K, V]) String() string {
+	return format.String(hashMap.describe())
+}
+
+/*
+Decode the binary format written by MarshalBinary into the map, replacing its content
+*/
+func (hashMap *HashMap[K, V]) UnmarshalBinary(data []byte) error {
+	var entries []maps.Entry[K, V]
+	if err := serialization.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+
+	return hashMap.load(entries)
+}
+
+/*
+Decode a JSON array of objects with a "key" and a "value" field into the map,
+replacing its content. When a key is present several times, the last value is kept
+*/
+func (hashMap *HashMap[K, V]) UnmarshalJSON(data []byte) error {
+	var entries []maps.Entry[K, V]
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+
+	return hashMap.load(entries)
+}
+
+/*
+Return the values of the map in an ArrayList. The list uses the comparator registered
+for the type of the values, if any. Otherwise, Contains, IndexOf and Remove compare
+the values with == (or reflect.DeepEqual) and Sort returns comparator.ErrNoComparator
+*/
+func (hashMap *HashMap[K, V]) Values() list.List[V] {
+	// The comparator is optional, so a missing default comparator is not an error
+	valueComparator, _ := comparator.Default[V]()
+	values := arraylist.New(valueComparator)
+	for _, value := range hashMap.entries {
+		values.Add(value)
+	}
+
+	return values
+}
+
+/*
+Write the entries of the map, as returned by String, to the writer
+*/
+func (hashMap *HashMap[K, V]) WriteTo(writer io.Writer) (int64, error) {
+	return format.WriteTo(writer, hashMap.describe())
+}
+
+// Private methods
+
+// Describe the map for the format package
+func (hashMap *HashMap[K, V]) describe() format.Container[K, V] {
+	return format.Container[K, V]{
+		Name:  "HashMap",
+		Open:  "{",
+		Close: "}",
+		All:   hashMap.All(),
+		Keyed: true,
+		Size:  hashMap.Size(),
+	}
+}
+
+// Replace the content of the map by the entries
+func (hashMap *HashMap[K, V]) load(entries []maps.Entry[K, V]) error {
+	*hashMap = *New[K, V]()
+	for _, entry := range entries {
+		hashMap.Put(entry.Key, entry.Value)
+	}
+
+	return nil
+}
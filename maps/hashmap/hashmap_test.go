@@ -0,0 +1,194 @@
+package hashmap
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dterbah/gods/maps"
+	comparator "github.com/dterbah/gods/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashMapBinary(t *testing.T) {
+	assert := assert.New(t)
+	original := New[string, int]()
+	original.Put("a", 1)
+
+	data, err := original.MarshalBinary()
+	assert.Nil(err)
+
+	var decoded HashMap[string, int]
+	assert.Nil(decoded.UnmarshalBinary(data))
+	assert.Equal(original.Entries(), decoded.Entries())
+
+	// Containers can be embedded in gob encoded structs
+	var buffer bytes.Buffer
+	assert.Nil(gob.NewEncoder(&buffer).Encode(struct{ Container *HashMap[string, int] }{original}))
+
+	var checkpoint struct{ Container *HashMap[string, int] }
+	assert.Nil(gob.NewDecoder(&buffer).Decode(&checkpoint))
+	decoded = *checkpoint.Container
+	assert.Equal(original.Entries(), decoded.Entries())
+
+	assert.NotNil(decoded.UnmarshalBinary([]byte("invalid")))
+}
+
+func TestHashMapClear(t *testing.T) {
+	assert := assert.New(t)
+	m := New[string, int]()
+	m.Put("a", 1)
+	m.Clear()
+
+	assert.True(m.IsEmpty())
+	assert.False(m.ContainsKey("a"))
+}
+
+func TestHashMapContainsKey(t *testing.T) {
+	assert := assert.New(t)
+	m := New[string, int]()
+	m.Put("a", 1)
+
+	assert.True(m.ContainsKey("a"))
+	assert.False(m.ContainsKey("b"))
+}
+
+func TestHashMapGet(t *testing.T) {
+	assert := assert.New(t)
+	m := New[string, int]()
+
+	_, err := m.Get("a")
+	assert.NotNil(err)
+
+	m.Put("a", 1)
+	value, err := m.Get("a")
+	assert.Nil(err)
+	assert.Equal(1, value)
+}
+
+func TestHashMapImplementsMap(t *testing.T) {
+	var m maps.Map[string, int] = New[string, int]()
+	assert.True(t, m.IsEmpty())
+}
+
+func TestHashMapJSON(t *testing.T) {
+	assert := assert.New(t)
+	m := New[string, int]()
+	m.Put("a", 1)
+
+	data, err := json.Marshal(m)
+	assert.Nil(err)
+	assert.Equal(`[{"key":"a","value":1}]`, string(data))
+
+	var decoded HashMap[string, int]
+	assert.Nil(json.Unmarshal([]byte(`[{"key":"a","value":1},{"key":"b","value":2},{"key":"a","value":3}]`), &decoded))
+	assert.Equal(2, decoded.Size())
+	value, _ := decoded.Get("a")
+	assert.Equal(3, value)
+}
+
+func TestHashMapPut(t *testing.T) {
+	assert := assert.New(t)
+	m := New[string, int]()
+
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("a", 3)
+
+	assert.Equal(2, m.Size())
+	value, _ := m.Get("a")
+	assert.Equal(3, value)
+}
+
+func TestHashMapRemove(t *testing.T) {
+	assert := assert.New(t)
+	m := New[string, int]()
+	m.Put("a", 1)
+	m.Put("b", 2)
+
+	assert.True(m.Remove("a"))
+	assert.False(m.Remove("a"))
+	assert.Equal(1, m.Size())
+	assert.False(m.ContainsKey("a"))
+}
+
+func TestHashMapKeysValues(t *testing.T) {
+	assert := assert.New(t)
+	m := New[string, int]()
+	m.Put("a", 1)
+	m.Put("b", 2)
+
+	keys := m.Keys()
+	assert.Equal(2, keys.Size())
+	assert.True(keys.Contains("a"))
+	assert.True(keys.Contains("b"))
+
+	assert.ElementsMatch([]int{1, 2}, m.Values().ToArray())
+	assert.ElementsMatch([]maps.Entry[string, int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}}, m.Entries())
+}
+
+func TestHashMapAll(t *testing.T) {
+	assert := assert.New(t)
+	m := New[string, int]()
+	m.Put("a", 1)
+	m.Put("b", 2)
+
+	count := 0
+	for range m.All() {
+		count++
+		break
+	}
+	assert.Equal(1, count)
+
+	sum := 0
+	m.ForEach(func(key string, value int) {
+		sum += value
+	})
+	assert.Equal(3, sum)
+}
+
+func TestHashMapString(t *testing.T) {
+	assert := assert.New(t)
+	m := New[string, int]()
+	m.Put("a", 1)
+
+	assert.Equal("{a: 1}", m.String())
+	assert.Equal("{a: 1}", fmt.Sprint(m))
+	assert.Equal("HashMap(size=1) {a: 1}", fmt.Sprintf("%+v", m))
+	assert.Equal(`&hashmap.HashMap[string,int]{"a": 1}`, fmt.Sprintf("%#v", m))
+
+	var builder strings.Builder
+	count, err := m.WriteTo(&builder)
+	assert.Nil(err)
+	assert.Equal(int64(len("{a: 1}")), count)
+	assert.Equal("{a: 1}", builder.String())
+}
+
+func TestHashMapValuesComparator(t *testing.T) {
+	assert := assert.New(t)
+	m := New[string, int]()
+	m.Put("a", 2)
+	m.Put("b", 1)
+
+	values := m.Values()
+	assert.True(values.Contains(2))
+	assert.Nil(values.Sort())
+	assert.Equal([]int{1, 2}, values.ToArray())
+	values.Remove(1)
+	assert.Equal([]int{2}, values.ToArray())
+
+	// No comparator is registered for the struct values
+	type point struct{ x, y int }
+	points := New[string, point]()
+	points.Put("a", point{1, 2})
+
+	pointValues := points.Values()
+	assert.True(pointValues.Contains(point{1, 2}))
+	assert.Equal(0, pointValues.IndexOf(point{1, 2}))
+	assert.ErrorIs(pointValues.Sort(), comparator.ErrNoComparator)
+	pointValues.Remove(point{1, 2})
+	assert.True(pointValues.IsEmpty())
+}
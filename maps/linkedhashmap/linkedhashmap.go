@@ -0,0 +1,300 @@
+package linkedhashmap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/dterbah/gods/internal/format"
+	"github.com/dterbah/gods/internal/serialization"
+	"github.com/dterbah/gods/list"
+	"github.com/dterbah/gods/list/arraylist"
+	"github.com/dterbah/gods/maps"
+	"github.com/dterbah/gods/set"
+	"github.com/dterbah/gods/set/hashset"
+	comparator "github.com/dterbah/gods/utils"
+)
+
+type Node[K comparable, V any] struct {
+	key      K
+	value    V
+	previous *Node[K, V]
+	next     *Node[K, V]
+}
+
+/*
+Struct that defines what is a LinkedHashMap. The entries are stored in a hash table
+and chained in a doubly linked list, so the map is iterated in insertion order.
+Replacing the value of an existing key does not change its position
+*/
+type LinkedHashMap[K comparable, V any] struct {
+	nodes     map[K]*Node[K, V]
+	head      *Node[K, V]
+	tail      *Node[K, V]
+	zeroValue V
+}
+
+/*
+Create a new LinkedHashMap
+*/
+func New[K comparable, V any]() *LinkedHashMap[K, V] {
+	var zero V
+	return &LinkedHashMap[K, V]{nodes: make(map[K]*Node[K, V]), zeroValue: zero}
+}
+
+/*
+Return an iterator over the keys and the values of the map, in insertion order.
+The iteration stops as soon as the loop body breaks
+*/
+func (linkedMap *LinkedHashMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for node := linkedMap.head; node != nil; node = node.next {
+			if !yield(node.key, node.value) {
+				return
+			}
+		}
+	}
+}
+
+/*
+Remove all the entries of the map
+*/
+func (linkedMap *LinkedHashMap[K, V]) Clear() {
+	linkedMap.nodes = make(map[K]*Node[K, V])
+	linkedMap.head = nil
+	linkedMap.tail = nil
+}
+
+/*
+Return true if the map contains an entry for the key, else false
+*/
+func (linkedMap *LinkedHashMap[K, V]) ContainsKey(key K) bool {
+	_, exists := linkedMap.nodes[key]
+	return exists
+}
+
+/*
+Return the entries of the map in insertion order
+*/
+func (linkedMap *LinkedHashMap[K, V]) Entries() []maps.Entry[K, V] {
+	entries := make([]maps.Entry[K, V], 0, len(linkedMap.nodes))
+	for node := linkedMap.head; node != nil; node = node.next {
+		entries = append(entries, maps.Entry[K, V]{Key: node.key, Value: node.value})
+	}
+
+	return entries
+}
+
+/*
+Call a function for each entry of the map, in insertion order
+*/
+func (linkedMap *LinkedHashMap[K, V]) ForEach(callback func(key K, value V)) {
+	for node := linkedMap.head; node != nil; node = node.next {
+		callback(node.key, node.value)
+	}
+}
+
+/*
+Format the map for the fmt package: %v writes its entries, %+v also writes
+its size, and %#v writes a Go-syntax representation.
+The other verbs are applied to each key and value
+*/
+func (linkedMap *LinkedHashMap[K, V]) Format(state fmt.State, verb rune) {
+	format.Format(state, verb, linkedMap, linkedMap.describe())
+}
+
+/*
+Retrieve the value associated to the key.
+If the key is not present in the map, the method will return an error
+*/
+func (linkedMap *LinkedHashMap[K, V]) Get(key K) (V, error) {
+	node, exists := linkedMap.nodes[key]
+	if !exists {
+		return linkedMap.zeroValue, errors.New("key not found")
+	}
+
+	return node.value, nil
+}
+
+/*
+Decode the map from the gob encoding, see UnmarshalBinary
+*/
+func (linkedMap *LinkedHashMap[K, V]) GobDecode(data []byte) error {
+	return linkedMap.UnmarshalBinary(data)
+}
+
+/*
+Encode the map for gob, see MarshalBinary
+*/
+func (linkedMap *LinkedHashMap[K, V]) GobEncode() ([]byte, error) {
+	return linkedMap.MarshalBinary()
+}
+
+/*
+Return true if the map has no entries, else false
+*/
+func (linkedMap *LinkedHashMap[K, V]) IsEmpty() bool {
+	return len(linkedMap.nodes) == 0
+}
+
+/*
+Return the keys of the map in a HashSet, in insertion order
+*/
+func (linkedMap *LinkedHashMap[K, V]) Keys() set.BasicSet[K] {
+	keys := hashset.New[K]()
+	for node := linkedMap.head; node != nil; node = node.next {
+		keys.Add(node.key)
+	}
+
+	return keys
+}
+
+/*
+Encode the map in a binary format made of a versioned header
+followed by the gob encoding of its entries
+*/
+func (linkedMap *LinkedHashMap[K, V]) MarshalBinary() ([]byte, error) {
+	return serialization.Marshal(linkedMap.Entries())
+}
+
+/*
+Encode the map as a JSON array of objects with a "key" and a "value" field, in insertion order
+*/
+func (linkedMap *LinkedHashMap[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(linkedMap.Entries())
+}
+
+/*
+Associate the value to the key. If the key is already present in
+the map, its value is replaced and it keeps its position
+*/
+func (linkedMap *LinkedHashMap[K, V]) Put(key K, value V) {
+	if node, exists := linkedMap.nodes[key]; exists {
+		node.value = value
+		return
+	}
+
+	node := &Node[K, V]{key: key, value: value, previous: linkedMap.tail}
+	if linkedMap.tail == nil {
+		linkedMap.head = node
+	} else {
+		linkedMap.tail.next = node
+	}
+
+	linkedMap.tail = node
+	linkedMap.nodes[key] = node
+}
+
+/*
+Remove the entry of the key. Return true if the entry was removed, else false
+*/
+func (linkedMap *LinkedHashMap[K, V]) Remove(key K) bool {
+	node, exists := linkedMap.nodes[key]
+	if !exists {
+		return false
+	}
+
+	if node.previous == nil {
+		linkedMap.head = node.next
+	} else {
+		node.previous.next = node.next
+	}
+
+	if node.next == nil {
+		linkedMap.tail = node.previous
+	} else {
+		node.next.previous = node.previous
+	}
+
+	delete(linkedMap.nodes, key)
+	return true
+}
+
+/*
+Return the number of entries in the map
+*/
+func (linkedMap *LinkedHashMap[K, V]) Size() int {
+	return len(linkedMap.nodes)
+}
+
+/*
+Return the entries of the map as a string, e.g. {a: 1, b: 2} in insertion order
+*/
+func (linkedMap *LinkedHashMap[K, V]) String() string {
+	return format.String(linkedMap.describe())
+}
+
+/*
+Decode the binary format written by MarshalBinary into the map, replacing its content.
+The entries keep the insertion order of the encoded map
+*/
+func (linkedMap *LinkedHashMap[K, V]) UnmarshalBinary(data []byte) error {
+	var entries []maps.Entry[K, V]
+	if err := serialization.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+
+	return linkedMap.load(entries)
+}
+
+/*
+Decode a JSON array of objects with a "key" and a "value" field into the map,
+replacing its content. When a key is present several times, the last value is kept
+*/
+func (linkedMap *LinkedHashMap[K, V]) UnmarshalJSON(data []byte) error {
+	var entries []maps.Entry[K, V]
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+
+	return linkedMap.load(entries)
+}
+
+/*
+Return the values of the map in an ArrayList, in insertion order. The list uses the comparator registered
+for the type of the values, if any. Otherwise, Contains, IndexOf and Remove compare
+the values with == (or reflect.DeepEqual) and Sort returns comparator.ErrNoComparator
+*/
+func (linkedMap *LinkedHashMap[K, V]) Values() list.List[V] {
+	// The comparator is optional, so a missing default comparator is not an error
+	valueComparator, _ := comparator.Default[V]()
+	values := arraylist.New(valueComparator)
+	for node := linkedMap.head; node != nil; node = node.next {
+		values.Add(node.value)
+	}
+
+	return values
+}
+
+/*
+Write the entries of the map, as returned by String, to the writer
+*/
+func (linkedMap *LinkedHashMap[K, V]) WriteTo(writer io.Writer) (int64, error) {
+	return format.WriteTo(writer, linkedMap.describe())
+}
+
+// Private methods
+
+// Describe the map for the format package
+func (linkedMap *LinkedHashMap[K, V]) describe() format.Container[K, V] {
+	return format.Container[K, V]{
+		Name:  "LinkedHashMap",
+		Open:  "{",
+		Close: "}",
+		All:   linkedMap.All(),
+		Keyed: true,
+		Size:  linkedMap.Size(),
+	}
+}
+
+// Replace the content of the map by the entries
+func (linkedMap *LinkedHashMap[K, V]) load(entries []maps.Entry[K, V]) error {
+	*linkedMap = *New[K, V]()
+	for _, entry := range entries {
+		linkedMap.Put(entry.Key, entry.Value)
+	}
+
+	return nil
+}
@@ -0,0 +1,203 @@
+package linkedhashmap
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dterbah/gods/maps"
+	comparator "github.com/dterbah/gods/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkedHashMapBinary(t *testing.T) {
+	assert := assert.New(t)
+	original := New[string, int]()
+	original.Put("b", 2)
+	original.Put("a", 1)
+
+	data, err := original.MarshalBinary()
+	assert.Nil(err)
+
+	var decoded LinkedHashMap[string, int]
+	assert.Nil(decoded.UnmarshalBinary(data))
+	assert.Equal(original.Entries(), decoded.Entries())
+
+	// Containers can be embedded in gob encoded structs
+	var buffer bytes.Buffer
+	assert.Nil(gob.NewEncoder(&buffer).Encode(struct{ Container *LinkedHashMap[string, int] }{original}))
+
+	var checkpoint struct{ Container *LinkedHashMap[string, int] }
+	assert.Nil(gob.NewDecoder(&buffer).Decode(&checkpoint))
+	decoded = *checkpoint.Container
+	assert.Equal(original.Entries(), decoded.Entries())
+
+	assert.NotNil(decoded.UnmarshalBinary([]byte("invalid")))
+}
+
+func TestLinkedHashMapClear(t *testing.T) {
+	assert := assert.New(t)
+	m := New[string, int]()
+	m.Put("a", 1)
+	m.Clear()
+
+	assert.True(m.IsEmpty())
+	assert.False(m.ContainsKey("a"))
+}
+
+func TestLinkedHashMapContainsKey(t *testing.T) {
+	assert := assert.New(t)
+	m := New[string, int]()
+	m.Put("a", 1)
+
+	assert.True(m.ContainsKey("a"))
+	assert.False(m.ContainsKey("b"))
+}
+
+func TestLinkedHashMapGet(t *testing.T) {
+	assert := assert.New(t)
+	m := New[string, int]()
+
+	_, err := m.Get("a")
+	assert.NotNil(err)
+
+	m.Put("a", 1)
+	value, err := m.Get("a")
+	assert.Nil(err)
+	assert.Equal(1, value)
+}
+
+func TestLinkedHashMapImplementsMap(t *testing.T) {
+	var m maps.Map[string, int] = New[string, int]()
+	assert.True(t, m.IsEmpty())
+}
+
+func TestLinkedHashMapJSON(t *testing.T) {
+	assert := assert.New(t)
+	m := New[string, int]()
+	m.Put("b", 2)
+	m.Put("a", 1)
+
+	data, err := json.Marshal(m)
+	assert.Nil(err)
+	assert.Equal(`[{"key":"b","value":2},{"key":"a","value":1}]`, string(data))
+
+	var decoded LinkedHashMap[string, int]
+	assert.Nil(json.Unmarshal(data, &decoded))
+	assert.Equal(m.Entries(), decoded.Entries())
+}
+
+func TestLinkedHashMapPut(t *testing.T) {
+	assert := assert.New(t)
+	m := New[string, int]()
+
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("a", 3)
+
+	assert.Equal(2, m.Size())
+	value, _ := m.Get("a")
+	assert.Equal(3, value)
+}
+
+func TestLinkedHashMapRemove(t *testing.T) {
+	assert := assert.New(t)
+	m := New[string, int]()
+	m.Put("a", 1)
+	m.Put("b", 2)
+
+	assert.True(m.Remove("a"))
+	assert.False(m.Remove("a"))
+	assert.Equal(1, m.Size())
+	assert.False(m.ContainsKey("a"))
+}
+
+func TestLinkedHashMapOrder(t *testing.T) {
+	assert := assert.New(t)
+	m := New[string, int]()
+	m.Put("c", 1)
+	m.Put("a", 2)
+	m.Put("b", 3)
+	m.Put("c", 4)
+
+	assert.Equal([]string{"c", "a", "b"}, m.Keys().ToArray())
+	assert.Equal([]int{4, 2, 3}, m.Values().ToArray())
+
+	m.Remove("a")
+	assert.Equal([]maps.Entry[string, int]{{Key: "c", Value: 4}, {Key: "b", Value: 3}}, m.Entries())
+
+	m.Remove("b")
+	m.Remove("c")
+	m.Put("d", 5)
+	assert.Equal([]string{"d"}, m.Keys().ToArray())
+}
+
+func TestLinkedHashMapAll(t *testing.T) {
+	assert := assert.New(t)
+	m := New[string, int]()
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("c", 3)
+
+	keys := []string{}
+	for key := range m.All() {
+		if key == "c" {
+			break
+		}
+		keys = append(keys, key)
+	}
+	assert.Equal([]string{"a", "b"}, keys)
+
+	values := []int{}
+	m.ForEach(func(key string, value int) {
+		values = append(values, value)
+	})
+	assert.Equal([]int{1, 2, 3}, values)
+}
+
+func TestLinkedHashMapString(t *testing.T) {
+	assert := assert.New(t)
+	m := New[string, int]()
+	m.Put("b", 2)
+	m.Put("a", 1)
+
+	assert.Equal("{b: 2, a: 1}", m.String())
+	assert.Equal("{b: 2, a: 1}", fmt.Sprint(m))
+	assert.Equal("LinkedHashMap(size=2) {b: 2, a: 1}", fmt.Sprintf("%+v", m))
+	assert.Equal(`&linkedhashmap.LinkedHashMap[string,int]{"b": 2, "a": 1}`, fmt.Sprintf("%#v", m))
+
+	var builder strings.Builder
+	count, err := m.WriteTo(&builder)
+	assert.Nil(err)
+	assert.Equal(int64(len("{b: 2, a: 1}")), count)
+	assert.Equal("{b: 2, a: 1}", builder.String())
+}
+
+func TestLinkedHashMapValuesComparator(t *testing.T) {
+	assert := assert.New(t)
+	m := New[string, int]()
+	m.Put("a", 2)
+	m.Put("b", 1)
+
+	values := m.Values()
+	assert.True(values.Contains(2))
+	assert.Nil(values.Sort())
+	assert.Equal([]int{1, 2}, values.ToArray())
+	values.Remove(1)
+	assert.Equal([]int{2}, values.ToArray())
+
+	// No comparator is registered for the struct values
+	type point struct{ x, y int }
+	points := New[string, point]()
+	points.Put("a", point{1, 2})
+
+	pointValues := points.Values()
+	assert.True(pointValues.Contains(point{1, 2}))
+	assert.Equal(0, pointValues.IndexOf(point{1, 2}))
+	assert.ErrorIs(pointValues.Sort(), comparator.ErrNoComparator)
+	pointValues.Remove(point{1, 2})
+	assert.True(pointValues.IsEmpty())
+}
@@ -0,0 +1,87 @@
+package maps
+
+import (
+	"iter"
+
+	"github.com/dterbah/gods/list"
+	"github.com/dterbah/gods/set"
+)
+
+/*
+Struct that represents a key/value pair stored in a Map
+*/
+type Entry[K any, V any] struct {
+	Key   K `json:"key"`
+	Value V `json:"value"`
+}
+
+/*
+Interface that defines what is a Map, i.e. a container associating
+each key to a single value
+*/
+type Map[K any, V any] interface {
+	/*
+		Return an iterator over the keys and the values of the map.
+		It can be used with a range-over-func loop and supports early break
+	*/
+	All() iter.Seq2[K, V]
+
+	/*
+		Remove all the entries of the map
+	*/
+	Clear()
+
+	/*
+		Return true if the map contains an entry for the key, else false
+	*/
+	ContainsKey(key K) bool
+
+	/*
+		Return the entries of the map
+	*/
+	Entries() []Entry[K, V]
+
+	/*
+		Call a function for each entry of the map
+	*/
+	ForEach(callback func(key K, value V))
+
+	/*
+		Retrieve the value associated to the key.
+		If the key is not present in the map, the method will return an error
+	*/
+	Get(key K) (V, error)
+
+	/*
+		Return true if the map has no entries, else false
+	*/
+	IsEmpty() bool
+
+	/*
+		Return the keys of the map in a Set
+	*/
+	Keys() set.BasicSet[K]
+
+	/*
+		Associate the value to the key. If the key is already present in
+		the map, its value is replaced
+	*/
+	Put(key K, value V)
+
+	/*
+		Remove the entry of the key. Return true if the entry was removed, else false
+	*/
+	Remove(key K) bool
+
+	/*
+		Return the number of entries in the map
+	*/
+	Size() int
+
+	/*
+		Return the values of the map in a List. The list uses the comparator registered for
+		the type of the values, if any. Otherwise, Contains, IndexOf and Remove compare the
+		values with == (or reflect.DeepEqual) and Sort returns comparator.ErrNoComparator
+	*/
+	Values() list.List[V]
+}
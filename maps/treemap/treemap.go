@@ -0,0 +1,282 @@
+package treemap
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/dterbah/gods/internal/format"
+	"github.com/dterbah/gods/internal/serialization"
+	"github.com/dterbah/gods/list"
+	"github.com/dterbah/gods/list/arraylist"
+	"github.com/dterbah/gods/maps"
+	"github.com/dterbah/gods/set"
+	"github.com/dterbah/gods/set/treeset"
+	"github.com/dterbah/gods/tree/avl"
+	comparator "github.com/dterbah/gods/utils"
+)
+
+/*
+Struct that defines what is a TreeMap. The entries are stored in a self-balancing
+tree ordered by the keys, so the map is iterated in ascending key order.
+Put, Get and Remove run in O(log n)
+*/
+type TreeMap[K any, V any] struct {
+	tree          *avl.AVLTree[*maps.Entry[K, V]]
+	keyComparator comparator.Comparator[K]
+	zeroValue     V
+}
+
+/*
+Create a new TreeMap ordered by the key comparator
+*/
+func New[K any, V any](keyComparator comparator.Comparator[K]) *TreeMap[K, V] {
+	var zeroValue V
+	entryComparator := func(a, b *maps.Entry[K, V]) int {
+		return keyComparator(a.Key, b.Key)
+	}
+
+	return &TreeMap[K, V]{
+		tree:          avl.New(entryComparator),
+		keyComparator: keyComparator,
+		zeroValue:     zeroValue,
+	}
+}
+
+/*
+Return an iterator over the keys and the values of the map, in ascending key order.
+The iteration stops as soon as the loop body breaks
+*/
+func (treeMap *TreeMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for entry := range treeMap.tree.Values() {
+			if !yield(entry.Key, entry.Value) {
+				return
+			}
+		}
+	}
+}
+
+/*
+Remove all the entries of the map
+*/
+func (treeMap *TreeMap[K, V]) Clear() {
+	treeMap.tree.Clear()
+}
+
+/*
+Return true if the map contains an entry for the key, else false
+*/
+func (treeMap *TreeMap[K, V]) ContainsKey(key K) bool {
+	return treeMap.tree.Has(treeMap.probe(key))
+}
+
+/*
+Return the entries of the map in ascending key order
+*/
+func (treeMap *TreeMap[K, V]) Entries() []maps.Entry[K, V] {
+	entries := make([]maps.Entry[K, V], 0, treeMap.Size())
+	for entry := range treeMap.tree.Values() {
+		entries = append(entries, *entry)
+	}
+
+	return entries
+}
+
+/*
+Call a function for each entry of the map, in ascending key order
+*/
+func (treeMap *TreeMap[K, V]) ForEach(callback func(key K, value V)) {
+	for entry := range treeMap.tree.Values() {
+		callback(entry.Key, entry.Value)
+	}
+}
+
+/*
+Format the map for the fmt package: %v writes its entries, %+v also writes
+its size, and %#v writes a Go-syntax representation.
+The other verbs are applied to each key and value
+*/
+func (treeMap *TreeMap[K, V]) Format(state fmt.State, verb rune) {
+	format.Format(state, verb, treeMap, treeMap.describe())
+}
+
+/*
+Retrieve the value associated to the key.
+If the key is not present in the map, the method will return an error
+*/
+func (treeMap *TreeMap[K, V]) Get(key K) (V, error) {
+	entry, err := treeMap.tree.Get(treeMap.probe(key))
+	if err != nil {
+		return treeMap.zeroValue, err
+	}
+
+	return entry.Value, nil
+}
+
+/*
+Decode the map from the gob encoding, see UnmarshalBinary
+*/
+func (treeMap *TreeMap[K, V]) GobDecode(data []byte) error {
+	return treeMap.UnmarshalBinary(data)
+}
+
+/*
+Encode the map for gob, see MarshalBinary
+*/
+func (treeMap *TreeMap[K, V]) GobEncode() ([]byte, error) {
+	return treeMap.MarshalBinary()
+}
+
+/*
+Return true if the map has no entries, else false
+*/
+func (treeMap *TreeMap[K, V]) IsEmpty() bool {
+	return treeMap.tree.IsEmpty()
+}
+
+/*
+Return the keys of the map in a TreeSet
+*/
+func (treeMap *TreeMap[K, V]) Keys() set.BasicSet[K] {
+	keys := treeset.New(treeMap.keyComparator)
+	for entry := range treeMap.tree.Values() {
+		keys.Add(entry.Key)
+	}
+
+	return keys
+}
+
+/*
+Encode the map in a binary format made of a versioned header
+followed by the gob encoding of its entries
+*/
+func (treeMap *TreeMap[K, V]) MarshalBinary() ([]byte, error) {
+	return serialization.Marshal(treeMap.Entries())
+}
+
+/*
+Encode the map as a JSON array of objects with a "key" and a "value" field, in ascending key order
+*/
+func (treeMap *TreeMap[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(treeMap.Entries())
+}
+
+/*
+Associate the value to the key. If the key is already present in
+the map, its value is replaced
+*/
+func (treeMap *TreeMap[K, V]) Put(key K, value V) {
+	if entry, err := treeMap.tree.Get(treeMap.probe(key)); err == nil {
+		entry.Value = value
+		return
+	}
+
+	treeMap.tree.Add(&maps.Entry[K, V]{Key: key, Value: value})
+}
+
+/*
+Remove the entry of the key. Return true if the entry was removed, else false
+*/
+func (treeMap *TreeMap[K, V]) Remove(key K) bool {
+	return treeMap.tree.Remove(treeMap.probe(key))
+}
+
+/*
+Return the number of entries in the map
+*/
+func (treeMap *TreeMap[K, V]) Size() int {
+	return treeMap.tree.Size()
+}
+
+/*
+Return the entries of the map as a string, e.g. {a: 1, b: 2} in ascending key order
+*/
+func (treeMap *TreeMap[K, V]) String() string {
+	return format.String(treeMap.describe())
+}
+
+/*
+Decode the binary format written by MarshalBinary into the map, replacing its content.
+The key comparator of the map is kept, or the one registered for the type of the keys is used
+*/
+func (treeMap *TreeMap[K, V]) UnmarshalBinary(data []byte) error {
+	var entries []maps.Entry[K, V]
+	if err := serialization.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+
+	return treeMap.load(entries)
+}
+
+/*
+Decode a JSON array of objects with a "key" and a "value" field into the map,
+replacing its content. The key comparator of the map is kept. If the map has no key
+comparator, the one registered for the type of the keys is used
+*/
+func (treeMap *TreeMap[K, V]) UnmarshalJSON(data []byte) error {
+	var entries []maps.Entry[K, V]
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+
+	return treeMap.load(entries)
+}
+
+/*
+Return the values of the map in an ArrayList, in ascending key order. The list uses the comparator registered
+for the type of the values, if any. Otherwise, Contains, IndexOf and Remove compare
+the values with == (or reflect.DeepEqual) and Sort returns comparator.ErrNoComparator
+*/
+func (treeMap *TreeMap[K, V]) Values() list.List[V] {
+	// The comparator is optional, so a missing default comparator is not an error
+	valueComparator, _ := comparator.Default[V]()
+	values := arraylist.New(valueComparator)
+	for entry := range treeMap.tree.Values() {
+		values.Add(entry.Value)
+	}
+
+	return values
+}
+
+/*
+Write the entries of the map, as returned by String, to the writer
+*/
+func (treeMap *TreeMap[K, V]) WriteTo(writer io.Writer) (int64, error) {
+	return format.WriteTo(writer, treeMap.describe())
+}
+
+// Private methods
+
+// Describe the map for the format package
+func (treeMap *TreeMap[K, V]) describe() format.Container[K, V] {
+	return format.Container[K, V]{
+		Name:  "TreeMap",
+		Open:  "{",
+		Close: "}",
+		All:   treeMap.All(),
+		Keyed: true,
+		Size:  treeMap.Size(),
+	}
+}
+
+// Replace the content of the map by the entries, resolving the comparator if needed
+func (treeMap *TreeMap[K, V]) load(entries []maps.Entry[K, V]) error {
+	keyComparator, err := comparator.Resolve(treeMap.keyComparator)
+	if err != nil {
+		return err
+	}
+
+	*treeMap = *New[K, V](keyComparator)
+	for _, entry := range entries {
+		treeMap.Put(entry.Key, entry.Value)
+	}
+
+	return nil
+}
+
+// Create an entry only used to search a key in the tree
+func (treeMap *TreeMap[K, V]) probe(key K) *maps.Entry[K, V] {
+	return &maps.Entry[K, V]{Key: key}
+}
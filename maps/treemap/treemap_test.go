@@ -0,0 +1,198 @@
+package treemap
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dterbah/gods/maps"
+	comparator "github.com/dterbah/gods/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTreeMapBinary(t *testing.T) {
+	assert := assert.New(t)
+	original := New[int, string](comparator.IntComparator)
+	original.Put(2, "b")
+	original.Put(1, "a")
+
+	data, err := original.MarshalBinary()
+	assert.Nil(err)
+
+	var decoded TreeMap[int, string]
+	assert.Nil(decoded.UnmarshalBinary(data))
+	assert.Equal(original.Entries(), decoded.Entries())
+
+	// Containers can be embedded in gob encoded structs
+	var buffer bytes.Buffer
+	assert.Nil(gob.NewEncoder(&buffer).Encode(struct{ Container *TreeMap[int, string] }{original}))
+
+	var checkpoint struct{ Container *TreeMap[int, string] }
+	assert.Nil(gob.NewDecoder(&buffer).Decode(&checkpoint))
+	decoded = *checkpoint.Container
+	assert.Equal(original.Entries(), decoded.Entries())
+
+	assert.NotNil(decoded.UnmarshalBinary([]byte("invalid")))
+}
+
+func TestTreeMapClear(t *testing.T) {
+	assert := assert.New(t)
+	m := New[string, int](comparator.StringComparator)
+	m.Put("a", 1)
+	m.Clear()
+
+	assert.True(m.IsEmpty())
+	assert.False(m.ContainsKey("a"))
+}
+
+func TestTreeMapContainsKey(t *testing.T) {
+	assert := assert.New(t)
+	m := New[string, int](comparator.StringComparator)
+	m.Put("a", 1)
+
+	assert.True(m.ContainsKey("a"))
+	assert.False(m.ContainsKey("b"))
+}
+
+func TestTreeMapGet(t *testing.T) {
+	assert := assert.New(t)
+	m := New[string, int](comparator.StringComparator)
+
+	_, err := m.Get("a")
+	assert.NotNil(err)
+
+	m.Put("a", 1)
+	value, err := m.Get("a")
+	assert.Nil(err)
+	assert.Equal(1, value)
+}
+
+func TestTreeMapImplementsMap(t *testing.T) {
+	var m maps.Map[string, int] = New[string, int](comparator.StringComparator)
+	assert.True(t, m.IsEmpty())
+}
+
+func TestTreeMapJSON(t *testing.T) {
+	assert := assert.New(t)
+	m := New[int, string](comparator.IntComparator)
+	m.Put(2, "b")
+	m.Put(1, "a")
+
+	data, err := json.Marshal(m)
+	assert.Nil(err)
+	assert.Equal(`[{"key":1,"value":"a"},{"key":2,"value":"b"}]`, string(data))
+
+	var decoded TreeMap[int, string]
+	assert.Nil(json.Unmarshal(data, &decoded))
+	assert.Equal(m.Entries(), decoded.Entries())
+
+	var unknown TreeMap[[]int, string]
+	assert.NotNil(json.Unmarshal([]byte(`[{"key":[1],"value":"a"}]`), &unknown))
+}
+
+func TestTreeMapPut(t *testing.T) {
+	assert := assert.New(t)
+	m := New[string, int](comparator.StringComparator)
+
+	m.Put("a", 1)
+	m.Put("b", 2)
+	m.Put("a", 3)
+
+	assert.Equal(2, m.Size())
+	value, _ := m.Get("a")
+	assert.Equal(3, value)
+}
+
+func TestTreeMapRemove(t *testing.T) {
+	assert := assert.New(t)
+	m := New[string, int](comparator.StringComparator)
+	m.Put("a", 1)
+	m.Put("b", 2)
+
+	assert.True(m.Remove("a"))
+	assert.False(m.Remove("a"))
+	assert.Equal(1, m.Size())
+	assert.False(m.ContainsKey("a"))
+}
+
+func TestTreeMapOrder(t *testing.T) {
+	assert := assert.New(t)
+	m := New[string, int](comparator.StringComparator)
+	m.Put("c", 1)
+	m.Put("a", 2)
+	m.Put("b", 3)
+
+	assert.Equal([]string{"a", "b", "c"}, m.Keys().ToArray())
+	assert.Equal([]int{2, 3, 1}, m.Values().ToArray())
+	assert.Equal([]maps.Entry[string, int]{{Key: "a", Value: 2}, {Key: "b", Value: 3}, {Key: "c", Value: 1}}, m.Entries())
+}
+
+func TestTreeMapAll(t *testing.T) {
+	assert := assert.New(t)
+	m := New[string, int](comparator.StringComparator)
+	m.Put("b", 2)
+	m.Put("a", 1)
+	m.Put("c", 3)
+
+	keys := []string{}
+	for key := range m.All() {
+		if key == "c" {
+			break
+		}
+		keys = append(keys, key)
+	}
+	assert.Equal([]string{"a", "b"}, keys)
+
+	values := []int{}
+	m.ForEach(func(key string, value int) {
+		values = append(values, value)
+	})
+	assert.Equal([]int{1, 2, 3}, values)
+}
+
+func TestTreeMapString(t *testing.T) {
+	assert := assert.New(t)
+	m := New[string, int](comparator.StringComparator)
+	m.Put("b", 2)
+	m.Put("a", 1)
+
+	assert.Equal("{a: 1, b: 2}", m.String())
+	assert.Equal("{a: 1, b: 2}", fmt.Sprint(m))
+	assert.Equal("TreeMap(size=2) {a: 1, b: 2}", fmt.Sprintf("%+v", m))
+	assert.Equal(`&treemap.TreeMap[string,int]{"a": 1, "b": 2}`, fmt.Sprintf("%#v", m))
+
+	var builder strings.Builder
+	count, err := m.WriteTo(&builder)
+	assert.Nil(err)
+	assert.Equal(int64(len("{a: 1, b: 2}")), count)
+	assert.Equal("{a: 1, b: 2}", builder.String())
+}
+
+func TestTreeMapValuesComparator(t *testing.T) {
+	assert := assert.New(t)
+	m := New[string, int](comparator.StringComparator)
+	m.Put("a", 2)
+	m.Put("b", 1)
+
+	values := m.Values()
+	assert.True(values.Contains(2))
+	assert.Nil(values.Sort())
+	assert.Equal([]int{1, 2}, values.ToArray())
+	values.Remove(1)
+	assert.Equal([]int{2}, values.ToArray())
+
+	// No comparator is registered for the struct values
+	type point struct{ x, y int }
+	points := New[string, point](comparator.StringComparator)
+	points.Put("a", point{1, 2})
+
+	pointValues := points.Values()
+	assert.True(pointValues.Contains(point{1, 2}))
+	assert.Equal(0, pointValues.IndexOf(point{1, 2}))
+	assert.ErrorIs(pointValues.Sort(), comparator.ErrNoComparator)
+	pointValues.Remove(point{1, 2})
+	assert.True(pointValues.IsEmpty())
+}
@@ -0,0 +1,360 @@
+package blocking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/dterbah/gods/collection"
+	"github.com/dterbah/gods/deque"
+	"github.com/dterbah/gods/internal/format"
+	comparator "github.com/dterbah/gods/utils"
+)
+
+/*
+Error returned when an element is put in a closed queue,
+or taken from a closed queue that has no elements left
+*/
+var ErrClosed = errors.New("blocking queue closed")
+
+/*
+Error returned by Offer and Poll when the timeout expires
+*/
+var ErrTimeout = errors.New("blocking queue timeout")
+
+/*
+Struct that defines what a BlockingQueue is. It is a bounded FIFO queue
+safe for concurrent use: Put blocks while the queue is full and Take blocks
+while it is empty. It is meant to be shared between producers and consumers
+*/
+type BlockingQueue[T any] struct {
+	mutex       sync.Mutex
+	elements    *deque.Deque[T]
+	capacity    int
+	closed      bool
+	changed     chan struct{}
+	zeroElement T
+}
+
+/*
+Create a new BlockingQueue able to store at most capacity elements.
+If the capacity is not strictly positive, this function returns nil
+*/
+func New[T any](capacity int, comparator comparator.Comparator[T]) *BlockingQueue[T] {
+	if capacity <= 0 {
+		return nil
+	}
+
+	var zero T
+
+	return &BlockingQueue[T]{
+		elements:    deque.New(comparator),
+		capacity:    capacity,
+		changed:     make(chan struct{}),
+		zeroElement: zero,
+	}
+}
+
+/*
+Return the maximum number of elements the queue can store
+*/
+func (queue *BlockingQueue[T]) Cap() int {
+	return queue.capacity
+}
+
+/*
+Remove all the elements of the queue. The blocked producers are woken up
+*/
+func (queue *BlockingQueue[T]) Clear() {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	queue.elements.Clear()
+	queue.signal()
+}
+
+/*
+Close the queue. After a close, Put and Offer return ErrClosed, while Take and Poll
+keep returning the remaining elements, then return ErrClosed once the queue is empty.
+All the blocked producers and consumers are woken up. Closing twice has no effect
+*/
+func (queue *BlockingQueue[T]) Close() {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	if queue.closed {
+		return
+	}
+
+	queue.closed = true
+	queue.signal()
+}
+
+/*
+Return true if the element is present inside the queue, else false
+*/
+func (queue *BlockingQueue[T]) Contains(element T) bool {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	return queue.elements.Contains(element)
+}
+
+/*
+Move at most max elements of the queue into the collection, without blocking.
+If max is negative, all the elements are moved. Return the number of moved elements
+*/
+func (queue *BlockingQueue[T]) DrainTo(target collection.Collection[T], max int) int {
+	queue.mutex.Lock()
+
+	count := queue.elements.Size()
+	if max >= 0 && max < count {
+		count = max
+	}
+
+	elements := make([]T, 0, count)
+	for len(elements) < count {
+		element, _ := queue.elements.PopFront()
+		elements = append(elements, element)
+	}
+
+	if count > 0 {
+		queue.signal()
+	}
+	queue.mutex.Unlock()
+
+	// The collection is filled outside the lock, in case it calls back the queue
+	target.Add(elements...)
+
+	return count
+}
+
+/*
+Format the queue for the fmt package: %v writes its elements, %+v also writes
+its size and its capacity, and %#v writes a Go-syntax representation.
+The other verbs are applied to each element
+*/
+func (queue *BlockingQueue[T]) Format(state fmt.State, verb rune) {
+	format.Format(state, verb, queue, queue.describe())
+}
+
+/*
+Return true if no element is present in the queue, else false
+*/
+func (queue *BlockingQueue[T]) IsEmpty() bool {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	return queue.elements.IsEmpty()
+}
+
+/*
+Return true if the queue has been closed, else false
+*/
+func (queue *BlockingQueue[T]) IsClosed() bool {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	return queue.closed
+}
+
+/*
+Insert the element at the end of the queue, waiting up to the timeout for space
+to become available. If the timeout is not strictly positive, the method does not wait.
+It returns ErrTimeout if the queue stayed full and ErrClosed if the queue is closed
+*/
+func (queue *BlockingQueue[T]) Offer(element T, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	err := queue.Put(ctx, element)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrTimeout
+	}
+
+	return err
+}
+
+/*
+Return the first element of the queue without removing it.
+If the queue is empty, it returns an error
+*/
+func (queue *BlockingQueue[T]) Peek() (T, error) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	if queue.elements.IsEmpty() {
+		return queue.zeroElement, errors.New("queue empty")
+	}
+
+	return queue.elements.PeekFront()
+}
+
+/*
+Remove and return the first element of the queue, waiting up to the timeout for an
+element to become available. If the timeout is not strictly positive, the method does
+not wait. It returns ErrTimeout if the queue stayed empty and ErrClosed if the queue
+is closed and empty
+*/
+func (queue *BlockingQueue[T]) Poll(timeout time.Duration) (T, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	element, err := queue.Take(ctx)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return element, ErrTimeout
+	}
+
+	return element, err
+}
+
+func (queue *BlockingQueue[T]) Print() {
+	fmt.Println(queue.String())
+}
+
+/*
+Insert the element at the end of the queue, blocking while the queue is full.
+It returns ErrClosed if the queue is closed, or the error of the context
+if it is done before space becomes available
+*/
+func (queue *BlockingQueue[T]) Put(ctx context.Context, element T) error {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	err := queue.wait(ctx, func() bool {
+		return queue.closed || queue.elements.Size() < queue.capacity
+	})
+	if err != nil {
+		return err
+	}
+
+	if queue.closed {
+		return ErrClosed
+	}
+
+	queue.elements.PushBack(element)
+	queue.signal()
+	return nil
+}
+
+/*
+Return the number of elements that can be put in the queue without blocking
+*/
+func (queue *BlockingQueue[T]) RemainingCapacity() int {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	return queue.capacity - queue.elements.Size()
+}
+
+/*
+Return the current size of the queue
+*/
+func (queue *BlockingQueue[T]) Size() int {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	return queue.elements.Size()
+}
+
+/*
+Return the elements of the queue as a string, e.g. [1, 2, 3] from the first to the last
+*/
+func (queue *BlockingQueue[T]) String() string {
+	return format.String(queue.describe())
+}
+
+/*
+Remove and return the first element of the queue, blocking while the queue is empty.
+It returns ErrClosed if the queue is closed and empty, or the error of the context
+if it is done before an element becomes available
+*/
+func (queue *BlockingQueue[T]) Take(ctx context.Context) (T, error) {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	err := queue.wait(ctx, func() bool {
+		return queue.closed || !queue.elements.IsEmpty()
+	})
+	if err != nil {
+		return queue.zeroElement, err
+	}
+
+	if queue.elements.IsEmpty() {
+		return queue.zeroElement, ErrClosed
+	}
+
+	element, _ := queue.elements.PopFront()
+	queue.signal()
+	return element, nil
+}
+
+/*
+Return a snapshot of the elements of the queue, from the first to the last
+*/
+func (queue *BlockingQueue[T]) ToArray() []T {
+	queue.mutex.Lock()
+	defer queue.mutex.Unlock()
+
+	return queue.elements.ToArray()
+}
+
+/*
+Return an iterator over a snapshot of the elements of the queue
+*/
+func (queue *BlockingQueue[T]) Values() iter.Seq[T] {
+	return slices.Values(queue.ToArray())
+}
+
+/*
+Write the elements of the queue, as returned by String, to the writer
+*/
+func (queue *BlockingQueue[T]) WriteTo(writer io.Writer) (int64, error) {
+	return format.WriteTo(writer, queue.describe())
+}
+
+// Private methods
+
+// Describe the queue for the format package
+func (queue *BlockingQueue[T]) describe() format.Container[int, T] {
+	elements := queue.ToArray()
+
+	return format.Container[int, T]{
+		Name:     "BlockingQueue",
+		Open:     "[",
+		Close:    "]",
+		All:      slices.All(elements),
+		Size:     len(elements),
+		Capacity: queue.capacity,
+	}
+}
+
+// Wake up all the goroutines waiting for a change of the queue. Must be called with the lock held
+func (queue *BlockingQueue[T]) signal() {
+	close(queue.changed)
+	queue.changed = make(chan struct{})
+}
+
+// Wait until the condition is true or the context is done. Must be called with the lock held,
+// which is released while waiting and held again when the method returns
+func (queue *BlockingQueue[T]) wait(ctx context.Context, condition func() bool) error {
+	for !condition() {
+		changed := queue.changed
+
+		queue.mutex.Unlock()
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			queue.mutex.Lock()
+			return ctx.Err()
+		}
+		queue.mutex.Lock()
+	}
+
+	return nil
+}
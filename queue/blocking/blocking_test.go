@@ -0,0 +1,311 @@
+package blocking
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dterbah/gods/list/arraylist"
+	comparator "github.com/dterbah/gods/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockingQueueNew(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Nil(New(0, comparator.IntComparator))
+	assert.Nil(New(-1, comparator.IntComparator))
+
+	queue := New(2, comparator.IntComparator)
+	assert.Equal(2, queue.Cap())
+	assert.True(queue.IsEmpty())
+	assert.Equal(2, queue.RemainingCapacity())
+}
+
+func TestBlockingQueuePutTake(t *testing.T) {
+	assert := assert.New(t)
+	queue := New(3, comparator.IntComparator)
+	ctx := context.Background()
+
+	assert.Nil(queue.Put(ctx, 1))
+	assert.Nil(queue.Put(ctx, 2))
+
+	assert.Equal(2, queue.Size())
+	assert.True(queue.Contains(2))
+	assert.False(queue.Contains(3))
+
+	element, err := queue.Peek()
+	assert.Nil(err)
+	assert.Equal(1, element)
+
+	element, err = queue.Take(ctx)
+	assert.Nil(err)
+	assert.Equal(1, element)
+
+	element, err = queue.Take(ctx)
+	assert.Nil(err)
+	assert.Equal(2, element)
+
+	_, err = queue.Peek()
+	assert.NotNil(err)
+}
+
+func TestBlockingQueuePutBlocksWhenFull(t *testing.T) {
+	assert := assert.New(t)
+	queue := New(1, comparator.IntComparator)
+	ctx := context.Background()
+	queue.Put(ctx, 1)
+
+	done := make(chan error)
+	go func() {
+		done <- queue.Put(ctx, 2)
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("Put should block while the queue is full")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	element, _ := queue.Take(ctx)
+	assert.Equal(1, element)
+	assert.Nil(<-done)
+
+	element, _ = queue.Take(ctx)
+	assert.Equal(2, element)
+}
+
+func TestBlockingQueueString(t *testing.T) {
+	assert := assert.New(t)
+	queue := New(4, comparator.IntComparator)
+	queue.Offer(1, 0)
+	queue.Offer(2, 0)
+
+	assert.Equal("[1, 2]", queue.String())
+	assert.Equal("[1, 2]", fmt.Sprint(queue))
+	assert.Equal("BlockingQueue(size=2, capacity=4) [1, 2]", fmt.Sprintf("%+v", queue))
+	assert.Equal(`&blocking.BlockingQueue[int]{1, 2}`, fmt.Sprintf("%#v", queue))
+
+	var builder strings.Builder
+	count, err := queue.WriteTo(&builder)
+	assert.Nil(err)
+	assert.Equal(int64(len("[1, 2]")), count)
+	assert.Equal("[1, 2]", builder.String())
+}
+
+func TestBlockingQueueTakeBlocksWhenEmpty(t *testing.T) {
+	assert := assert.New(t)
+	queue := New(1, comparator.IntComparator)
+
+	done := make(chan int)
+	go func() {
+		element, _ := queue.Take(context.Background())
+		done <- element
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	queue.Put(context.Background(), 42)
+
+	assert.Equal(42, <-done)
+}
+
+func TestBlockingQueueContextCancel(t *testing.T) {
+	assert := assert.New(t)
+	queue := New(1, comparator.IntComparator)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	_, err := queue.Take(ctx)
+	assert.ErrorIs(err, context.Canceled)
+
+	queue.Put(context.Background(), 1)
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(queue.Put(ctx, 2), context.DeadlineExceeded)
+	assert.Equal(1, queue.Size())
+}
+
+func TestBlockingQueueOfferPoll(t *testing.T) {
+	assert := assert.New(t)
+	queue := New(1, comparator.IntComparator)
+
+	assert.Nil(queue.Offer(1, 0))
+	assert.ErrorIs(queue.Offer(2, 0), ErrTimeout)
+	assert.ErrorIs(queue.Offer(2, 10*time.Millisecond), ErrTimeout)
+
+	element, err := queue.Poll(0)
+	assert.Nil(err)
+	assert.Equal(1, element)
+
+	_, err = queue.Poll(10 * time.Millisecond)
+	assert.ErrorIs(err, ErrTimeout)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		queue.Offer(3, 0)
+	}()
+
+	element, err = queue.Poll(time.Second)
+	assert.Nil(err)
+	assert.Equal(3, element)
+}
+
+func TestBlockingQueueClose(t *testing.T) {
+	assert := assert.New(t)
+	queue := New(2, comparator.IntComparator)
+	ctx := context.Background()
+	queue.Put(ctx, 1)
+	queue.Put(ctx, 2)
+
+	queue.Close()
+	queue.Close()
+
+	assert.True(queue.IsClosed())
+	assert.ErrorIs(queue.Put(ctx, 3), ErrClosed)
+	assert.ErrorIs(queue.Offer(3, 0), ErrClosed)
+
+	element, err := queue.Take(ctx)
+	assert.Nil(err)
+	assert.Equal(1, element)
+
+	element, err = queue.Poll(0)
+	assert.Nil(err)
+	assert.Equal(2, element)
+
+	_, err = queue.Take(ctx)
+	assert.ErrorIs(err, ErrClosed)
+}
+
+func TestBlockingQueueCloseWakesWaiters(t *testing.T) {
+	assert := assert.New(t)
+	queue := New(1, comparator.IntComparator)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 4)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := queue.Take(context.Background())
+			errs <- err
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	queue.Close()
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.ErrorIs(err, ErrClosed)
+	}
+}
+
+func TestBlockingQueueDrainTo(t *testing.T) {
+	assert := assert.New(t)
+	queue := New(5, comparator.IntComparator)
+	for i := 1; i <= 5; i++ {
+		queue.Offer(i, 0)
+	}
+
+	target := arraylist.New(comparator.IntComparator)
+
+	assert.Equal(2, queue.DrainTo(target, 2))
+	assert.Equal([]int{1, 2}, target.ToArray())
+	assert.Equal([]int{3, 4, 5}, queue.ToArray())
+
+	assert.Equal(3, queue.DrainTo(target, -1))
+	assert.Equal([]int{1, 2, 3, 4, 5}, target.ToArray())
+	assert.True(queue.IsEmpty())
+
+	assert.Equal(0, queue.DrainTo(target, 10))
+}
+
+func TestBlockingQueueClear(t *testing.T) {
+	assert := assert.New(t)
+	queue := New(1, comparator.IntComparator)
+	queue.Offer(1, 0)
+
+	done := make(chan error)
+	go func() {
+		done <- queue.Put(context.Background(), 2)
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	queue.Clear()
+
+	assert.Nil(<-done)
+	assert.Equal([]int{2}, queue.ToArray())
+}
+
+func TestBlockingQueueProducerConsumer(t *testing.T) {
+	assert := assert.New(t)
+	queue := New(4, comparator.IntComparator)
+	ctx := context.Background()
+
+	const producers = 4
+	const elements = 100
+
+	var producersGroup sync.WaitGroup
+	for producer := 0; producer < producers; producer++ {
+		producersGroup.Add(1)
+		go func() {
+			defer producersGroup.Done()
+			for i := 1; i <= elements; i++ {
+				queue.Put(ctx, i)
+			}
+		}()
+	}
+
+	var consumersGroup sync.WaitGroup
+	sums := make([]int, 3)
+	for consumer := range sums {
+		consumersGroup.Add(1)
+		go func() {
+			defer consumersGroup.Done()
+			for {
+				element, err := queue.Take(ctx)
+				if err != nil {
+					return
+				}
+				sums[consumer] += element
+			}
+		}()
+	}
+
+	producersGroup.Wait()
+	queue.Close()
+	consumersGroup.Wait()
+
+	total := 0
+	for _, sum := range sums {
+		total += sum
+	}
+
+	assert.Equal(producers*elements*(elements+1)/2, total)
+	assert.True(queue.IsEmpty())
+}
+
+func TestBlockingQueueValues(t *testing.T) {
+	assert := assert.New(t)
+	queue := New(3, comparator.IntComparator)
+	queue.Offer(1, 0)
+	queue.Offer(2, 0)
+
+	var elements []int
+	for element := range queue.Values() {
+		elements = append(elements, element)
+		queue.Offer(element*10, 0)
+	}
+
+	assert.Equal([]int{1, 2}, elements)
+	assert.Equal([]int{1, 2, 10}, queue.ToArray())
+}
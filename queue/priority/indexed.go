@@ -0,0 +1,300 @@
+package priority
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/dterbah/gods/internal/format"
+	comparator "github.com/dterbah/gods/utils"
+)
+
+/*
+Identifier returned when an element is pushed in an IndexedPriorityQueue.
+It is used to update or remove the element later. A handle is never reused,
+even after the removal of its element
+*/
+type Handle uint64
+
+type item[T any] struct {
+	value  T
+	handle Handle
+	index  int
+}
+
+/*
+Struct that represents what is an IndexedPriorityQueue.
+Like a PriorityQueue, the elements are stored in a binary heap ordered by the comparator,
+but each element is identified by a Handle, so its priority can be changed or it can be
+removed after its insertion. Push, Pop, Update and Remove run in O(log n),
+Peek and Contains in O(1).
+*/
+type IndexedPriorityQueue[T any] struct {
+	heap        []*item[T]
+	items       map[Handle]*item[T]
+	nextHandle  Handle
+	comparator  comparator.Comparator[T]
+	max         bool
+	zeroElement T
+}
+
+/*
+Create a new IndexedPriorityQueue where the lowest element has the highest priority
+*/
+func NewIndexed[T any](comparator comparator.Comparator[T]) *IndexedPriorityQueue[T] {
+	return newIndexedQueue(comparator, false)
+}
+
+/*
+Create a new IndexedPriorityQueue where the greatest element has the highest priority
+*/
+func NewIndexedMax[T any](comparator comparator.Comparator[T]) *IndexedPriorityQueue[T] {
+	return newIndexedQueue(comparator, true)
+}
+
+/*
+Remove all the elements of the queue. The handles already returned become invalid
+*/
+func (queue *IndexedPriorityQueue[T]) Clear() {
+	queue.heap = []*item[T]{}
+	queue.items = make(map[Handle]*item[T])
+}
+
+/*
+Return true if the element identified by the handle is still in the queue, else false
+*/
+func (queue *IndexedPriorityQueue[T]) Contains(handle Handle) bool {
+	_, exists := queue.items[handle]
+	return exists
+}
+
+/*
+Format the queue for the fmt package: %v writes its elements, %+v also writes
+its size, and %#v writes a Go-syntax representation.
+The other verbs are applied to each element
+*/
+func (queue *IndexedPriorityQueue[T]) Format(state fmt.State, verb rune) {
+	format.Format(state, verb, queue, queue.describe())
+}
+
+/*
+Retrieve the element identified by the handle.
+If the handle is not in the queue, it returns an error
+*/
+func (queue *IndexedPriorityQueue[T]) Get(handle Handle) (T, error) {
+	item, exists := queue.items[handle]
+	if !exists {
+		return queue.zeroElement, errors.New("unknown handle")
+	}
+
+	return item.value, nil
+}
+
+/*
+Return true if no element is present in the queue, else false
+*/
+func (queue *IndexedPriorityQueue[T]) IsEmpty() bool {
+	return len(queue.heap) == 0
+}
+
+/*
+Return the element with the highest priority and its handle without removing it.
+If the queue is empty, it returns an error
+*/
+func (queue *IndexedPriorityQueue[T]) Peek() (T, Handle, error) {
+	if queue.IsEmpty() {
+		return queue.zeroElement, 0, errors.New("queue empty")
+	}
+
+	return queue.heap[0].value, queue.heap[0].handle, nil
+}
+
+/*
+Remove and return the element with the highest priority and its handle.
+If the queue is empty, it returns an error
+*/
+func (queue *IndexedPriorityQueue[T]) Pop() (T, Handle, error) {
+	if queue.IsEmpty() {
+		return queue.zeroElement, 0, errors.New("queue empty")
+	}
+
+	top := queue.heap[0]
+	queue.removeAt(0)
+
+	return top.value, top.handle, nil
+}
+
+func (queue *IndexedPriorityQueue[T]) Print() {
+	fmt.Println(queue.String())
+}
+
+/*
+Push an element inside the queue and return the handle identifying it
+*/
+func (queue *IndexedPriorityQueue[T]) Push(element T) Handle {
+	queue.nextHandle++
+	item := &item[T]{value: element, handle: queue.nextHandle, index: len(queue.heap)}
+
+	queue.heap = append(queue.heap, item)
+	queue.items[item.handle] = item
+	queue.siftUp(item.index)
+
+	return item.handle
+}
+
+/*
+Remove the element identified by the handle and return it.
+If the handle is not in the queue, it returns an error
+*/
+func (queue *IndexedPriorityQueue[T]) Remove(handle Handle) (T, error) {
+	item, exists := queue.items[handle]
+	if !exists {
+		return queue.zeroElement, errors.New("unknown handle")
+	}
+
+	queue.removeAt(item.index)
+
+	return item.value, nil
+}
+
+/*
+Return the current size of the queue
+*/
+func (queue *IndexedPriorityQueue[T]) Size() int {
+	return len(queue.heap)
+}
+
+/*
+Return the elements of the queue as a string, e.g. [1, 2, 3] in heap order
+*/
+func (queue *IndexedPriorityQueue[T]) String() string {
+	return format.String(queue.describe())
+}
+
+/*
+Replace the element identified by the handle with a new one, and move it
+according to its new priority. If the handle is not in the queue, it returns an error
+*/
+func (queue *IndexedPriorityQueue[T]) Update(handle Handle, element T) error {
+	item, exists := queue.items[handle]
+	if !exists {
+		return errors.New("unknown handle")
+	}
+
+	item.value = element
+	queue.fix(item.index)
+
+	return nil
+}
+
+/*
+Write the elements of the queue, as returned by String, to the writer
+*/
+func (queue *IndexedPriorityQueue[T]) WriteTo(writer io.Writer) (int64, error) {
+	return format.WriteTo(writer, queue.describe())
+}
+
+// Private methods //
+
+func newIndexedQueue[T any](comparator comparator.Comparator[T], max bool) *IndexedPriorityQueue[T] {
+	var zero T
+	return &IndexedPriorityQueue[T]{
+		heap:        []*item[T]{},
+		items:       make(map[Handle]*item[T]),
+		comparator:  comparator,
+		max:         max,
+		zeroElement: zero,
+	}
+}
+
+// Describe the queue for the format package
+func (queue *IndexedPriorityQueue[T]) describe() format.Container[int, T] {
+	return format.Container[int, T]{
+		Name:  "IndexedPriorityQueue",
+		Open:  "[",
+		Close: "]",
+		All: func(yield func(int, T) bool) {
+			for index, item := range queue.heap {
+				if !yield(index, item.value) {
+					return
+				}
+			}
+		},
+		Size: len(queue.heap),
+	}
+}
+
+// Restore the heap order for the element at the index, moving it up or down
+func (queue *IndexedPriorityQueue[T]) fix(index int) {
+	if index > 0 && queue.higher(index, (index-1)/2) {
+		queue.siftUp(index)
+	} else {
+		queue.siftDown(index)
+	}
+}
+
+// Return true if the element at index i has a higher priority than the one at index j
+func (queue *IndexedPriorityQueue[T]) higher(i, j int) bool {
+	diff := queue.comparator(queue.heap[i].value, queue.heap[j].value)
+	if queue.max {
+		return diff > 0
+	}
+
+	return diff < 0
+}
+
+func (queue *IndexedPriorityQueue[T]) removeAt(index int) {
+	removed := queue.heap[index]
+	last := len(queue.heap) - 1
+
+	queue.swap(index, last)
+	queue.heap[last] = nil
+	queue.heap = queue.heap[:last]
+	delete(queue.items, removed.handle)
+
+	if index < last {
+		queue.fix(index)
+	}
+}
+
+func (queue *IndexedPriorityQueue[T]) siftDown(index int) {
+	size := len(queue.heap)
+
+	for {
+		best := index
+		left, right := 2*index+1, 2*index+2
+
+		if left < size && queue.higher(left, best) {
+			best = left
+		}
+
+		if right < size && queue.higher(right, best) {
+			best = right
+		}
+
+		if best == index {
+			return
+		}
+
+		queue.swap(index, best)
+		index = best
+	}
+}
+
+func (queue *IndexedPriorityQueue[T]) siftUp(index int) {
+	for index > 0 {
+		parent := (index - 1) / 2
+		if !queue.higher(index, parent) {
+			return
+		}
+
+		queue.swap(index, parent)
+		index = parent
+	}
+}
+
+func (queue *IndexedPriorityQueue[T]) swap(i, j int) {
+	queue.heap[i], queue.heap[j] = queue.heap[j], queue.heap[i]
+	queue.heap[i].index = i
+	queue.heap[j].index = j
+}
@@ -0,0 +1,207 @@
+package priority
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	comparator "github.com/dterbah/gods/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func popAllIndexed(queue *IndexedPriorityQueue[int]) []int {
+	values := []int{}
+	for !queue.IsEmpty() {
+		value, _, _ := queue.Pop()
+		values = append(values, value)
+	}
+
+	return values
+}
+
+func TestIndexedPriorityQueueClear(t *testing.T) {
+	assert := assert.New(t)
+	queue := NewIndexed(comparator.IntComparator)
+	handle := queue.Push(1)
+	queue.Clear()
+
+	assert.True(queue.IsEmpty())
+	assert.False(queue.Contains(handle))
+}
+
+func TestIndexedPriorityQueueContains(t *testing.T) {
+	assert := assert.New(t)
+	queue := NewIndexed(comparator.IntComparator)
+
+	handle := queue.Push(1)
+	assert.True(queue.Contains(handle))
+
+	queue.Pop()
+	assert.False(queue.Contains(handle))
+}
+
+func TestIndexedPriorityQueueGet(t *testing.T) {
+	assert := assert.New(t)
+	queue := NewIndexed(comparator.IntComparator)
+	handle := queue.Push(7)
+
+	value, err := queue.Get(handle)
+	assert.Nil(err)
+	assert.Equal(7, value)
+
+	_, err = queue.Get(handle + 1)
+	assert.NotNil(err)
+}
+
+func TestIndexedPriorityQueueMax(t *testing.T) {
+	assert := assert.New(t)
+	queue := NewIndexedMax(comparator.IntComparator)
+	queue.Push(1)
+	queue.Push(3)
+	queue.Push(2)
+
+	assert.Equal([]int{3, 2, 1}, popAllIndexed(queue))
+}
+
+func TestIndexedPriorityQueuePeek(t *testing.T) {
+	assert := assert.New(t)
+	queue := NewIndexed(comparator.IntComparator)
+
+	_, _, err := queue.Peek()
+	assert.NotNil(err)
+
+	queue.Push(3)
+	handle := queue.Push(1)
+
+	value, peekedHandle, err := queue.Peek()
+	assert.Nil(err)
+	assert.Equal(1, value)
+	assert.Equal(handle, peekedHandle)
+	assert.Equal(2, queue.Size())
+}
+
+func TestIndexedPriorityQueuePop(t *testing.T) {
+	assert := assert.New(t)
+	queue := NewIndexed(comparator.IntComparator)
+
+	_, _, err := queue.Pop()
+	assert.NotNil(err)
+
+	first := queue.Push(2)
+	queue.Push(3)
+
+	value, handle, err := queue.Pop()
+	assert.Nil(err)
+	assert.Equal(2, value)
+	assert.Equal(first, handle)
+}
+
+func TestIndexedPriorityQueuePrint(t *testing.T) {
+	queue := NewIndexed(comparator.IntComparator)
+	queue.Push(1)
+	queue.Push(2)
+	queue.Print()
+}
+
+func TestIndexedPriorityQueueRemove(t *testing.T) {
+	assert := assert.New(t)
+	queue := NewIndexed(comparator.IntComparator)
+	queue.Push(5)
+	handle := queue.Push(1)
+	queue.Push(3)
+	queue.Push(4)
+
+	value, err := queue.Remove(handle)
+	assert.Nil(err)
+	assert.Equal(1, value)
+
+	_, err = queue.Remove(handle)
+	assert.NotNil(err)
+
+	assert.Equal([]int{3, 4, 5}, popAllIndexed(queue))
+}
+
+func TestIndexedPriorityQueueString(t *testing.T) {
+	assert := assert.New(t)
+	queue := NewIndexed(comparator.IntComparator)
+	queue.Push(2)
+	queue.Push(1)
+
+	assert.Equal("[1, 2]", queue.String())
+	assert.Equal("[1, 2]", fmt.Sprint(queue))
+	assert.Equal("IndexedPriorityQueue(size=2) [1, 2]", fmt.Sprintf("%+v", queue))
+	assert.Equal(`&priority.IndexedPriorityQueue[int]{1, 2}`, fmt.Sprintf("%#v", queue))
+
+	var builder strings.Builder
+	count, err := queue.WriteTo(&builder)
+	assert.Nil(err)
+	assert.Equal(int64(len("[1, 2]")), count)
+	assert.Equal("[1, 2]", builder.String())
+}
+
+func TestIndexedPriorityQueueUpdate(t *testing.T) {
+	assert := assert.New(t)
+	queue := NewIndexed(comparator.IntComparator)
+	queue.Push(5)
+	handle := queue.Push(10)
+	queue.Push(3)
+
+	// Decrease key
+	assert.Nil(queue.Update(handle, 1))
+	value, peekedHandle, _ := queue.Peek()
+	assert.Equal(1, value)
+	assert.Equal(handle, peekedHandle)
+
+	// Increase key
+	assert.Nil(queue.Update(handle, 20))
+	assert.Equal([]int{3, 5, 20}, popAllIndexed(queue))
+
+	assert.NotNil(queue.Update(handle, 1))
+}
+
+func TestIndexedPriorityQueueRandomOperations(t *testing.T) {
+	assert := assert.New(t)
+	queue := NewIndexed(comparator.IntComparator)
+	random := rand.New(rand.NewSource(42))
+	expected := map[Handle]int{}
+	handles := []Handle{}
+
+	for i := 0; i < 2000; i++ {
+		switch random.Intn(3) {
+		case 0:
+			value := random.Intn(1000)
+			handle := queue.Push(value)
+			expected[handle] = value
+			handles = append(handles, handle)
+		case 1:
+			if len(handles) == 0 {
+				continue
+			}
+
+			handle := handles[random.Intn(len(handles))]
+			if _, exists := expected[handle]; exists {
+				value := random.Intn(1000)
+				assert.Nil(queue.Update(handle, value))
+				expected[handle] = value
+			}
+		default:
+			if len(handles) > 0 {
+				handle := handles[random.Intn(len(handles))]
+				_, err := queue.Remove(handle)
+				_, exists := expected[handle]
+				assert.Equal(exists, err == nil)
+				delete(expected, handle)
+			}
+		}
+	}
+
+	values := []int{}
+	for _, value := range expected {
+		values = append(values, value)
+	}
+	slices.Sort(values)
+
+	assert.Equal(values, popAllIndexed(queue))
+}
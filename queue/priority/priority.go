@@ -0,0 +1,362 @@
+package priority
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/dterbah/gods/internal/format"
+	"github.com/dterbah/gods/internal/serialization"
+	"github.com/dterbah/gods/iterable"
+	comparator "github.com/dterbah/gods/utils"
+)
+
+/*
+Struct that represents what is a PriorityQueue.
+The elements are stored in a binary heap ordered by the comparator:
+Push and Pop run in O(log n) and Peek in O(1).
+By default, the queue is a min-heap, i.e. Pop returns the lowest element.
+*/
+type PriorityQueue[T any] struct {
+	elements    []T
+	comparator  comparator.Comparator[T]
+	max         bool
+	zeroElement T
+}
+
+/*
+Payload of the binary format of a PriorityQueue. Elements contains the elements
+in heap order, and Max tells if the greatest element has the highest priority
+*/
+type priorityQueue[T any] struct {
+	Elements []T
+	Max      bool
+}
+
+/*
+Create a new PriorityQueue where the lowest element has the highest priority.
+The initial elements are heapified in O(n)
+*/
+func New[T any](comparator comparator.Comparator[T], elements ...T) *PriorityQueue[T] {
+	return newQueue(comparator, false, elements)
+}
+
+/*
+Create a new PriorityQueue where the greatest element has the highest priority.
+The initial elements are heapified in O(n)
+*/
+func NewMax[T any](comparator comparator.Comparator[T], elements ...T) *PriorityQueue[T] {
+	return newQueue(comparator, true, elements)
+}
+
+/*
+Create a min PriorityQueue from an iterable. The elements are heapified in O(n)
+*/
+func FromIterable[T any](iterable iterable.Iterable[T], comparator comparator.Comparator[T]) *PriorityQueue[T] {
+	elements := []T{}
+	iterable.ForEach(func(element T, index int) {
+		elements = append(elements, element)
+	})
+
+	return newQueue(comparator, false, elements)
+}
+
+/*
+Return an iterator over the elements of the queue, in heap order.
+The iteration stops as soon as the loop body breaks
+*/
+func (queue *PriorityQueue[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for index, element := range queue.elements {
+			if !yield(index, element) {
+				return
+			}
+		}
+	}
+}
+
+/*
+Remove all the elements of the queue
+*/
+func (queue *PriorityQueue[T]) Clear() {
+	queue.elements = []T{}
+}
+
+/*
+Call a function for each element in the queue, in heap order
+*/
+func (queue *PriorityQueue[T]) ForEach(callback func(element T, index int)) {
+	for index, element := range queue.elements {
+		callback(element, index)
+	}
+}
+
+/*
+Format the queue for the fmt package: %v writes its elements, %+v also writes
+its size, and %#v writes a Go-syntax representation.
+The other verbs are applied to each element
+*/
+func (queue *PriorityQueue[T]) Format(state fmt.State, verb rune) {
+	format.Format(state, verb, queue, queue.describe())
+}
+
+/*
+Decode the queue from the gob encoding, see UnmarshalBinary
+*/
+func (queue *PriorityQueue[T]) GobDecode(data []byte) error {
+	return queue.UnmarshalBinary(data)
+}
+
+/*
+Encode the queue for gob, see MarshalBinary
+*/
+func (queue *PriorityQueue[T]) GobEncode() ([]byte, error) {
+	return queue.MarshalBinary()
+}
+
+/*
+Return true if the greatest element has the highest priority, else false
+*/
+func (queue *PriorityQueue[T]) IsMax() bool {
+	return queue.max
+}
+
+/*
+Return true if no element is present in the queue, else false
+*/
+func (queue *PriorityQueue[T]) IsEmpty() bool {
+	return len(queue.elements) == 0
+}
+
+/*
+Encode the queue in a binary format made of a versioned header
+followed by the gob encoding of its elements and of its ordering
+*/
+func (queue *PriorityQueue[T]) MarshalBinary() ([]byte, error) {
+	return serialization.Marshal(&priorityQueue[T]{Elements: queue.ToArray(), Max: queue.max})
+}
+
+/*
+Encode the queue as a JSON array of its elements, in heap order
+*/
+func (queue *PriorityQueue[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(queue.ToArray())
+}
+
+/*
+Return the element with the highest priority without removing it.
+If the queue is empty, it returns an error
+*/
+func (queue *PriorityQueue[T]) Peek() (T, error) {
+	if queue.IsEmpty() {
+		return queue.zeroElement, errors.New("queue empty")
+	}
+
+	return queue.elements[0], nil
+}
+
+/*
+Remove and return the element with the highest priority.
+If the queue is empty, it returns an error
+*/
+func (queue *PriorityQueue[T]) Pop() (T, error) {
+	if queue.IsEmpty() {
+		return queue.zeroElement, errors.New("queue empty")
+	}
+
+	top := queue.elements[0]
+	last := len(queue.elements) - 1
+	queue.elements[0] = queue.elements[last]
+	queue.elements[last] = queue.zeroElement
+	queue.elements = queue.elements[:last]
+	queue.siftDown(0)
+
+	return top, nil
+}
+
+func (queue *PriorityQueue[T]) Print() {
+	fmt.Println(queue.String())
+}
+
+/*
+Push elements inside the queue
+*/
+func (queue *PriorityQueue[T]) Push(elements ...T) {
+	for _, element := range elements {
+		queue.elements = append(queue.elements, element)
+		queue.siftUp(len(queue.elements) - 1)
+	}
+}
+
+/*
+Toggle the ordering of the queue: a min queue becomes a max queue and
+the other way around. The elements are heapified again in O(n)
+*/
+func (queue *PriorityQueue[T]) Reverse() {
+	queue.max = !queue.max
+	queue.heapify()
+}
+
+/*
+Return the current size of the queue
+*/
+func (queue *PriorityQueue[T]) Size() int {
+	return len(queue.elements)
+}
+
+/*
+Return the elements of the queue as a string, e.g. [1, 2, 3] in heap order
+*/
+func (queue *PriorityQueue[T]) String() string {
+	return format.String(queue.describe())
+}
+
+/*
+Return the elements of the queue, in heap order
+*/
+func (queue *PriorityQueue[T]) ToArray() []T {
+	elements := make([]T, len(queue.elements))
+	copy(elements, queue.elements)
+	return elements
+}
+
+/*
+Decode the binary format written by MarshalBinary into the queue, replacing its content.
+The queue becomes a min or a max queue like the encoded one. Its comparator is kept,
+or the one registered for the type of the elements is used
+*/
+func (queue *PriorityQueue[T]) UnmarshalBinary(data []byte) error {
+	var payload priorityQueue[T]
+	if err := serialization.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	queue.max = payload.Max
+	return queue.load(payload.Elements)
+}
+
+/*
+Decode a JSON array of elements into the queue, replacing its content. The elements
+are heapified, and the queue stays a min or a max queue. The comparator of the queue is kept.
+If the queue has no comparator, the one registered for the type of the elements is used
+*/
+func (queue *PriorityQueue[T]) UnmarshalJSON(data []byte) error {
+	var elements []T
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	return queue.load(elements)
+}
+
+/*
+Return an iterator over the elements of the queue, in heap order.
+The iteration stops as soon as the loop body breaks
+*/
+func (queue *PriorityQueue[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, element := range queue.elements {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+/*
+Write the elements of the queue, as returned by String, to the writer
+*/
+func (queue *PriorityQueue[T]) WriteTo(writer io.Writer) (int64, error) {
+	return format.WriteTo(writer, queue.describe())
+}
+
+// Private methods //
+
+func newQueue[T any](comparator comparator.Comparator[T], max bool, elements []T) *PriorityQueue[T] {
+	var zero T
+	queue := &PriorityQueue[T]{
+		elements:    append([]T{}, elements...),
+		comparator:  comparator,
+		max:         max,
+		zeroElement: zero,
+	}
+	queue.heapify()
+
+	return queue
+}
+
+// Describe the queue for the format package
+func (queue *PriorityQueue[T]) describe() format.Container[int, T] {
+	return format.Container[int, T]{
+		Name:  "PriorityQueue",
+		Open:  "[",
+		Close: "]",
+		All:   queue.All(),
+		Size:  queue.Size(),
+	}
+}
+
+func (queue *PriorityQueue[T]) heapify() {
+	for index := len(queue.elements)/2 - 1; index >= 0; index-- {
+		queue.siftDown(index)
+	}
+}
+
+// Return true if the element at index i has a higher priority than the one at index j
+func (queue *PriorityQueue[T]) higher(i, j int) bool {
+	diff := queue.comparator(queue.elements[i], queue.elements[j])
+	if queue.max {
+		return diff > 0
+	}
+
+	return diff < 0
+}
+
+// Replace the content of the queue by the elements, resolving the comparator if needed
+func (queue *PriorityQueue[T]) load(elements []T) error {
+	comparator, err := comparator.Resolve(queue.comparator)
+	if err != nil {
+		return err
+	}
+
+	*queue = *newQueue(comparator, queue.max, elements)
+	return nil
+}
+
+func (queue *PriorityQueue[T]) siftDown(index int) {
+	size := len(queue.elements)
+
+	for {
+		best := index
+		left, right := 2*index+1, 2*index+2
+
+		if left < size && queue.higher(left, best) {
+			best = left
+		}
+
+		if right < size && queue.higher(right, best) {
+			best = right
+		}
+
+		if best == index {
+			return
+		}
+
+		queue.elements[index], queue.elements[best] = queue.elements[best], queue.elements[index]
+		index = best
+	}
+}
+
+func (queue *PriorityQueue[T]) siftUp(index int) {
+	for index > 0 {
+		parent := (index - 1) / 2
+		if !queue.higher(index, parent) {
+			return
+		}
+
+		queue.elements[index], queue.elements[parent] = queue.elements[parent], queue.elements[index]
+		index = parent
+	}
+}
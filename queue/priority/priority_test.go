@@ -0,0 +1,222 @@
+package priority
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dterbah/gods/list/arraylist"
+	comparator "github.com/dterbah/gods/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func popAll(queue *PriorityQueue[int]) []int {
+	values := []int{}
+	for !queue.IsEmpty() {
+		value, _ := queue.Pop()
+		values = append(values, value)
+	}
+
+	return values
+}
+
+func TestPriorityQueueBinary(t *testing.T) {
+	assert := assert.New(t)
+	original := New(comparator.IntComparator, 3, 1, 2)
+
+	data, err := original.MarshalBinary()
+	assert.Nil(err)
+
+	var decoded PriorityQueue[int]
+	assert.Nil(decoded.UnmarshalBinary(data))
+	assert.Equal(original.ToArray(), decoded.ToArray())
+
+	// Containers can be embedded in gob encoded structs
+	var buffer bytes.Buffer
+	assert.Nil(gob.NewEncoder(&buffer).Encode(struct{ Container *PriorityQueue[int] }{original}))
+
+	var checkpoint struct{ Container *PriorityQueue[int] }
+	assert.Nil(gob.NewDecoder(&buffer).Decode(&checkpoint))
+	decoded = *checkpoint.Container
+	assert.Equal(original.ToArray(), decoded.ToArray())
+
+	assert.NotNil(decoded.UnmarshalBinary([]byte("invalid")))
+}
+
+func TestPriorityQueueBinaryMax(t *testing.T) {
+	assert := assert.New(t)
+	original := NewMax(comparator.IntComparator, 3, 5, 1)
+
+	var buffer bytes.Buffer
+	assert.Nil(gob.NewEncoder(&buffer).Encode(struct{ Container *PriorityQueue[int] }{original}))
+
+	var checkpoint struct{ Container *PriorityQueue[int] }
+	assert.Nil(gob.NewDecoder(&buffer).Decode(&checkpoint))
+	decoded := checkpoint.Container
+	assert.True(decoded.IsMax())
+
+	element, err := decoded.Pop()
+	assert.Nil(err)
+	assert.Equal(5, element)
+
+	// A max queue decoded into a min queue becomes a max queue
+	data, err := original.MarshalBinary()
+	assert.Nil(err)
+	minQueue := New(comparator.IntComparator)
+	assert.Nil(minQueue.UnmarshalBinary(data))
+	assert.True(minQueue.IsMax())
+}
+
+func TestPriorityQueueClear(t *testing.T) {
+	assert := assert.New(t)
+	queue := New(comparator.IntComparator, 1, 2, 3)
+	queue.Clear()
+
+	assert.True(queue.IsEmpty())
+	assert.Equal(0, queue.Size())
+}
+
+func TestPriorityQueueFromIterable(t *testing.T) {
+	assert := assert.New(t)
+	list := arraylist.New(comparator.IntComparator, 5, 1, 4, 2, 3)
+	queue := FromIterable(list, comparator.IntComparator)
+
+	assert.Equal(5, queue.Size())
+	assert.Equal([]int{1, 2, 3, 4, 5}, popAll(queue))
+}
+
+func TestPriorityQueueJSON(t *testing.T) {
+	assert := assert.New(t)
+	queue := New(comparator.IntComparator, 3, 1, 2)
+
+	data, err := json.Marshal(queue)
+	assert.Nil(err)
+
+	var decoded PriorityQueue[int]
+	assert.Nil(json.Unmarshal(data, &decoded))
+	assert.Equal(3, decoded.Size())
+	element, _ := decoded.Pop()
+	assert.Equal(1, element)
+
+	// A max queue stays a max queue
+	maxQueue := NewMax(comparator.IntComparator)
+	assert.Nil(json.Unmarshal([]byte("[1,5,3]"), maxQueue))
+	element, _ = maxQueue.Pop()
+	assert.Equal(5, element)
+}
+
+func TestPriorityQueueNew(t *testing.T) {
+	assert := assert.New(t)
+	queue := New(comparator.IntComparator, 5, 1, 4, 2, 3)
+
+	assert.False(queue.IsMax())
+	assert.Equal([]int{1, 2, 3, 4, 5}, popAll(queue))
+}
+
+func TestPriorityQueueNewMax(t *testing.T) {
+	assert := assert.New(t)
+	queue := NewMax(comparator.IntComparator, 5, 1, 4, 2, 3)
+
+	assert.True(queue.IsMax())
+	assert.Equal([]int{5, 4, 3, 2, 1}, popAll(queue))
+}
+
+func TestPriorityQueueNewDoesNotAliasSlice(t *testing.T) {
+	assert := assert.New(t)
+	values := []int{3, 2, 1}
+	New(comparator.IntComparator, values...)
+
+	assert.Equal([]int{3, 2, 1}, values)
+}
+
+func TestPriorityQueuePeek(t *testing.T) {
+	assert := assert.New(t)
+	queue := New(comparator.IntComparator)
+
+	_, err := queue.Peek()
+	assert.NotNil(err)
+
+	queue.Push(3, 1, 2)
+	value, err := queue.Peek()
+	assert.Nil(err)
+	assert.Equal(1, value)
+	assert.Equal(3, queue.Size())
+}
+
+func TestPriorityQueuePop(t *testing.T) {
+	assert := assert.New(t)
+	queue := New(comparator.IntComparator)
+
+	_, err := queue.Pop()
+	assert.NotNil(err)
+
+	random := rand.New(rand.NewSource(42))
+	expected := []int{}
+	for i := 0; i < 1000; i++ {
+		value := random.Intn(100)
+		queue.Push(value)
+		expected = append(expected, value)
+	}
+
+	slices.Sort(expected)
+	assert.Equal(expected, popAll(queue))
+}
+
+func TestPriorityQueuePrint(t *testing.T) {
+	queue := New(comparator.IntComparator, 1, 2, 3)
+	queue.Print()
+}
+
+func TestPriorityQueueReverse(t *testing.T) {
+	assert := assert.New(t)
+	queue := New(comparator.IntComparator, 2, 3, 1)
+
+	queue.Reverse()
+	assert.True(queue.IsMax())
+	value, _ := queue.Peek()
+	assert.Equal(3, value)
+
+	queue.Reverse()
+	assert.Equal([]int{1, 2, 3}, popAll(queue))
+}
+
+func TestPriorityQueueString(t *testing.T) {
+	assert := assert.New(t)
+	queue := New(comparator.IntComparator, 2, 1)
+
+	assert.Equal("[1, 2]", queue.String())
+	assert.Equal("[1, 2]", fmt.Sprint(queue))
+	assert.Equal("PriorityQueue(size=2) [1, 2]", fmt.Sprintf("%+v", queue))
+	assert.Equal(`&priority.PriorityQueue[int]{1, 2}`, fmt.Sprintf("%#v", queue))
+
+	var builder strings.Builder
+	count, err := queue.WriteTo(&builder)
+	assert.Nil(err)
+	assert.Equal(int64(len("[1, 2]")), count)
+	assert.Equal("[1, 2]", builder.String())
+}
+
+func TestPriorityQueueValues(t *testing.T) {
+	assert := assert.New(t)
+	queue := New(comparator.IntComparator, 1, 2, 3)
+
+	assert.ElementsMatch([]int{1, 2, 3}, queue.ToArray())
+
+	count := 0
+	for range queue.Values() {
+		count++
+		break
+	}
+	assert.Equal(1, count)
+
+	indexes := []int{}
+	queue.ForEach(func(element, index int) {
+		indexes = append(indexes, index)
+	})
+	assert.Equal([]int{0, 1, 2}, indexes)
+}
@@ -1,21 +1,26 @@
 package queue
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"iter"
 
+	"github.com/dterbah/gods/deque"
+	"github.com/dterbah/gods/internal/format"
+	"github.com/dterbah/gods/internal/serialization"
 	"github.com/dterbah/gods/iterable"
 	comparator "github.com/dterbah/gods/utils"
 )
 
 /*
 Struct that represents whats is a Queue.
-It stores the elements in an array that grow when
-needed.
+It stores the elements in a Deque, so Enqueue and Dequeue
+run in amortized O(1). The zero value is an empty queue ready to use.
 */
 type Queue[T any] struct {
-	elements    []T
-	size        int
+	elements    deque.Deque[T]
 	zeroElement T
 	comparator  comparator.Comparator[T]
 }
@@ -25,15 +30,36 @@ Create a new Queue
 */
 func New[T any](comparator comparator.Comparator[T]) *Queue[T] {
 	var zero T
-	return &Queue[T]{elements: []T{}, zeroElement: zero, comparator: comparator}
+	return &Queue[T]{elements: *deque.New(comparator), zeroElement: zero, comparator: comparator}
+}
+
+/*
+Create a new Queue comparing its elements with the == operator
+*/
+func NewComparable[T comparable]() *Queue[T] {
+	return NewWithEqualer(comparator.Equal[T])
+}
+
+/*
+Create a new Queue comparing its elements with the equaler, for the types with no ordering
+*/
+func NewWithEqualer[T any](equaler comparator.Equaler[T]) *Queue[T] {
+	return New(comparator.FromEqualer(equaler))
+}
+
+/*
+Return an iterator over the indexes and the elements of the queue.
+The iteration stops as soon as the loop body breaks
+*/
+func (queue *Queue[T]) All() iter.Seq2[int, T] {
+	return queue.elements.All()
 }
 
 /*
 Remove all the elements of the queue
 */
 func (queue *Queue[T]) Clear() {
-	queue.size = 0
-	queue.elements = []T{}
+	queue.elements.Clear()
 }
 
 /*
@@ -53,24 +79,14 @@ func (queue Queue[T]) Copy() *Queue[T] {
 Return true if the element is present inside the Queue, else false
 */
 func (queue Queue[T]) Contains(element T) bool {
-	for _, value := range queue.elements[:queue.size] {
-		if queue.comparator(value, element) == 0 {
-			return true
-		}
-	}
-
-	return false
+	return queue.elements.Contains(element)
 }
 
 /*
 Enqueue an element inside the Queue
 */
 func (queue *Queue[T]) Enqueue(elements ...T) {
-	queue.growIfNeeded(len(elements))
-	for _, element := range elements {
-		queue.elements[queue.size] = element
-		queue.size++
-	}
+	queue.elements.PushBack(elements...)
 }
 
 /*
@@ -81,19 +97,14 @@ func (queue *Queue[T]) Dequeue() (T, error) {
 		return queue.zeroElement, errors.New("queue empty")
 	}
 
-	head := queue.elements[0]
-	queue.shiftElements()
-
-	return head, nil
+	return queue.elements.PopFront()
 }
 
 /*
 Call a function for each element in the queue
 */
 func (queue Queue[T]) ForEach(callback func(element T, index int)) {
-	for index, element := range queue.elements[:queue.size] {
-		callback(element, index)
-	}
+	queue.elements.ForEach(callback)
 }
 
 func FromIterable[T any](iterable iterable.Iterable[T], comparator comparator.Comparator[T]) *Queue[T] {
@@ -106,11 +117,49 @@ func FromIterable[T any](iterable iterable.Iterable[T], comparator comparator.Co
 	return queue
 }
 
+/*
+Format the queue for the fmt package: %v writes its elements, %+v also writes
+its size, and %#v writes a Go-syntax representation.
+The other verbs are applied to each element
+*/
+func (queue *Queue[T]) Format(state fmt.State, verb rune) {
+	format.Format(state, verb, queue, queue.describe())
+}
+
+/*
+Decode the queue from the gob encoding, see UnmarshalBinary
+*/
+func (queue *Queue[T]) GobDecode(data []byte) error {
+	return queue.UnmarshalBinary(data)
+}
+
+/*
+Encode the queue for gob, see MarshalBinary
+*/
+func (queue *Queue[T]) GobEncode() ([]byte, error) {
+	return queue.MarshalBinary()
+}
+
 /*
 Return true if no element is present in the Queue, else false
 */
 func (queue Queue[T]) IsEmpty() bool {
-	return queue.size == 0
+	return queue.elements.IsEmpty()
+}
+
+/*
+Encode the queue in a binary format made of a versioned header
+followed by the gob encoding of its elements
+*/
+func (queue *Queue[T]) MarshalBinary() ([]byte, error) {
+	return serialization.Marshal(queue.elements.ToArray())
+}
+
+/*
+Encode the queue as a JSON array of its elements, from the first to the last
+*/
+func (queue *Queue[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(queue.elements.ToArray())
 }
 
 func (queue Queue[T]) Peek() (T, error) {
@@ -118,44 +167,91 @@ func (queue Queue[T]) Peek() (T, error) {
 		return queue.zeroElement, errors.New("queue empty")
 	}
 
-	return queue.elements[0], nil
+	return queue.elements.PeekFront()
 }
 
 func (queue Queue[T]) Print() {
-	fmt.Print("[")
-
-	queue.ForEach(func(element T, index int) {
-		fmt.Print(element)
-		if index < queue.size-1 {
-			fmt.Print(", ")
-		}
-	})
-
-	fmt.Println("]")
+	fmt.Println(queue.String())
 }
 
 /*
 Return the current size of the Queue
 */
 func (queue Queue[T]) Size() int {
-	return queue.size
+	return queue.elements.Size()
 }
 
-// Private methods //
-func (queue *Queue[T]) growIfNeeded(n int) {
-	currentCapacity := cap(queue.elements)
-	if currentCapacity <= queue.size+n {
-		newCapacity := (currentCapacity + n) * 2
-		newElements := make([]T, newCapacity)
-		copy(newElements, queue.elements)
-		queue.elements = newElements
+/*
+Return the elements of the queue as a string, e.g. [1, 2, 3] from the first to the last
+*/
+func (queue *Queue[T]) String() string {
+	return format.String(queue.describe())
+}
+
+/*
+Decode the binary format written by MarshalBinary into the queue, replacing its content.
+The elements are dequeued in the same order as from the encoded queue. The comparator of
+the queue is kept, or the one registered for the type of the elements is used
+*/
+func (queue *Queue[T]) UnmarshalBinary(data []byte) error {
+	var elements []T
+	if err := serialization.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	return queue.load(elements)
+}
+
+/*
+Decode a JSON array of elements, from the first to the last, into the queue,
+replacing its content. The comparator of the queue is kept. If the queue has no
+comparator, the one registered for the type of the elements is used
+*/
+func (queue *Queue[T]) UnmarshalJSON(data []byte) error {
+	var elements []T
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	return queue.load(elements)
+}
+
+/*
+Return an iterator over the elements of the queue.
+The iteration stops as soon as the loop body breaks
+*/
+func (queue *Queue[T]) Values() iter.Seq[T] {
+	return queue.elements.Values()
+}
+
+/*
+Write the elements of the queue, as returned by String, to the writer
+*/
+func (queue *Queue[T]) WriteTo(writer io.Writer) (int64, error) {
+	return format.WriteTo(writer, queue.describe())
+}
+
+// Private methods
+
+// Describe the queue for the format package
+func (queue *Queue[T]) describe() format.Container[int, T] {
+	return format.Container[int, T]{
+		Name:  "Queue",
+		Open:  "[",
+		Close: "]",
+		All:   queue.All(),
+		Size:  queue.Size(),
 	}
 }
 
-func (queue *Queue[T]) shiftElements() {
-	for index := range queue.elements[:queue.size] {
-		queue.elements[index] = queue.elements[index+1]
+// Replace the content of the queue by the elements, resolving the comparator if needed
+func (queue *Queue[T]) load(elements []T) error {
+	comparator, err := comparator.Resolve(queue.comparator)
+	if err != nil {
+		return err
 	}
 
-	queue.size--
+	*queue = *New(comparator)
+	queue.Enqueue(elements...)
+	return nil
 }
@@ -1,6 +1,12 @@
 package queue
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/dterbah/gods/list/arraylist"
@@ -8,6 +14,30 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func TestQueueBinary(t *testing.T) {
+	assert := assert.New(t)
+	original := New(comparator.IntComparator)
+	original.Enqueue(1, 2, 3)
+
+	data, err := original.MarshalBinary()
+	assert.Nil(err)
+
+	var decoded Queue[int]
+	assert.Nil(decoded.UnmarshalBinary(data))
+	assert.Equal([]int{1, 2, 3}, slices.Collect(decoded.Values()))
+
+	// Containers can be embedded in gob encoded structs
+	var buffer bytes.Buffer
+	assert.Nil(gob.NewEncoder(&buffer).Encode(struct{ Container *Queue[int] }{original}))
+
+	var checkpoint struct{ Container *Queue[int] }
+	assert.Nil(gob.NewDecoder(&buffer).Decode(&checkpoint))
+	decoded = *checkpoint.Container
+	assert.Equal([]int{1, 2, 3}, slices.Collect(decoded.Values()))
+
+	assert.NotNil(decoded.UnmarshalBinary([]byte("invalid")))
+}
+
 func TestQueueClear(t *testing.T) {
 	assert := assert.New(t)
 	queue := New[int](comparator.IntComparator)
@@ -112,6 +142,42 @@ func TestQueueIsEmpty(t *testing.T) {
 	assert.True(queue.IsEmpty())
 }
 
+func TestQueueJSON(t *testing.T) {
+	assert := assert.New(t)
+	queue := New(comparator.IntComparator)
+	queue.Enqueue(1, 2, 3)
+	queue.Dequeue()
+
+	data, err := json.Marshal(queue)
+	assert.Nil(err)
+	assert.Equal("[2,3]", string(data))
+
+	var decoded Queue[int]
+	assert.Nil(json.Unmarshal(data, &decoded))
+	element, _ := decoded.Dequeue()
+	assert.Equal(2, element)
+	assert.True(decoded.Contains(3))
+}
+
+func TestQueueNewComparable(t *testing.T) {
+	assert := assert.New(t)
+	type point struct{ x, y int }
+	queue := NewComparable[point]()
+	queue.Enqueue(point{1, 2}, point{3, 4})
+
+	assert.True(queue.Contains(point{3, 4}))
+	assert.False(queue.Contains(point{4, 3}))
+}
+
+func TestQueueNewWithEqualer(t *testing.T) {
+	assert := assert.New(t)
+	queue := NewWithEqualer(slices.Equal[[]int])
+	queue.Enqueue([]int{1, 2})
+
+	assert.True(queue.Contains([]int{1, 2}))
+	assert.False(queue.Contains([]int{2, 1}))
+}
+
 func TestQueuePrint(t *testing.T) {
 	queue := New[int](comparator.IntComparator)
 	queue.Enqueue(1, 2, 3)
@@ -139,3 +205,103 @@ func TestQueueSize(t *testing.T) {
 	queue.Enqueue(1, 2, 4)
 	assert.Equal(3, queue.Size())
 }
+
+func TestQueueAll(t *testing.T) {
+	assert := assert.New(t)
+	queue := New(comparator.IntComparator)
+	queue.Enqueue(1, 2, 3, 4)
+
+	indexes := []int{}
+	values := []int{}
+	for index, value := range queue.All() {
+		if index == 2 {
+			break
+		}
+		indexes = append(indexes, index)
+		values = append(values, value)
+	}
+
+	assert.Equal([]int{0, 1}, indexes)
+	assert.Equal([]int{1, 2}, values)
+}
+
+func TestQueueString(t *testing.T) {
+	assert := assert.New(t)
+	queue := New(comparator.IntComparator)
+	queue.Enqueue(1, 2)
+
+	assert.Equal("[1, 2]", queue.String())
+	assert.Equal("[1, 2]", fmt.Sprint(queue))
+	assert.Equal("Queue(size=2) [1, 2]", fmt.Sprintf("%+v", queue))
+	assert.Equal(`&queue.Queue[int]{1, 2}`, fmt.Sprintf("%#v", queue))
+
+	var builder strings.Builder
+	count, err := queue.WriteTo(&builder)
+	assert.Nil(err)
+	assert.Equal(int64(len("[1, 2]")), count)
+	assert.Equal("[1, 2]", builder.String())
+}
+
+func TestQueueValues(t *testing.T) {
+	assert := assert.New(t)
+	queue := New(comparator.IntComparator)
+	queue.Enqueue(1, 2, 3, 4)
+
+	values := []int{}
+	for value := range queue.Values() {
+		values = append(values, value)
+	}
+	assert.Equal([]int{1, 2, 3, 4}, values)
+
+	values = []int{}
+	for value := range queue.Values() {
+		if value == 3 {
+			break
+		}
+		values = append(values, value)
+	}
+	assert.Equal([]int{1, 2}, values)
+}
+
+func TestQueueInterleaved(t *testing.T) {
+	assert := assert.New(t)
+	queue := New[int](comparator.IntComparator)
+	expectedValues := []int{}
+
+	for i := 0; i < 100; i++ {
+		queue.Enqueue(2*i, 2*i+1)
+		expectedValues = append(expectedValues, 2*i, 2*i+1)
+
+		value, err := queue.Dequeue()
+		assert.Nil(err)
+		assert.Equal(expectedValues[0], value)
+		expectedValues = expectedValues[1:]
+	}
+
+	assert.Equal(expectedValues, queue.elements.ToArray())
+}
+
+func TestQueueZeroValue(t *testing.T) {
+	assert := assert.New(t)
+	var queue Queue[int]
+
+	assert.True(queue.IsEmpty())
+	_, err := queue.Dequeue()
+	assert.NotNil(err)
+
+	queue.Enqueue(1, 2)
+	assert.Equal(2, queue.Size())
+	assert.False(queue.IsEmpty())
+
+	element, err := queue.Peek()
+	assert.Nil(err)
+	assert.Equal(1, element)
+
+	element, err = queue.Dequeue()
+	assert.Nil(err)
+	assert.Equal(1, element)
+	assert.Equal("[2]", queue.String())
+
+	assert.True(queue.Contains(2))
+	assert.False(queue.Contains(1))
+}
@@ -0,0 +1,15 @@
+package set_test
+
+import (
+	"testing"
+
+	"github.com/dterbah/gods/collection/collectiontest"
+	"github.com/dterbah/gods/set"
+	comparator "github.com/dterbah/gods/utils"
+)
+
+func TestSetConformance(t *testing.T) {
+	collectiontest.TestSet(t, func() set.BasicSet[int] {
+		return set.New(comparator.IntComparator)
+	})
+}
@@ -0,0 +1,27 @@
+package hashset_test
+
+import (
+	"testing"
+
+	"github.com/dterbah/gods/collection/collectiontest"
+	"github.com/dterbah/gods/set"
+	"github.com/dterbah/gods/set/hashset"
+	comparator "github.com/dterbah/gods/utils"
+)
+
+func TestHashSetConformance(t *testing.T) {
+	collectiontest.TestSet(t, func() set.BasicSet[int] {
+		return hashset.New[int]()
+	})
+}
+
+func TestCustomHashSetConformance(t *testing.T) {
+	// A small modulus gives many collisions
+	hasher := func(element int) uint64 {
+		return uint64(element % 7)
+	}
+
+	collectiontest.TestSet(t, func() set.BasicSet[int] {
+		return hashset.NewWithHasher(hasher, comparator.IntComparator)
+	})
+}
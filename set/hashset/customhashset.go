@@ -0,0 +1,426 @@
+package hashset
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/dterbah/gods/collection"
+	"github.com/dterbah/gods/internal/format"
+	"github.com/dterbah/gods/internal/serialization"
+	"github.com/dterbah/gods/set"
+	comparator "github.com/dterbah/gods/utils"
+)
+
+/*
+Function used to compute the hash of an element. Two elements considered
+equal by the comparator must have the same hash
+*/
+type Hasher[T any] func(element T) uint64
+
+/*
+Struct that defines a hash set for elements that are not comparable with ==
+(slices, structs containing slices, ...). The hash of an element is computed
+with a user Hasher, and the collisions are resolved with the comparator.
+
+Like HashSet, removing an element moves the last element of the set at its position.
+*/
+type CustomHashSet[T any] struct {
+	elements    []T
+	hashes      []uint64
+	buckets     map[uint64][]int
+	hasher      Hasher[T]
+	comparator  comparator.Comparator[T]
+	zeroElement T
+}
+
+/*
+Create a new CustomHashSet using a hash function and a comparator
+*/
+func NewWithHasher[T any](hasher Hasher[T], comparator comparator.Comparator[T], elements ...T) *CustomHashSet[T] {
+	var zero T
+	set := &CustomHashSet[T]{
+		elements:    []T{},
+		hashes:      []uint64{},
+		buckets:     make(map[uint64][]int),
+		hasher:      hasher,
+		comparator:  comparator,
+		zeroElement: zero,
+	}
+	set.Add(elements...)
+	return set
+}
+
+/*
+Add elements in the set. If some elements are already
+present in the set, they won't be include a second time
+*/
+func (set *CustomHashSet[T]) Add(elements ...T) {
+	for _, element := range elements {
+		hash := set.hasher(element)
+		if set.find(element, hash) != -1 {
+			continue
+		}
+
+		set.buckets[hash] = append(set.buckets[hash], len(set.elements))
+		set.elements = append(set.elements, element)
+		set.hashes = append(set.hashes, hash)
+	}
+}
+
+/*
+Add all elements present in the collection
+*/
+func (set *CustomHashSet[T]) AddAll(elements collection.Collection[T]) {
+	for i := 0; i < elements.Size(); i++ {
+		element, _ := elements.At(i)
+		set.Add(element)
+	}
+}
+
+/*
+Return an iterator over the indexes and the elements of the set.
+The iteration stops as soon as the loop body breaks
+*/
+func (set *CustomHashSet[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for index, element := range set.elements {
+			if !yield(index, element) {
+				return
+			}
+		}
+	}
+}
+
+/*
+Retrieve an element by its index
+If the index is negative or greater than the set size, the method will return an error
+*/
+func (set *CustomHashSet[T]) At(index int) (T, error) {
+	if index < 0 || index >= len(set.elements) {
+		return set.zeroElement, errors.New("index out of bound")
+	}
+
+	return set.elements[index], nil
+}
+
+/*
+Clear all the elements in the set. After a clear, the set is totally empty
+*/
+func (set *CustomHashSet[T]) Clear() {
+	set.elements = []T{}
+	set.hashes = []uint64{}
+	set.buckets = make(map[uint64][]int)
+}
+
+/*
+Return true if the set contains the element, else false
+*/
+func (set *CustomHashSet[T]) Contains(element T) bool {
+	return set.IndexOf(element) != -1
+}
+
+func (set *CustomHashSet[T]) ContainsAll(collection collection.Collection[T]) bool {
+	for index := 0; index < collection.Size(); index++ {
+		value, _ := collection.At(index)
+		if !set.Contains(value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+Create a copy of the current set
+*/
+func (set *CustomHashSet[T]) Copy() set.BasicSet[T] {
+	return NewWithHasher(set.hasher, set.comparator, set.elements...)
+}
+
+/*
+Create a new Set with all elements in the current set that
+are not present on the set passed in param
+*/
+func (set *CustomHashSet[T]) Diff(otherSet set.BasicSet[T]) set.BasicSet[T] {
+	newSet := NewWithHasher(set.hasher, set.comparator)
+
+	for _, element := range set.elements {
+		if !otherSet.Contains(element) {
+			newSet.Add(element)
+		}
+	}
+
+	return newSet
+}
+
+/*
+Apply a function for each element of the set
+*/
+func (set *CustomHashSet[T]) ForEach(callback func(element T, index int)) {
+	for index, element := range set.elements {
+		callback(element, index)
+	}
+}
+
+/*
+Format the set for the fmt package: %v writes its elements, %+v also writes
+its size, and %#v writes a Go-syntax representation.
+The other verbs are applied to each element
+*/
+func (set *CustomHashSet[T]) Format(state fmt.State, verb rune) {
+	format.Format(state, verb, set, set.describe())
+}
+
+/*
+Decode the set from the gob encoding, see UnmarshalBinary
+*/
+func (set *CustomHashSet[T]) GobDecode(data []byte) error {
+	return set.UnmarshalBinary(data)
+}
+
+/*
+Encode the set for gob, see MarshalBinary
+*/
+func (set *CustomHashSet[T]) GobEncode() ([]byte, error) {
+	return set.MarshalBinary()
+}
+
+/*
+Return the index in the set of the element (if the element exists in the set)
+If the element is not present in the set, the method will return -1
+*/
+func (set *CustomHashSet[T]) IndexOf(element T) int {
+	return set.find(element, set.hasher(element))
+}
+
+/*
+Compute the intersection between the current set and the one passed in parameter.
+The result is equivalent of A ∩ B
+*/
+func (set *CustomHashSet[T]) Intersection(otherSet set.BasicSet[T]) set.BasicSet[T] {
+	newSet := NewWithHasher(set.hasher, set.comparator)
+
+	for _, element := range set.elements {
+		if otherSet.Contains(element) {
+			newSet.Add(element)
+		}
+	}
+
+	return newSet
+}
+
+/*
+Check if the set is empty or not. Return true if it is empty, otherwise false
+*/
+func (set *CustomHashSet[T]) IsEmpty() bool {
+	return len(set.elements) == 0
+}
+
+/*
+Return true if the set passed in param is a subset of the current set, else false
+*/
+func (set *CustomHashSet[T]) IsSubset(otherSet set.BasicSet[T]) bool {
+	for i := 0; i < otherSet.Size(); i++ {
+		value, _ := otherSet.At(i)
+		if !set.Contains(value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+Encode the set in a binary format made of a versioned header
+followed by the gob encoding of its elements
+*/
+func (set *CustomHashSet[T]) MarshalBinary() ([]byte, error) {
+	return serialization.Marshal(set.ToArray())
+}
+
+/*
+Encode the set as a JSON array of its elements
+*/
+func (set *CustomHashSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(set.ToArray())
+}
+
+func (set *CustomHashSet[T]) Print() {
+	fmt.Println(set.String())
+}
+
+/*
+Remove the element from the set if it exists
+*/
+func (set *CustomHashSet[T]) Remove(element T) {
+	hash := set.hasher(element)
+	index := set.find(element, hash)
+	if index == -1 {
+		return
+	}
+
+	lastIndex := len(set.elements) - 1
+	set.removeFromBucket(hash, index)
+
+	if index != lastIndex {
+		// Move the last element in the hole left by the removed one
+		lastHash := set.hashes[lastIndex]
+		bucket := set.buckets[lastHash]
+		for i, elementIndex := range bucket {
+			if elementIndex == lastIndex {
+				bucket[i] = index
+				break
+			}
+		}
+
+		set.elements[index] = set.elements[lastIndex]
+		set.hashes[index] = lastHash
+	}
+
+	set.elements[lastIndex] = set.zeroElement
+	set.elements = set.elements[:lastIndex]
+	set.hashes = set.hashes[:lastIndex]
+}
+
+/*
+Return the size of the set
+*/
+func (set *CustomHashSet[T]) Size() int {
+	return len(set.elements)
+}
+
+/*
+Return the elements of the set as a string, e.g. {1, 2, 3}
+*/
+func (set *CustomHashSet[T]) String() string {
+	return format.String(set.describe())
+}
+
+func (set *CustomHashSet[T]) ToArray() []T {
+	elements := make([]T, len(set.elements))
+	copy(elements, set.elements)
+	return elements
+}
+
+/*
+Compute the union between the current set and the one passed in parameter.
+The result is equivalent of A ∪ B
+*/
+func (set *CustomHashSet[T]) Union(otherSet set.BasicSet[T]) set.BasicSet[T] {
+	newSet := NewWithHasher(set.hasher, set.comparator, set.elements...)
+
+	otherSet.ForEach(func(element T, index int) {
+		newSet.Add(element)
+	})
+
+	return newSet
+}
+
+/*
+Decode the binary format written by MarshalBinary into the set, replacing its content.
+As for UnmarshalJSON, the set must be created with NewWithHasher before, since the hasher
+is not encoded. The comparator of the set is kept, or the registered one is used
+*/
+func (set *CustomHashSet[T]) UnmarshalBinary(data []byte) error {
+	var elements []T
+	if err := serialization.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	return set.load(elements)
+}
+
+/*
+Decode a JSON array of elements into the set, replacing its content. The duplicated
+elements are ignored. The hasher cannot be decoded, so the set must be created with
+NewWithHasher before, otherwise this method will return an error. If the set has no
+comparator, the one registered for the type of the elements is used
+*/
+func (set *CustomHashSet[T]) UnmarshalJSON(data []byte) error {
+	var elements []T
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	return set.load(elements)
+}
+
+/*
+Return an iterator over the elements of the set.
+The iteration stops as soon as the loop body breaks
+*/
+func (set *CustomHashSet[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, element := range set.elements {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+/*
+Write the elements of the set, as returned by String, to the writer
+*/
+func (set *CustomHashSet[T]) WriteTo(writer io.Writer) (int64, error) {
+	return format.WriteTo(writer, set.describe())
+}
+
+// Private methods
+
+// Describe the set for the format package
+func (set *CustomHashSet[T]) describe() format.Container[int, T] {
+	return format.Container[int, T]{
+		Name:  "CustomHashSet",
+		Open:  "{",
+		Close: "}",
+		All:   set.All(),
+		Size:  set.Size(),
+	}
+}
+
+// Return the index of the element in the set, or -1 if it is not present
+func (set *CustomHashSet[T]) find(element T, hash uint64) int {
+	for _, index := range set.buckets[hash] {
+		if set.comparator(set.elements[index], element) == 0 {
+			return index
+		}
+	}
+
+	return -1
+}
+
+// Replace the content of the set by the elements, resolving the comparator if needed
+func (set *CustomHashSet[T]) load(elements []T) error {
+	if set.hasher == nil {
+		return errors.New("set has no hasher, create it with NewWithHasher before decoding")
+	}
+
+	comparator, err := comparator.Resolve(set.comparator)
+	if err != nil {
+		return err
+	}
+
+	*set = *NewWithHasher(set.hasher, comparator, elements...)
+	return nil
+}
+
+// Remove an index from the bucket of a hash
+func (set *CustomHashSet[T]) removeFromBucket(hash uint64, index int) {
+	bucket := set.buckets[hash]
+	for i, elementIndex := range bucket {
+		if elementIndex == index {
+			bucket = append(bucket[:i], bucket[i+1:]...)
+			break
+		}
+	}
+
+	if len(bucket) == 0 {
+		delete(set.buckets, hash)
+	} else {
+		set.buckets[hash] = bucket
+	}
+}
@@ -0,0 +1,161 @@
+package hashset
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func hashInts(element []int) uint64 {
+	hash := fnv.New64a()
+	for _, value := range element {
+		hash.Write([]byte{byte(value), byte(value >> 8), byte(value >> 16), byte(value >> 24)})
+	}
+	return hash.Sum64()
+}
+
+// Hash function that always collides, to test the collision handling
+func collidingHash(element []int) uint64 {
+	return 0
+}
+
+func TestCustomHashSetAdd(t *testing.T) {
+	assert := assert.New(t)
+	set := NewWithHasher(hashInts, slices.Compare[[]int])
+
+	set.Add([]int{1, 2}, []int{3})
+	set.Add([]int{1, 2})
+
+	assert.Equal(2, set.Size())
+	assert.True(set.Contains([]int{3}))
+	assert.False(set.Contains([]int{2, 1}))
+}
+
+func TestCustomHashSetAt(t *testing.T) {
+	assert := assert.New(t)
+	set := NewWithHasher(hashInts, slices.Compare[[]int], []int{1}, []int{2})
+
+	value, err := set.At(1)
+	assert.Nil(err)
+	assert.Equal([]int{2}, value)
+
+	_, err = set.At(2)
+	assert.NotNil(err)
+}
+
+func TestCustomHashSetBinary(t *testing.T) {
+	assert := assert.New(t)
+	original := NewWithHasher(hashInts, slices.Compare[[]int], []int{1, 2}, []int{3})
+
+	data, err := original.MarshalBinary()
+	assert.Nil(err)
+
+	decoded := NewWithHasher[[]int](hashInts, slices.Compare[[]int])
+	assert.Nil(decoded.UnmarshalBinary(data))
+	assert.Equal([][]int{{1, 2}, {3}}, decoded.ToArray())
+
+	var withoutHasher CustomHashSet[[]int]
+	assert.NotNil(withoutHasher.UnmarshalBinary(data))
+}
+
+func TestCustomHashSetClear(t *testing.T) {
+	assert := assert.New(t)
+	set := NewWithHasher(hashInts, slices.Compare[[]int], []int{1})
+	set.Clear()
+
+	assert.True(set.IsEmpty())
+	assert.False(set.Contains([]int{1}))
+}
+
+func TestCustomHashSetCollisions(t *testing.T) {
+	assert := assert.New(t)
+	set := NewWithHasher(collidingHash, slices.Compare[[]int], []int{1}, []int{2}, []int{3})
+
+	assert.Equal(3, set.Size())
+	assert.Equal(1, set.IndexOf([]int{2}))
+
+	set.Remove([]int{1})
+	assert.Equal(2, set.Size())
+	assert.False(set.Contains([]int{1}))
+	assert.Equal(0, set.IndexOf([]int{3}))
+	assert.Equal(1, set.IndexOf([]int{2}))
+}
+
+func TestCustomHashSetJSON(t *testing.T) {
+	assert := assert.New(t)
+	set := NewWithHasher(hashInts, slices.Compare[[]int], []int{1, 2}, []int{3})
+
+	data, err := json.Marshal(set)
+	assert.Nil(err)
+	assert.Equal("[[1,2],[3]]", string(data))
+
+	decoded := NewWithHasher[[]int](hashInts, slices.Compare[[]int])
+	assert.Nil(json.Unmarshal([]byte("[[1,2],[3],[1,2]]"), decoded))
+	assert.Equal(2, decoded.Size())
+	assert.True(decoded.Contains([]int{1, 2}))
+
+	// The hasher cannot be decoded
+	var withoutHasher CustomHashSet[[]int]
+	assert.NotNil(json.Unmarshal(data, &withoutHasher))
+}
+
+func TestCustomHashSetOperations(t *testing.T) {
+	assert := assert.New(t)
+	set := NewWithHasher(hashInts, slices.Compare[[]int], []int{1}, []int{2}, []int{3})
+	otherSet := NewWithHasher(hashInts, slices.Compare[[]int], []int{2}, []int{4})
+
+	assert.Equal([][]int{{1}, {3}}, set.Diff(otherSet).ToArray())
+	assert.Equal([][]int{{2}}, set.Intersection(otherSet).ToArray())
+	assert.Equal([][]int{{1}, {2}, {3}, {4}}, set.Union(otherSet).ToArray())
+	assert.False(set.IsSubset(otherSet))
+	assert.True(set.IsSubset(set.Copy()))
+}
+
+func TestCustomHashSetRemove(t *testing.T) {
+	assert := assert.New(t)
+	set := NewWithHasher(hashInts, slices.Compare[[]int], []int{1}, []int{2}, []int{3})
+
+	set.Remove([]int{1})
+	set.Remove([]int{10})
+
+	assert.Equal(2, set.Size())
+	assert.Equal([][]int{{3}, {2}}, set.ToArray())
+
+	set.Remove([]int{3})
+	set.Remove([]int{2})
+	assert.True(set.IsEmpty())
+}
+
+func TestCustomHashSetString(t *testing.T) {
+	assert := assert.New(t)
+	set := NewWithHasher(hashInts, slices.Compare[[]int], []int{1, 2})
+
+	assert.Equal("{[1 2]}", set.String())
+	assert.Equal("{[1 2]}", fmt.Sprint(set))
+	assert.Equal("CustomHashSet(size=1) {[1 2]}", fmt.Sprintf("%+v", set))
+	assert.Equal(`&hashset.CustomHashSet[[]int]{[]int{1, 2}}`, fmt.Sprintf("%#v", set))
+
+	var builder strings.Builder
+	count, err := set.WriteTo(&builder)
+	assert.Nil(err)
+	assert.Equal(int64(len("{[1 2]}")), count)
+	assert.Equal("{[1 2]}", builder.String())
+}
+
+func TestCustomHashSetValues(t *testing.T) {
+	assert := assert.New(t)
+	set := NewWithHasher(hashInts, slices.Compare[[]int], []int{1}, []int{2}, []int{3})
+
+	count := 0
+	for range set.Values() {
+		count++
+		break
+	}
+
+	assert.Equal(1, count)
+}
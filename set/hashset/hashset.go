@@ -0,0 +1,355 @@
+package hashset
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/dterbah/gods/collection"
+	"github.com/dterbah/gods/internal/format"
+	"github.com/dterbah/gods/internal/serialization"
+	"github.com/dterbah/gods/set"
+)
+
+/*
+Struct that defines what a HashSet is. Like a Set, it can only store
+one occurence of a specific value, but the lookups are done with a hash
+table. Add, Contains and Remove run in O(1) on average.
+
+The elements are also kept in a slice to provide an indexed access.
+Removing an element moves the last element of the set at its position, so
+the index of an element can change after a removal.
+*/
+type HashSet[T comparable] struct {
+	elements    []T
+	indexes     map[T]int
+	zeroElement T
+}
+
+/*
+Create a new HashSet
+*/
+func New[T comparable](elements ...T) *HashSet[T] {
+	var zero T
+	set := &HashSet[T]{elements: []T{}, indexes: make(map[T]int), zeroElement: zero}
+	set.Add(elements...)
+	return set
+}
+
+/*
+Add elements in the HashSet. If some elements are already
+present in the HashSet, they won't be include a second time
+*/
+func (set *HashSet[T]) Add(elements ...T) {
+	for _, element := range elements {
+		if _, exists := set.indexes[element]; !exists {
+			set.indexes[element] = len(set.elements)
+			set.elements = append(set.elements, element)
+		}
+	}
+}
+
+/*
+Add all elements present in the collection
+*/
+func (set *HashSet[T]) AddAll(elements collection.Collection[T]) {
+	for i := 0; i < elements.Size(); i++ {
+		element, _ := elements.At(i)
+		set.Add(element)
+	}
+}
+
+/*
+Return an iterator over the indexes and the elements of the set.
+The iteration stops as soon as the loop body breaks
+*/
+func (set *HashSet[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for index, element := range set.elements {
+			if !yield(index, element) {
+				return
+			}
+		}
+	}
+}
+
+/*
+Retrieve an element by its index
+If the index is negative or greater than the set size, the method will return an error
+*/
+func (set *HashSet[T]) At(index int) (T, error) {
+	if index < 0 || index >= len(set.elements) {
+		return set.zeroElement, errors.New("index out of bound")
+	}
+
+	return set.elements[index], nil
+}
+
+/*
+Clear all the elements in the set. After a clear, the set is totally empty
+*/
+func (set *HashSet[T]) Clear() {
+	set.elements = []T{}
+	set.indexes = make(map[T]int)
+}
+
+/*
+Return true if the set contains the element, else false
+*/
+func (set *HashSet[T]) Contains(element T) bool {
+	_, exists := set.indexes[element]
+	return exists
+}
+
+func (set *HashSet[T]) ContainsAll(collection collection.Collection[T]) bool {
+	for index := 0; index < collection.Size(); index++ {
+		value, _ := collection.At(index)
+		if !set.Contains(value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+Create a copy of the current set
+*/
+func (set *HashSet[T]) Copy() set.BasicSet[T] {
+	return New(set.elements...)
+}
+
+/*
+Create a new Set with all elements in the current set that
+are not present on the set passed in param
+*/
+func (set *HashSet[T]) Diff(otherSet set.BasicSet[T]) set.BasicSet[T] {
+	newSet := New[T]()
+
+	for _, element := range set.elements {
+		if !otherSet.Contains(element) {
+			newSet.Add(element)
+		}
+	}
+
+	return newSet
+}
+
+/*
+Apply a function for each element of the set
+*/
+func (set *HashSet[T]) ForEach(callback func(element T, index int)) {
+	for index, element := range set.elements {
+		callback(element, index)
+	}
+}
+
+/*
+Format the set for the fmt package: %v writes its elements, %+v also writes
+its size, and %#v writes a Go-syntax representation.
+The other verbs are applied to each element
+*/
+func (set *HashSet[T]) Format(state fmt.State, verb rune) {
+	format.Format(state, verb, set, set.describe())
+}
+
+/*
+Decode the set from the gob encoding, see UnmarshalBinary
+*/
+func (set *HashSet[T]) GobDecode(data []byte) error {
+	return set.UnmarshalBinary(data)
+}
+
+/*
+Encode the set for gob, see MarshalBinary
+*/
+func (set *HashSet[T]) GobEncode() ([]byte, error) {
+	return set.MarshalBinary()
+}
+
+/*
+Return the index in the set of the element (if the element exists in the set)
+If the element is not present in the set, the method will return -1
+*/
+func (set *HashSet[T]) IndexOf(element T) int {
+	if index, exists := set.indexes[element]; exists {
+		return index
+	}
+
+	return -1
+}
+
+/*
+Compute the intersection between the current set and the one passed in parameter.
+The result is equivalent of A ∩ B
+*/
+func (set *HashSet[T]) Intersection(otherSet set.BasicSet[T]) set.BasicSet[T] {
+	newSet := New[T]()
+
+	for _, element := range set.elements {
+		if otherSet.Contains(element) {
+			newSet.Add(element)
+		}
+	}
+
+	return newSet
+}
+
+/*
+Check if the set is empty or not. Return true if it is empty, otherwise false
+*/
+func (set *HashSet[T]) IsEmpty() bool {
+	return len(set.elements) == 0
+}
+
+/*
+Return true if the set passed in param is a subset of the current set, else false
+*/
+func (set *HashSet[T]) IsSubset(otherSet set.BasicSet[T]) bool {
+	for i := 0; i < otherSet.Size(); i++ {
+		value, _ := otherSet.At(i)
+		if !set.Contains(value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+Encode the set in a binary format made of a versioned header
+followed by the gob encoding of its elements
+*/
+func (set *HashSet[T]) MarshalBinary() ([]byte, error) {
+	return serialization.Marshal(set.ToArray())
+}
+
+/*
+Encode the set as a JSON array of its elements
+*/
+func (set *HashSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(set.ToArray())
+}
+
+func (set *HashSet[T]) Print() {
+	fmt.Println(set.String())
+}
+
+/*
+Remove the element from the set if it exists
+*/
+func (set *HashSet[T]) Remove(element T) {
+	index, exists := set.indexes[element]
+	if !exists {
+		return
+	}
+
+	lastIndex := len(set.elements) - 1
+	last := set.elements[lastIndex]
+	set.elements[index] = last
+	set.indexes[last] = index
+	set.elements[lastIndex] = set.zeroElement
+	set.elements = set.elements[:lastIndex]
+	delete(set.indexes, element)
+}
+
+/*
+Return the size of the set
+*/
+func (set *HashSet[T]) Size() int {
+	return len(set.elements)
+}
+
+/*
+Return the elements of the set as a string, e.g. {1, 2, 3}
+*/
+func (set *HashSet[T]) String() string {
+	return format.String(set.describe())
+}
+
+func (set *HashSet[T]) ToArray() []T {
+	elements := make([]T, len(set.elements))
+	copy(elements, set.elements)
+	return elements
+}
+
+/*
+Compute the union between the current set and the one passed in parameter.
+The result is equivalent of A ∪ B
+*/
+func (set *HashSet[T]) Union(otherSet set.BasicSet[T]) set.BasicSet[T] {
+	newSet := New(set.elements...)
+
+	otherSet.ForEach(func(element T, index int) {
+		newSet.Add(element)
+	})
+
+	return newSet
+}
+
+/*
+Decode the binary format written by MarshalBinary into the set, replacing its content
+*/
+func (set *HashSet[T]) UnmarshalBinary(data []byte) error {
+	var elements []T
+	if err := serialization.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	return set.load(elements)
+}
+
+/*
+Decode a JSON array of elements into the set, replacing its content.
+The duplicated elements are ignored
+*/
+func (set *HashSet[T]) UnmarshalJSON(data []byte) error {
+	var elements []T
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	return set.load(elements)
+}
+
+/*
+Return an iterator over the elements of the set.
+The iteration stops as soon as the loop body breaks
+*/
+func (set *HashSet[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, element := range set.elements {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+/*
+Write the elements of the set, as returned by String, to the writer
+*/
+func (set *HashSet[T]) WriteTo(writer io.Writer) (int64, error) {
+	return format.WriteTo(writer, set.describe())
+}
+
+// Private methods
+
+// Describe the set for the format package
+func (set *HashSet[T]) describe() format.Container[int, T] {
+	return format.Container[int, T]{
+		Name:  "HashSet",
+		Open:  "{",
+		Close: "}",
+		All:   set.All(),
+		Size:  set.Size(),
+	}
+}
+
+// Replace the content of the set by the elements
+func (set *HashSet[T]) load(elements []T) error {
+	*set = *New(elements...)
+	return nil
+}
@@ -0,0 +1,252 @@
+package hashset
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dterbah/gods/list/arraylist"
+	"github.com/dterbah/gods/set"
+	comparator "github.com/dterbah/gods/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashSetAdd(t *testing.T) {
+	assert := assert.New(t)
+	set := New[int]()
+
+	set.Add(1, 2, 3)
+	assert.Equal(3, set.Size())
+
+	set.Add(1)
+	assert.Equal(3, set.Size())
+}
+
+func TestHashSetAddAll(t *testing.T) {
+	assert := assert.New(t)
+	set := New[int]()
+	set.AddAll(arraylist.New(comparator.IntComparator, 5, 6, 5))
+
+	assert.Equal(2, set.Size())
+	assert.Equal([]int{5, 6}, set.ToArray())
+}
+
+func TestHashSetAt(t *testing.T) {
+	assert := assert.New(t)
+	set := New(1, 2, 4)
+
+	value, err := set.At(0)
+	assert.Nil(err)
+	assert.Equal(1, value)
+
+	_, err = set.At(-1)
+	assert.NotNil(err)
+
+	_, err = set.At(3)
+	assert.NotNil(err)
+}
+
+func TestHashSetBinary(t *testing.T) {
+	assert := assert.New(t)
+	original := New(3, 1, 2)
+
+	data, err := original.MarshalBinary()
+	assert.Nil(err)
+
+	var decoded HashSet[int]
+	assert.Nil(decoded.UnmarshalBinary(data))
+	assert.Equal([]int{3, 1, 2}, decoded.ToArray())
+
+	// Containers can be embedded in gob encoded structs
+	var buffer bytes.Buffer
+	assert.Nil(gob.NewEncoder(&buffer).Encode(struct{ Container *HashSet[int] }{original}))
+
+	var checkpoint struct{ Container *HashSet[int] }
+	assert.Nil(gob.NewDecoder(&buffer).Decode(&checkpoint))
+	decoded = *checkpoint.Container
+	assert.Equal([]int{3, 1, 2}, decoded.ToArray())
+
+	assert.NotNil(decoded.UnmarshalBinary([]byte("invalid")))
+}
+
+func TestHashSetClear(t *testing.T) {
+	assert := assert.New(t)
+	set := New(1, 3, 4)
+	set.Clear()
+
+	assert.True(set.IsEmpty())
+	assert.False(set.Contains(1))
+}
+
+func TestHashSetContains(t *testing.T) {
+	assert := assert.New(t)
+	set := New("a", "b")
+
+	assert.True(set.Contains("a"))
+	assert.True(set.Contains("b"))
+	assert.False(set.Contains("c"))
+}
+
+func TestHashSetContainsAll(t *testing.T) {
+	assert := assert.New(t)
+	set := New(1, 2, 3, 4)
+
+	assert.False(set.ContainsAll(arraylist.New(comparator.IntComparator, 1, 2, 5)))
+	assert.True(set.ContainsAll(arraylist.New(comparator.IntComparator, 1, 2)))
+}
+
+func TestHashSetCopy(t *testing.T) {
+	assert := assert.New(t)
+	set := New(1, 3)
+	copy := set.Copy()
+
+	assert.Equal(set.ToArray(), copy.ToArray())
+	copy.Add(4)
+	assert.False(set.Contains(4))
+}
+
+func TestHashSetDiff(t *testing.T) {
+	assert := assert.New(t)
+	set := New(1, 2, 3, 6, 9)
+	otherSet := New(1, 2, 5, 6)
+
+	assert.Equal([]int{3, 9}, set.Diff(otherSet).ToArray())
+}
+
+func TestHashSetForEach(t *testing.T) {
+	assert := assert.New(t)
+	expectedValues := []int{4, 2, 8}
+	set := New(expectedValues...)
+
+	set.ForEach(func(element, index int) {
+		assert.Equal(expectedValues[index], element)
+	})
+}
+
+func TestHashSetIndexOf(t *testing.T) {
+	assert := assert.New(t)
+	set := New(1, 2, 3, 4)
+
+	assert.Equal(0, set.IndexOf(1))
+	assert.Equal(3, set.IndexOf(4))
+	assert.Equal(-1, set.IndexOf(9))
+}
+
+func TestHashSetIntersection(t *testing.T) {
+	assert := assert.New(t)
+	set := New(1, 2, 3, 6, 9)
+	otherSet := set.Intersection(New(1, 2, 5, 6))
+
+	assert.Equal([]int{1, 2, 6}, otherSet.ToArray())
+}
+
+func TestHashSetIsSubset(t *testing.T) {
+	assert := assert.New(t)
+	set := New(1, 2, 3, 6, 9)
+
+	assert.True(set.IsSubset(New(1, 2)))
+	assert.False(New(1).IsSubset(New(1, 2)))
+}
+
+func TestHashSetImplementsBasicSet(t *testing.T) {
+	var basicSet set.BasicSet[int] = New(1)
+	assert.True(t, basicSet.Contains(1))
+}
+
+func TestHashSetJSON(t *testing.T) {
+	assert := assert.New(t)
+	set := New(1, 2, 3)
+
+	data, err := json.Marshal(set)
+	assert.Nil(err)
+	assert.Equal("[1,2,3]", string(data))
+
+	var decoded HashSet[int]
+	assert.Nil(json.Unmarshal([]byte("[3,3,1]"), &decoded))
+	assert.Equal(2, decoded.Size())
+	assert.True(decoded.Contains(3))
+	assert.True(decoded.Contains(1))
+}
+
+func TestHashSetPrint(t *testing.T) {
+	set := New(1, 2, 3)
+	set.Print()
+}
+
+func TestHashSetRemove(t *testing.T) {
+	assert := assert.New(t)
+	set := New(1, 2, 3, 6, 9)
+
+	set.Remove(1)
+	assert.Equal(4, set.Size())
+	assert.False(set.Contains(1))
+
+	// The last element takes the place of the removed one
+	assert.Equal(0, set.IndexOf(9))
+	for index, element := range set.All() {
+		assert.Equal(index, set.IndexOf(element))
+	}
+
+	set.Remove(10)
+	assert.Equal(4, set.Size())
+
+	set.Remove(2)
+	set.Remove(3)
+	set.Remove(6)
+	set.Remove(9)
+	assert.True(set.IsEmpty())
+}
+
+func TestHashSetString(t *testing.T) {
+	assert := assert.New(t)
+	set := New(1, 2)
+
+	assert.Equal("{1, 2}", set.String())
+	assert.Equal("{1, 2}", fmt.Sprint(set))
+	assert.Equal("HashSet(size=2) {1, 2}", fmt.Sprintf("%+v", set))
+	assert.Equal(`&hashset.HashSet[int]{1, 2}`, fmt.Sprintf("%#v", set))
+
+	var builder strings.Builder
+	count, err := set.WriteTo(&builder)
+	assert.Nil(err)
+	assert.Equal(int64(len("{1, 2}")), count)
+	assert.Equal("{1, 2}", builder.String())
+}
+
+func TestHashSetUnion(t *testing.T) {
+	assert := assert.New(t)
+	set := New(1, 2, 3, 6, 9)
+
+	result := set.Union(New(1, 2, 5, 6))
+	assert.Equal([]int{1, 2, 3, 6, 9, 5}, result.ToArray())
+}
+
+func TestHashSetValues(t *testing.T) {
+	assert := assert.New(t)
+	set := New(1, 2, 3, 4)
+
+	values := []int{}
+	for value := range set.Values() {
+		if value == 3 {
+			break
+		}
+		values = append(values, value)
+	}
+
+	assert.Equal([]int{1, 2}, values)
+}
+
+func TestHashSetLargeInput(t *testing.T) {
+	assert := assert.New(t)
+	set := New[int]()
+
+	for i := 0; i < 300000; i++ {
+		set.Add(i % 150000)
+	}
+
+	assert.Equal(150000, set.Size())
+	assert.True(set.Contains(149999))
+}
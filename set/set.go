@@ -1,9 +1,14 @@
 package set
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"iter"
 
 	"github.com/dterbah/gods/collection"
+	"github.com/dterbah/gods/internal/format"
+	"github.com/dterbah/gods/internal/serialization"
 	"github.com/dterbah/gods/list"
 	"github.com/dterbah/gods/list/arraylist"
 	comparator "github.com/dterbah/gods/utils"
@@ -30,6 +35,20 @@ func New[T any](comparator comparator.Comparator[T], elements ...T) *Set[T] {
 	return set
 }
 
+/*
+Create a new Set comparing its elements with the == operator
+*/
+func NewComparable[T comparable](elements ...T) *Set[T] {
+	return NewWithEqualer(comparator.Equal[T], elements...)
+}
+
+/*
+Create a new Set comparing its elements with the equaler, for the types with no ordering
+*/
+func NewWithEqualer[T any](equaler comparator.Equaler[T], elements ...T) *Set[T] {
+	return New(comparator.FromEqualer(equaler), elements...)
+}
+
 /*
 Add elements in the Set. If some elements are already
 present in the Set, they won't be include a second time
@@ -52,6 +71,14 @@ func (set *Set[T]) AddAll(elements collection.Collection[T]) {
 	}
 }
 
+/*
+Return an iterator over the indexes and the elements of the set.
+The iteration stops as soon as the loop body breaks
+*/
+func (set *Set[T]) All() iter.Seq2[int, T] {
+	return set.elements.All()
+}
+
 /*
 Retrieve an element by its index
 If the index is negative or greater than the set size, the method will return an error
@@ -118,6 +145,29 @@ func (set *Set[T]) ForEach(callback func(element T, index int)) {
 	set.elements.ForEach(callback)
 }
 
+/*
+Format the set for the fmt package: %v writes its elements, %+v also writes
+its size, and %#v writes a Go-syntax representation.
+The other verbs are applied to each element
+*/
+func (set *Set[T]) Format(state fmt.State, verb rune) {
+	format.Format(state, verb, set, set.describe())
+}
+
+/*
+Decode the set from the gob encoding, see UnmarshalBinary
+*/
+func (set *Set[T]) GobDecode(data []byte) error {
+	return set.UnmarshalBinary(data)
+}
+
+/*
+Encode the set for gob, see MarshalBinary
+*/
+func (set *Set[T]) GobEncode() ([]byte, error) {
+	return set.MarshalBinary()
+}
+
 /*
 Return the index in the set of the element (if the element exists in the set)
 If the element is not present in the set, the method will return -1
@@ -160,23 +210,36 @@ func (set *Set[T]) IsEmpty() bool {
 	return set.elements.IsEmpty()
 }
 
-func (set Set[T]) Print() {
-	fmt.Print("{")
+/*
+Encode the set in a binary format made of a versioned header
+followed by the gob encoding of its elements
+*/
+func (set *Set[T]) MarshalBinary() ([]byte, error) {
+	return serialization.Marshal(set.ToArray())
+}
 
-	set.elements.ForEach(func(element T, index int) {
-		fmt.Print(element)
-		if index < set.Size()-1 {
-			fmt.Print(", ")
-		}
-	})
+/*
+Encode the set as a JSON array of its elements
+*/
+func (set *Set[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(set.ToArray())
+}
 
-	fmt.Println("}")
+func (set Set[T]) Print() {
+	fmt.Println(set.String())
 }
 
 func (set *Set[T]) Remove(element T) {
 	set.elements.Remove(element)
 }
 
+/*
+Return the elements of the set as a string, e.g. {1, 2, 3}
+*/
+func (set *Set[T]) String() string {
+	return format.String(set.describe())
+}
+
 func (set *Set[T]) ToArray() []T {
 	return set.elements.ToArray()
 }
@@ -186,18 +249,10 @@ Compute the union between the current set and the one passed in parameter.
 The result is equivalent of A ∪ B
 */
 func (set *Set[T]) Union(otherSet BasicSet[T]) BasicSet[T] {
-	newSet := New(set.comparator)
-
-	set.ForEach(func(element T, index int) {
-		if !otherSet.Contains(element) {
-			newSet.Add(element)
-		}
-	})
+	newSet := set.Copy()
 
 	otherSet.ForEach(func(element T, index int) {
-		if !set.Contains(element) {
-			newSet.Add(element)
-		}
+		newSet.Add(element)
 	})
 
 	return newSet
@@ -209,3 +264,70 @@ Return the size of the set
 func (set Set[T]) Size() int {
 	return set.elements.Size()
 }
+
+/*
+Decode the binary format written by MarshalBinary into the set, replacing its content.
+The comparator of the set is kept. If the set has no comparator, the one registered for
+the type of the elements is used, else this method will return an error
+*/
+func (set *Set[T]) UnmarshalBinary(data []byte) error {
+	var elements []T
+	if err := serialization.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	return set.load(elements)
+}
+
+/*
+Decode a JSON array of elements into the set, replacing its content.
+The duplicated elements are ignored. The comparator of the set is kept. If the
+set has no comparator, the one registered for the type of the elements is used
+*/
+func (set *Set[T]) UnmarshalJSON(data []byte) error {
+	var elements []T
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	return set.load(elements)
+}
+
+/*
+Return an iterator over the elements of the set.
+The iteration stops as soon as the loop body breaks
+*/
+func (set *Set[T]) Values() iter.Seq[T] {
+	return set.elements.Values()
+}
+
+/*
+Write the elements of the set, as returned by String, to the writer
+*/
+func (set *Set[T]) WriteTo(writer io.Writer) (int64, error) {
+	return format.WriteTo(writer, set.describe())
+}
+
+// Private methods
+
+// Describe the set for the format package
+func (set *Set[T]) describe() format.Container[int, T] {
+	return format.Container[int, T]{
+		Name:  "Set",
+		Open:  "{",
+		Close: "}",
+		All:   set.All(),
+		Size:  set.Size(),
+	}
+}
+
+// Replace the content of the set by the elements, resolving the comparator if needed
+func (set *Set[T]) load(elements []T) error {
+	comparator, err := comparator.Resolve(set.comparator)
+	if err != nil {
+		return err
+	}
+
+	*set = *New(comparator, elements...)
+	return nil
+}
@@ -1,6 +1,12 @@
 package set
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/dterbah/gods/collection"
@@ -55,6 +61,29 @@ func TestSetAt(t *testing.T) {
 	assert.NotNil(err)
 }
 
+func TestSetBinary(t *testing.T) {
+	assert := assert.New(t)
+	original := New(comparator.IntComparator, 3, 1, 2)
+
+	data, err := original.MarshalBinary()
+	assert.Nil(err)
+
+	var decoded Set[int]
+	assert.Nil(decoded.UnmarshalBinary(data))
+	assert.Equal([]int{3, 1, 2}, decoded.ToArray())
+
+	// Containers can be embedded in gob encoded structs
+	var buffer bytes.Buffer
+	assert.Nil(gob.NewEncoder(&buffer).Encode(struct{ Container *Set[int] }{original}))
+
+	var checkpoint struct{ Container *Set[int] }
+	assert.Nil(gob.NewDecoder(&buffer).Decode(&checkpoint))
+	decoded = *checkpoint.Container
+	assert.Equal([]int{3, 1, 2}, decoded.ToArray())
+
+	assert.NotNil(decoded.UnmarshalBinary([]byte("invalid")))
+}
+
 func TestSetClear(t *testing.T) {
 	assert := assert.New(t)
 	set := New[int](comparator.IntComparator, 1, 3, 4)
@@ -161,6 +190,41 @@ func TestSetIsSubset(t *testing.T) {
 
 }
 
+func TestSetJSON(t *testing.T) {
+	assert := assert.New(t)
+	set := New(comparator.IntComparator, 1, 2, 3)
+
+	data, err := json.Marshal(set)
+	assert.Nil(err)
+	assert.Equal("[1,2,3]", string(data))
+
+	var decoded Set[int]
+	assert.Nil(json.Unmarshal([]byte("[1,2,2,3]"), &decoded))
+	assert.Equal([]int{1, 2, 3}, decoded.ToArray())
+}
+
+func TestSetNewComparable(t *testing.T) {
+	assert := assert.New(t)
+	type point struct{ x, y int }
+	set := NewComparable(point{1, 2}, point{3, 4}, point{1, 2})
+
+	assert.Equal(2, set.Size())
+	assert.True(set.Contains(point{3, 4}))
+	assert.False(set.Contains(point{4, 3}))
+
+	set.Remove(point{1, 2})
+	assert.False(set.Contains(point{1, 2}))
+}
+
+func TestSetNewWithEqualer(t *testing.T) {
+	assert := assert.New(t)
+	set := NewWithEqualer(slices.Equal[[]int], []int{1, 2}, []int{1, 2})
+
+	assert.Equal(1, set.Size())
+	assert.True(set.Contains([]int{1, 2}))
+	assert.False(set.Contains([]int{2, 1}))
+}
+
 func TestSetPrint(t *testing.T) {
 	set := New(comparator.IntComparator, 1, 2, 3)
 	set.Print()
@@ -179,6 +243,22 @@ func TestSetRemove(t *testing.T) {
 	assert.Equal(4, set.Size())
 }
 
+func TestSetString(t *testing.T) {
+	assert := assert.New(t)
+	set := New(comparator.IntComparator, 1, 2)
+
+	assert.Equal("{1, 2}", set.String())
+	assert.Equal("{1, 2}", fmt.Sprint(set))
+	assert.Equal("Set(size=2) {1, 2}", fmt.Sprintf("%+v", set))
+	assert.Equal(`&set.Set[int]{1, 2}`, fmt.Sprintf("%#v", set))
+
+	var builder strings.Builder
+	count, err := set.WriteTo(&builder)
+	assert.Nil(err)
+	assert.Equal(int64(len("{1, 2}")), count)
+	assert.Equal("{1, 2}", builder.String())
+}
+
 func TestSetToArray(t *testing.T) {
 	assert := assert.New(t)
 	set := New[int](comparator.IntComparator, 1, 2, 4)
@@ -199,11 +279,49 @@ func TestSetUnion(t *testing.T) {
 	otherSet := New(comparator.IntComparator, 1, 2, 5, 6)
 
 	result := set.Union(otherSet)
-	expectedValues := []int{3, 5, 9}
+	expectedValues := []int{1, 2, 3, 5, 6, 9}
 
-	assert.Equal(3, result.Size())
+	assert.Equal(6, result.Size())
 
 	for _, element := range expectedValues {
 		assert.True(result.Contains(element))
 	}
 }
+
+func TestSetAll(t *testing.T) {
+	assert := assert.New(t)
+	set := New(comparator.IntComparator, 1, 2, 3, 4)
+
+	indexes := []int{}
+	values := []int{}
+	for index, value := range set.All() {
+		if index == 2 {
+			break
+		}
+		indexes = append(indexes, index)
+		values = append(values, value)
+	}
+
+	assert.Equal([]int{0, 1}, indexes)
+	assert.Equal([]int{1, 2}, values)
+}
+
+func TestSetValues(t *testing.T) {
+	assert := assert.New(t)
+	set := New(comparator.IntComparator, 1, 2, 3, 4)
+
+	values := []int{}
+	for value := range set.Values() {
+		values = append(values, value)
+	}
+	assert.Equal([]int{1, 2, 3, 4}, values)
+
+	values = []int{}
+	for value := range set.Values() {
+		if value == 3 {
+			break
+		}
+		values = append(values, value)
+	}
+	assert.Equal([]int{1, 2}, values)
+}
@@ -0,0 +1,16 @@
+package treeset_test
+
+import (
+	"testing"
+
+	"github.com/dterbah/gods/collection/collectiontest"
+	"github.com/dterbah/gods/set"
+	"github.com/dterbah/gods/set/treeset"
+	comparator "github.com/dterbah/gods/utils"
+)
+
+func TestTreeSetConformance(t *testing.T) {
+	collectiontest.TestSet(t, func() set.BasicSet[int] {
+		return treeset.New(comparator.IntComparator)
+	})
+}
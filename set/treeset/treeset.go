@@ -0,0 +1,597 @@
+package treeset
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/dterbah/gods/collection"
+	"github.com/dterbah/gods/internal/format"
+	"github.com/dterbah/gods/internal/serialization"
+	"github.com/dterbah/gods/set"
+	"github.com/dterbah/gods/tree/avl"
+	comparator "github.com/dterbah/gods/utils"
+)
+
+// Bound of the range of a TreeSet view
+type bound[T any] struct {
+	value     T
+	inclusive bool
+}
+
+/*
+Struct that defines what a TreeSet is. The elements are stored in a
+self-balancing tree and are always kept sorted according to the comparator,
+so ForEach, ToArray and At return them in ascending order.
+Add, Contains, Remove, At and Size run in O(log n).
+The sets returned by HeadSet, SubSet and TailSet are views over a range of the same tree
+*/
+type TreeSet[T any] struct {
+	tree       *avl.AVLTree[T]
+	comparator comparator.Comparator[T]
+	// Bounds of the range of a view, nil when the range is not bounded on that side
+	from, to  *bound[T]
+	zeroValue T
+}
+
+/*
+Create a new TreeSet
+*/
+func New[T any](comparator comparator.Comparator[T], elements ...T) *TreeSet[T] {
+	set := &TreeSet[T]{tree: avl.New(comparator), comparator: comparator}
+	set.Add(elements...)
+	return set
+}
+
+/*
+Add elements in the TreeSet. If some elements are already
+present in the TreeSet, they won't be include a second time.
+A view ignores the elements out of its range
+*/
+func (set *TreeSet[T]) Add(elements ...T) {
+	for _, element := range elements {
+		if set.inRange(element) {
+			set.tree.Add(element)
+		}
+	}
+}
+
+/*
+Add all elements present in the collection
+*/
+func (set *TreeSet[T]) AddAll(elements collection.Collection[T]) {
+	for i := 0; i < elements.Size(); i++ {
+		element, _ := elements.At(i)
+		set.Add(element)
+	}
+}
+
+/*
+Return an iterator over the indexes and the elements of the set, in ascending order.
+The iteration stops as soon as the loop body breaks
+*/
+func (set *TreeSet[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		index := 0
+		for element := range set.Values() {
+			if !yield(index, element) {
+				return
+			}
+			index++
+		}
+	}
+}
+
+/*
+Retrieve an element by its position in ascending order
+If the index is negative or greater than the set size, the method will return an error
+*/
+func (set *TreeSet[T]) At(index int) (T, error) {
+	if index < 0 || index >= set.Size() {
+		return set.zeroValue, errors.New("index out of bound")
+	}
+
+	return set.tree.At(set.firstRank() + index)
+}
+
+/*
+Return the least element greater than or equal to the specified one.
+If there is no such element, the method will return an error
+*/
+func (set *TreeSet[T]) Ceiling(element T) (T, error) {
+	if set.belowRange(element) {
+		return set.First()
+	}
+
+	return set.bounded(set.tree.Ceiling(element))
+}
+
+/*
+Clear all the elements in the set. After a clear, the set is totally empty.
+Clearing a view removes the elements of its range from the backing set
+*/
+func (set *TreeSet[T]) Clear() {
+	if set.from == nil && set.to == nil {
+		set.tree.Clear()
+		return
+	}
+
+	for _, element := range set.ToArray() {
+		set.tree.Remove(element)
+	}
+}
+
+/*
+Return true if the set contains the element, else false
+*/
+func (set *TreeSet[T]) Contains(element T) bool {
+	return set.inRange(element) && set.tree.Has(element)
+}
+
+func (set *TreeSet[T]) ContainsAll(collection collection.Collection[T]) bool {
+	for index := 0; index < collection.Size(); index++ {
+		value, _ := collection.At(index)
+		if !set.Contains(value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+Create a copy of the current set. The copy of a view is a new set
+with the elements of its range, independent of the backing set
+*/
+func (set *TreeSet[T]) Copy() set.BasicSet[T] {
+	return set.filter(func(element T) bool { return true })
+}
+
+/*
+Create a new Set with all elements in the current set that
+are not present on the set passed in param
+*/
+func (set *TreeSet[T]) Diff(otherSet set.BasicSet[T]) set.BasicSet[T] {
+	return set.filter(func(element T) bool { return !otherSet.Contains(element) })
+}
+
+/*
+Return the lowest element of the set. If the set is empty, it returns an error
+*/
+func (set *TreeSet[T]) First() (T, error) {
+	if set.from == nil {
+		return set.bounded(set.tree.Min())
+	}
+
+	if set.from.inclusive {
+		return set.bounded(set.tree.Ceiling(set.from.value))
+	}
+
+	return set.bounded(set.tree.Higher(set.from.value))
+}
+
+/*
+Return the greatest element less than or equal to the specified one.
+If there is no such element, the method will return an error
+*/
+func (set *TreeSet[T]) Floor(element T) (T, error) {
+	if set.aboveRange(element) {
+		return set.Last()
+	}
+
+	return set.bounded(set.tree.Floor(element))
+}
+
+/*
+Apply a function for each element of the set, in ascending order
+*/
+func (set *TreeSet[T]) ForEach(callback func(element T, index int)) {
+	for index, element := range set.All() {
+		callback(element, index)
+	}
+}
+
+/*
+Format the set for the fmt package: %v writes its elements, %+v also writes
+its size, and %#v writes a Go-syntax representation.
+The other verbs are applied to each element
+*/
+func (set *TreeSet[T]) Format(state fmt.State, verb rune) {
+	format.Format(state, verb, set, set.describe())
+}
+
+/*
+Decode the set from the gob encoding, see UnmarshalBinary
+*/
+func (set *TreeSet[T]) GobDecode(data []byte) error {
+	return set.UnmarshalBinary(data)
+}
+
+/*
+Encode the set for gob, see MarshalBinary
+*/
+func (set *TreeSet[T]) GobEncode() ([]byte, error) {
+	return set.MarshalBinary()
+}
+
+/*
+Return a view of the elements less than the specified one (or equal to, if inclusive
+is true). The view is backed by the set, so the changes made through one of them are
+visible in the other one. The view of a view keeps the bounds of both
+*/
+func (set *TreeSet[T]) HeadSet(to T, inclusive bool) *TreeSet[T] {
+	return set.view(set.from, set.lowestTo(&bound[T]{value: to, inclusive: inclusive}))
+}
+
+/*
+Return the least element strictly greater than the specified one.
+If there is no such element, the method will return an error
+*/
+func (set *TreeSet[T]) Higher(element T) (T, error) {
+	if set.belowRange(element) {
+		return set.First()
+	}
+
+	return set.bounded(set.tree.Higher(element))
+}
+
+/*
+Return the position of the element in the set (if the element exists in the set)
+If the element is not present in the set, the method will return -1
+*/
+func (set *TreeSet[T]) IndexOf(element T) int {
+	if !set.Contains(element) {
+		return -1
+	}
+
+	return set.tree.Rank(element) - set.firstRank()
+}
+
+/*
+Compute the intersection between the current set and the one passed in parameter.
+The result is equivalent of A ∩ B
+*/
+func (set *TreeSet[T]) Intersection(otherSet set.BasicSet[T]) set.BasicSet[T] {
+	return set.filter(otherSet.Contains)
+}
+
+/*
+Check if the set is empty or not. Return true if it is empty, otherwise false
+*/
+func (set *TreeSet[T]) IsEmpty() bool {
+	return set.Size() == 0
+}
+
+/*
+Return true if the set passed in param is a subset of the current set, else false
+*/
+func (set *TreeSet[T]) IsSubset(otherSet set.BasicSet[T]) bool {
+	for i := 0; i < otherSet.Size(); i++ {
+		value, _ := otherSet.At(i)
+		if !set.Contains(value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+Return the greatest element of the set. If the set is empty, it returns an error
+*/
+func (set *TreeSet[T]) Last() (T, error) {
+	if set.to == nil {
+		return set.bounded(set.tree.Max())
+	}
+
+	if set.to.inclusive {
+		return set.bounded(set.tree.Floor(set.to.value))
+	}
+
+	return set.bounded(set.tree.Lower(set.to.value))
+}
+
+/*
+Return the greatest element strictly less than the specified one.
+If there is no such element, the method will return an error
+*/
+func (set *TreeSet[T]) Lower(element T) (T, error) {
+	if set.aboveRange(element) {
+		return set.Last()
+	}
+
+	return set.bounded(set.tree.Lower(element))
+}
+
+/*
+Encode the set in a binary format made of a versioned header
+followed by the gob encoding of its values
+*/
+func (set *TreeSet[T]) MarshalBinary() ([]byte, error) {
+	return serialization.Marshal(set.ToArray())
+}
+
+/*
+Encode the set as a JSON array of its elements, in ascending order
+*/
+func (set *TreeSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(set.ToArray())
+}
+
+func (set *TreeSet[T]) Print() {
+	fmt.Println(set.String())
+}
+
+/*
+Remove the element from the set if it exists.
+A view ignores the elements out of its range
+*/
+func (set *TreeSet[T]) Remove(element T) {
+	if set.inRange(element) {
+		set.tree.Remove(element)
+	}
+}
+
+/*
+Return the size of the set
+*/
+func (set *TreeSet[T]) Size() int {
+	return max(set.lastRank()-set.firstRank(), 0)
+}
+
+/*
+Return the elements of the set as a string, e.g. {1, 2, 3}
+*/
+func (set *TreeSet[T]) String() string {
+	return format.String(set.describe())
+}
+
+/*
+Return a view of the elements between from and to. The bounds are included according
+to fromInclusive and toInclusive. The view is backed by the set, so the changes made
+through one of them are visible in the other one. If from is greater than to,
+the view is always empty
+*/
+func (set *TreeSet[T]) SubSet(from T, fromInclusive bool, to T, toInclusive bool) *TreeSet[T] {
+	return set.view(
+		set.highestFrom(&bound[T]{value: from, inclusive: fromInclusive}),
+		set.lowestTo(&bound[T]{value: to, inclusive: toInclusive}),
+	)
+}
+
+/*
+Return a view of the elements greater than the specified one (or equal to, if inclusive
+is true). The view is backed by the set, so the changes made through one of them are
+visible in the other one
+*/
+func (set *TreeSet[T]) TailSet(from T, inclusive bool) *TreeSet[T] {
+	return set.view(set.highestFrom(&bound[T]{value: from, inclusive: inclusive}), set.to)
+}
+
+/*
+Return the elements of the set in ascending order
+*/
+func (set *TreeSet[T]) ToArray() []T {
+	elements := make([]T, 0, set.Size())
+	for element := range set.Values() {
+		elements = append(elements, element)
+	}
+
+	return elements
+}
+
+/*
+Compute the union between the current set and the one passed in parameter.
+The result is equivalent of A ∪ B
+*/
+func (set *TreeSet[T]) Union(otherSet set.BasicSet[T]) set.BasicSet[T] {
+	newSet := set.filter(func(element T) bool { return true })
+
+	otherSet.ForEach(func(element T, index int) {
+		newSet.Add(element)
+	})
+
+	return newSet
+}
+
+/*
+Decode the binary format written by MarshalBinary into the set, replacing its content.
+The set orders the elements with its own comparator, or with the one registered for
+the type of the elements if it has none. Decoding into a view replaces the elements
+of its range in the backing set
+*/
+func (set *TreeSet[T]) UnmarshalBinary(data []byte) error {
+	var elements []T
+	if err := serialization.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	return set.load(elements)
+}
+
+/*
+Decode a JSON array of elements into the set, replacing its content.
+The duplicated elements are ignored. The comparator of the set is kept. If the
+set has no comparator, the one registered for the type of the elements is used.
+Decoding into a view replaces the elements of its range in the backing set
+*/
+func (set *TreeSet[T]) UnmarshalJSON(data []byte) error {
+	var elements []T
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	return set.load(elements)
+}
+
+/*
+Return an iterator over the elements of the set, in ascending order. The iteration
+of a view starts from its lower bound in O(log n). The iteration stops as soon as
+the loop body breaks
+*/
+func (set *TreeSet[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		elements := set.tree.Values()
+		if set.from != nil {
+			elements = set.tree.ValuesFrom(set.from.value, set.from.inclusive)
+		}
+
+		for element := range elements {
+			if set.aboveRange(element) || !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+/*
+Write the elements of the set, as returned by String, to the writer
+*/
+func (set *TreeSet[T]) WriteTo(writer io.Writer) (int64, error) {
+	return format.WriteTo(writer, set.describe())
+}
+
+// Private methods
+
+// Return true if the element is greater than the upper bound of the range
+func (set *TreeSet[T]) aboveRange(element T) bool {
+	if set.to == nil {
+		return false
+	}
+
+	diff := set.comparator(element, set.to.value)
+	return diff > 0 || (diff == 0 && !set.to.inclusive)
+}
+
+// Return true if the element is less than the lower bound of the range
+func (set *TreeSet[T]) belowRange(element T) bool {
+	if set.from == nil {
+		return false
+	}
+
+	diff := set.comparator(element, set.from.value)
+	return diff < 0 || (diff == 0 && !set.from.inclusive)
+}
+
+// Return the element found in the tree if it is in the range, else an error
+func (set *TreeSet[T]) bounded(element T, err error) (T, error) {
+	if err != nil {
+		return set.zeroValue, err
+	}
+
+	if !set.inRange(element) {
+		return set.zeroValue, errors.New("no element in the range of the set")
+	}
+
+	return element, nil
+}
+
+// Describe the set for the format package
+func (set *TreeSet[T]) describe() format.Container[int, T] {
+	return format.Container[int, T]{
+		Name:  "TreeSet",
+		Open:  "{",
+		Close: "}",
+		All:   set.All(),
+		Size:  set.Size(),
+	}
+}
+
+// Create a new TreeSet with the elements matching the predicate
+func (set *TreeSet[T]) filter(predicate func(element T) bool) *TreeSet[T] {
+	newSet := New(set.comparator)
+
+	for element := range set.Values() {
+		if predicate(element) {
+			newSet.Add(element)
+		}
+	}
+
+	return newSet
+}
+
+// Return the number of elements of the tree below the range
+func (set *TreeSet[T]) firstRank() int {
+	if set.from == nil {
+		return 0
+	}
+
+	rank := set.tree.Rank(set.from.value)
+	if !set.from.inclusive && set.tree.Has(set.from.value) {
+		rank++
+	}
+
+	return rank
+}
+
+// Return the greatest of the lower bound of the range and the specified one
+func (set *TreeSet[T]) highestFrom(from *bound[T]) *bound[T] {
+	if set.from == nil {
+		return from
+	}
+
+	diff := set.comparator(from.value, set.from.value)
+	if diff > 0 || (diff == 0 && !from.inclusive) {
+		return from
+	}
+
+	return set.from
+}
+
+// Return true if the element is in the range of the set
+func (set *TreeSet[T]) inRange(element T) bool {
+	return !set.belowRange(element) && !set.aboveRange(element)
+}
+
+// Return the number of elements of the tree below or in the range
+func (set *TreeSet[T]) lastRank() int {
+	if set.to == nil {
+		return set.tree.Size()
+	}
+
+	rank := set.tree.Rank(set.to.value)
+	if set.to.inclusive && set.tree.Has(set.to.value) {
+		rank++
+	}
+
+	return rank
+}
+
+// Replace the content of the set by the values, resolving the comparator if needed.
+// A view keeps its tree and only replaces the elements of its range
+func (set *TreeSet[T]) load(elements []T) error {
+	if set.from != nil || set.to != nil {
+		set.Clear()
+		set.Add(elements...)
+		return nil
+	}
+
+	comparator, err := comparator.Resolve(set.comparator)
+	if err != nil {
+		return err
+	}
+
+	*set = *New(comparator, elements...)
+	return nil
+}
+
+// Return the least of the upper bound of the range and the specified one
+func (set *TreeSet[T]) lowestTo(to *bound[T]) *bound[T] {
+	if set.to == nil {
+		return to
+	}
+
+	diff := set.comparator(to.value, set.to.value)
+	if diff < 0 || (diff == 0 && !to.inclusive) {
+		return to
+	}
+
+	return set.to
+}
+
+// Create a view over the range of the tree between the bounds
+func (set *TreeSet[T]) view(from, to *bound[T]) *TreeSet[T] {
+	return &TreeSet[T]{tree: set.tree, comparator: set.comparator, from: from, to: to}
+}
@@ -0,0 +1,357 @@
+package treeset
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dterbah/gods/list/arraylist"
+	"github.com/dterbah/gods/set"
+	comparator "github.com/dterbah/gods/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTreeSetAdd(t *testing.T) {
+	assert := assert.New(t)
+	set := New(comparator.IntComparator)
+
+	set.Add(3, 1, 2)
+	set.Add(1)
+
+	assert.Equal(3, set.Size())
+	assert.Equal([]int{1, 2, 3}, set.ToArray())
+}
+
+func TestTreeSetAddAll(t *testing.T) {
+	assert := assert.New(t)
+	set := New(comparator.IntComparator)
+	set.AddAll(arraylist.New(comparator.IntComparator, 6, 5, 6))
+
+	assert.Equal([]int{5, 6}, set.ToArray())
+}
+
+func TestTreeSetAt(t *testing.T) {
+	assert := assert.New(t)
+	set := New(comparator.IntComparator, 4, 2, 1)
+
+	value, err := set.At(0)
+	assert.Nil(err)
+	assert.Equal(1, value)
+
+	value, err = set.At(2)
+	assert.Nil(err)
+	assert.Equal(4, value)
+
+	_, err = set.At(3)
+	assert.NotNil(err)
+}
+
+func TestTreeSetBinary(t *testing.T) {
+	assert := assert.New(t)
+	original := New(comparator.IntComparator, 3, 1, 2)
+
+	data, err := original.MarshalBinary()
+	assert.Nil(err)
+
+	var decoded TreeSet[int]
+	assert.Nil(decoded.UnmarshalBinary(data))
+	assert.Equal([]int{1, 2, 3}, decoded.ToArray())
+
+	// Containers can be embedded in gob encoded structs
+	var buffer bytes.Buffer
+	assert.Nil(gob.NewEncoder(&buffer).Encode(struct{ Container *TreeSet[int] }{original}))
+
+	var checkpoint struct{ Container *TreeSet[int] }
+	assert.Nil(gob.NewDecoder(&buffer).Decode(&checkpoint))
+	decoded = *checkpoint.Container
+	assert.Equal([]int{1, 2, 3}, decoded.ToArray())
+
+	assert.NotNil(decoded.UnmarshalBinary([]byte("invalid")))
+}
+
+func TestTreeSetClear(t *testing.T) {
+	assert := assert.New(t)
+	set := New(comparator.IntComparator, 1, 3, 4)
+	set.Clear()
+
+	assert.True(set.IsEmpty())
+}
+
+func TestTreeSetContains(t *testing.T) {
+	assert := assert.New(t)
+	set := New(comparator.StringComparator, "b", "a")
+
+	assert.True(set.Contains("a"))
+	assert.False(set.Contains("c"))
+	assert.True(set.ContainsAll(New(comparator.StringComparator, "a")))
+	assert.False(set.ContainsAll(New(comparator.StringComparator, "a", "c")))
+}
+
+func TestTreeSetCopy(t *testing.T) {
+	assert := assert.New(t)
+	set := New(comparator.IntComparator, 1, 3)
+	copy := set.Copy()
+
+	copy.Add(2)
+	assert.Equal([]int{1, 2, 3}, copy.ToArray())
+	assert.Equal([]int{1, 3}, set.ToArray())
+}
+
+func TestTreeSetFirstLast(t *testing.T) {
+	assert := assert.New(t)
+	set := New(comparator.IntComparator)
+
+	_, err := set.First()
+	assert.NotNil(err)
+	_, err = set.Last()
+	assert.NotNil(err)
+
+	set.Add(5, -2, 9)
+	first, _ := set.First()
+	last, _ := set.Last()
+	assert.Equal(-2, first)
+	assert.Equal(9, last)
+}
+
+func TestTreeSetForEach(t *testing.T) {
+	assert := assert.New(t)
+	set := New(comparator.IntComparator, 3, 1, 2)
+
+	set.ForEach(func(element, index int) {
+		assert.Equal(index+1, element)
+	})
+}
+
+func TestTreeSetHeadSet(t *testing.T) {
+	assert := assert.New(t)
+	set := New(comparator.IntComparator, 1, 2, 3, 4, 5)
+
+	assert.Equal([]int{1, 2}, set.HeadSet(3, false).ToArray())
+	assert.Equal([]int{1, 2, 3}, set.HeadSet(3, true).ToArray())
+	assert.True(set.HeadSet(0, true).IsEmpty())
+}
+
+func TestTreeSetImplementsBasicSet(t *testing.T) {
+	var basicSet set.BasicSet[int] = New(comparator.IntComparator, 1)
+	assert.True(t, basicSet.Contains(1))
+}
+
+func TestTreeSetIndexOf(t *testing.T) {
+	assert := assert.New(t)
+	set := New(comparator.IntComparator, 30, 10, 20)
+
+	assert.Equal(0, set.IndexOf(10))
+	assert.Equal(2, set.IndexOf(30))
+	assert.Equal(-1, set.IndexOf(15))
+}
+
+func TestTreeSetJSON(t *testing.T) {
+	assert := assert.New(t)
+	set := New(comparator.IntComparator, 3, 1, 2)
+
+	data, err := json.Marshal(set)
+	assert.Nil(err)
+	assert.Equal("[1,2,3]", string(data))
+
+	var decoded TreeSet[int]
+	assert.Nil(json.Unmarshal([]byte("[3,3,1]"), &decoded))
+	assert.Equal([]int{1, 3}, decoded.ToArray())
+}
+
+func TestTreeSetNavigation(t *testing.T) {
+	assert := assert.New(t)
+	set := New(comparator.IntComparator, 10, 20, 30)
+
+	value, _ := set.Floor(15)
+	assert.Equal(10, value)
+	value, _ = set.Ceiling(15)
+	assert.Equal(20, value)
+	value, _ = set.Lower(20)
+	assert.Equal(10, value)
+	value, _ = set.Higher(20)
+	assert.Equal(30, value)
+
+	_, err := set.Higher(30)
+	assert.NotNil(err)
+}
+
+func TestTreeSetOperations(t *testing.T) {
+	assert := assert.New(t)
+	set := New(comparator.IntComparator, 1, 2, 3, 6, 9)
+	otherSet := New(comparator.IntComparator, 1, 2, 5, 6)
+
+	assert.Equal([]int{3, 9}, set.Diff(otherSet).ToArray())
+	assert.Equal([]int{1, 2, 6}, set.Intersection(otherSet).ToArray())
+	assert.Equal([]int{1, 2, 3, 5, 6, 9}, set.Union(otherSet).ToArray())
+	assert.False(set.IsSubset(otherSet))
+	assert.True(set.IsSubset(New(comparator.IntComparator, 9, 1)))
+}
+
+func TestTreeSetPrint(t *testing.T) {
+	set := New(comparator.IntComparator, 1, 2, 3)
+	set.Print()
+}
+
+func TestTreeSetRemove(t *testing.T) {
+	assert := assert.New(t)
+	set := New(comparator.IntComparator, 1, 2, 3)
+
+	set.Remove(2)
+	set.Remove(10)
+
+	assert.Equal([]int{1, 3}, set.ToArray())
+}
+
+func TestTreeSetString(t *testing.T) {
+	assert := assert.New(t)
+	set := New(comparator.IntComparator, 2, 1)
+
+	assert.Equal("{1, 2}", set.String())
+	assert.Equal("{1, 2}", fmt.Sprint(set))
+	assert.Equal("TreeSet(size=2) {1, 2}", fmt.Sprintf("%+v", set))
+	assert.Equal(`&treeset.TreeSet[int]{1, 2}`, fmt.Sprintf("%#v", set))
+	assert.Equal("{01, 02}", fmt.Sprintf("%02d", set))
+
+	var builder strings.Builder
+	count, err := set.WriteTo(&builder)
+	assert.Nil(err)
+	assert.Equal(int64(len("{1, 2}")), count)
+	assert.Equal("{1, 2}", builder.String())
+}
+
+func TestTreeSetSubSet(t *testing.T) {
+	assert := assert.New(t)
+	set := New(comparator.IntComparator, 1, 2, 3, 4, 5)
+
+	assert.Equal([]int{2, 3, 4}, set.SubSet(2, true, 4, true).ToArray())
+	assert.Equal([]int{3}, set.SubSet(2, false, 4, false).ToArray())
+	assert.True(set.SubSet(4, true, 2, true).IsEmpty())
+}
+
+func TestTreeSetSubSetSeeksLowerBound(t *testing.T) {
+	assert := assert.New(t)
+	comparisons := 0
+	counting := func(a, b int) int {
+		comparisons++
+		return comparator.IntComparator(a, b)
+	}
+	set := New(counting)
+	for element := range 1024 {
+		set.Add(element)
+	}
+
+	comparisons = 0
+	assert.Equal([]int{1000, 1001, 1002}, set.SubSet(1000, true, 1002, true).ToArray())
+	// The elements lower than 1000 are not scanned
+	assert.Less(comparisons, 100)
+
+	comparisons = 0
+	assert.Equal(23, set.TailSet(1000, false).Size())
+	assert.Less(comparisons, 200)
+}
+
+func TestTreeSetSubSetIsView(t *testing.T) {
+	assert := assert.New(t)
+	set := New(comparator.IntComparator, 1, 2, 3, 4, 5)
+
+	head := set.HeadSet(3, true)
+	tail := set.TailSet(3, true)
+	sub := set.SubSet(2, true, 4, true)
+	head.Add(0)
+	tail.Remove(5)
+	sub.Remove(3)
+	set.Add(6)
+
+	assert.Equal([]int{0, 1, 2, 4, 6}, set.ToArray())
+	assert.Equal([]int{0, 1, 2}, head.ToArray())
+	assert.Equal([]int{4, 6}, tail.ToArray())
+	assert.Equal([]int{2, 4}, sub.ToArray())
+
+	// The elements out of the range are ignored
+	sub.Add(10)
+	sub.Remove(0)
+	assert.False(set.Contains(10))
+	assert.True(set.Contains(0))
+	assert.False(sub.Contains(6))
+
+	// A copy of a view is independent of the set
+	copied := sub.Copy()
+	copied.Add(10)
+	assert.Equal([]int{2, 4}, sub.ToArray())
+
+	sub.Clear()
+	assert.True(sub.IsEmpty())
+	assert.Equal([]int{0, 1, 6}, set.ToArray())
+}
+
+func TestTreeSetSubSetNavigation(t *testing.T) {
+	assert := assert.New(t)
+	set := New(comparator.IntComparator, 10, 20, 30, 40, 50)
+	sub := set.SubSet(20, false, 50, false)
+
+	assert.Equal(2, sub.Size())
+	first, err := sub.First()
+	assert.Nil(err)
+	assert.Equal(30, first)
+	last, err := sub.Last()
+	assert.Nil(err)
+	assert.Equal(40, last)
+
+	element, err := sub.At(1)
+	assert.Nil(err)
+	assert.Equal(40, element)
+	_, err = sub.At(2)
+	assert.NotNil(err)
+	assert.Equal(0, sub.IndexOf(30))
+	assert.Equal(-1, sub.IndexOf(20))
+
+	element, _ = sub.Ceiling(0)
+	assert.Equal(30, element)
+	element, _ = sub.Floor(100)
+	assert.Equal(40, element)
+	_, err = sub.Higher(40)
+	assert.NotNil(err)
+	_, err = sub.Lower(30)
+	assert.NotNil(err)
+
+	// The view of a view keeps the tightest bounds
+	assert.Equal([]int{30}, sub.HeadSet(45, true).HeadSet(40, false).ToArray())
+	assert.Equal([]int{30, 40}, sub.TailSet(0, true).ToArray())
+	assert.True(set.SubSet(40, true, 20, true).IsEmpty())
+
+	assert.Nil(json.Unmarshal([]byte("[35, 60]"), sub))
+	assert.Equal([]int{10, 20, 35, 50}, set.ToArray())
+	assert.Equal("{35}", sub.String())
+}
+
+func TestTreeSetTailSet(t *testing.T) {
+	assert := assert.New(t)
+	set := New(comparator.IntComparator, 1, 2, 3, 4, 5)
+
+	assert.Equal([]int{4, 5}, set.TailSet(3, false).ToArray())
+	assert.Equal([]int{3, 4, 5}, set.TailSet(3, true).ToArray())
+}
+
+func TestTreeSetValues(t *testing.T) {
+	assert := assert.New(t)
+	set := New(comparator.IntComparator, 4, 3, 2, 1)
+
+	values := []int{}
+	for value := range set.Values() {
+		if value == 3 {
+			break
+		}
+		values = append(values, value)
+	}
+
+	assert.Equal([]int{1, 2}, values)
+
+	indexes := []int{}
+	for index := range set.All() {
+		indexes = append(indexes, index)
+	}
+	assert.Equal([]int{0, 1, 2, 3}, indexes)
+}
@@ -1,9 +1,14 @@
 package stack
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"iter"
 
+	"github.com/dterbah/gods/internal/format"
+	"github.com/dterbah/gods/internal/serialization"
 	"github.com/dterbah/gods/iterable"
 	comparator "github.com/dterbah/gods/utils"
 )
@@ -28,6 +33,34 @@ func New[T any](comparator comparator.Comparator[T]) *Stack[T] {
 	return &Stack[T]{zeroElement: zero, comparator: comparator}
 }
 
+/*
+Create a new Stack comparing its elements with the == operator
+*/
+func NewComparable[T comparable]() *Stack[T] {
+	return NewWithEqualer(comparator.Equal[T])
+}
+
+/*
+Create a new Stack comparing its elements with the equaler, for the types with no ordering
+*/
+func NewWithEqualer[T any](equaler comparator.Equaler[T]) *Stack[T] {
+	return New(comparator.FromEqualer(equaler))
+}
+
+/*
+Return an iterator over the indexes and the elements of the stack.
+The iteration stops as soon as the loop body breaks
+*/
+func (stack *Stack[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for index, element := range stack.elements[:stack.size] {
+			if !yield(index, element) {
+				return
+			}
+		}
+	}
+}
+
 /*
 Clear all the elements in the Stack
 */
@@ -82,6 +115,29 @@ func FromIterable[T any](iterable iterable.Iterable[T], comparator comparator.Co
 	return stack
 }
 
+/*
+Format the stack for the fmt package: %v writes its elements, %+v also writes
+its size, and %#v writes a Go-syntax representation.
+The other verbs are applied to each element
+*/
+func (stack *Stack[T]) Format(state fmt.State, verb rune) {
+	format.Format(state, verb, stack, stack.describe())
+}
+
+/*
+Decode the stack from the gob encoding, see UnmarshalBinary
+*/
+func (stack *Stack[T]) GobDecode(data []byte) error {
+	return stack.UnmarshalBinary(data)
+}
+
+/*
+Encode the stack for gob, see MarshalBinary
+*/
+func (stack *Stack[T]) GobEncode() ([]byte, error) {
+	return stack.MarshalBinary()
+}
+
 /*
 Return true if the stack is empty, else false
 */
@@ -89,6 +145,25 @@ func (stack Stack[T]) IsEmpty() bool {
 	return stack.size == 0
 }
 
+/*
+Encode the stack in a binary format made of a versioned header
+followed by the gob encoding of its elements
+*/
+func (stack *Stack[T]) MarshalBinary() ([]byte, error) {
+	elements := make([]T, stack.size)
+	copy(elements, stack.elements)
+	return serialization.Marshal(elements)
+}
+
+/*
+Encode the stack as a JSON array of its elements, from the bottom to the top
+*/
+func (stack *Stack[T]) MarshalJSON() ([]byte, error) {
+	elements := make([]T, stack.size)
+	copy(elements, stack.elements)
+	return json.Marshal(elements)
+}
+
 /*
 Return the last element of the stack without removing it. If the
 stack is empty, this method will return an error
@@ -116,16 +191,7 @@ func (stack *Stack[T]) Pop() (T, error) {
 }
 
 func (stack Stack[T]) Print() {
-	fmt.Print("[")
-
-	stack.ForEach(func(element T, index int) {
-		fmt.Print(element)
-		if index < stack.size-1 {
-			fmt.Print(", ")
-		}
-	})
-
-	fmt.Println("]")
+	fmt.Println(stack.String())
 }
 
 /*
@@ -147,7 +213,75 @@ func (stack Stack[T]) Size() int {
 	return stack.size
 }
 
+/*
+Return the elements of the stack as a string, e.g. [1, 2, 3] from the bottom to the top
+*/
+func (stack *Stack[T]) String() string {
+	return format.String(stack.describe())
+}
+
+/*
+Decode the binary format written by MarshalBinary into the stack, replacing its content.
+The top of the encoded stack stays on top. The comparator of the stack is kept,
+or the one registered for the type of the elements is used
+*/
+func (stack *Stack[T]) UnmarshalBinary(data []byte) error {
+	var elements []T
+	if err := serialization.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	return stack.load(elements)
+}
+
+/*
+Decode a JSON array of elements, from the bottom to the top, into the stack,
+replacing its content. The comparator of the stack is kept. If the stack has no
+comparator, the one registered for the type of the elements is used
+*/
+func (stack *Stack[T]) UnmarshalJSON(data []byte) error {
+	var elements []T
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	return stack.load(elements)
+}
+
+/*
+Return an iterator over the elements of the stack.
+The iteration stops as soon as the loop body breaks
+*/
+func (stack *Stack[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, element := range stack.elements[:stack.size] {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+/*
+Write the elements of the stack, as returned by String, to the writer
+*/
+func (stack *Stack[T]) WriteTo(writer io.Writer) (int64, error) {
+	return format.WriteTo(writer, stack.describe())
+}
+
 // Private methods //
+
+// Describe the stack for the format package
+func (stack *Stack[T]) describe() format.Container[int, T] {
+	return format.Container[int, T]{
+		Name:  "Stack",
+		Open:  "[",
+		Close: "]",
+		All:   stack.All(),
+		Size:  stack.size,
+	}
+}
+
 func (stack *Stack[T]) growIfNeeded(n int) {
 	currentCapacity := cap(stack.elements)
 	if currentCapacity <= stack.size+n {
@@ -158,6 +292,18 @@ func (stack *Stack[T]) growIfNeeded(n int) {
 	}
 }
 
+// Replace the content of the stack by the elements, resolving the comparator if needed
+func (stack *Stack[T]) load(elements []T) error {
+	comparator, err := comparator.Resolve(stack.comparator)
+	if err != nil {
+		return err
+	}
+
+	*stack = *New(comparator)
+	stack.Push(elements...)
+	return nil
+}
+
 func (stack *Stack[T]) shiftElements() {
 	stack.size--
 }
@@ -1,6 +1,12 @@
 package stack
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/dterbah/gods/list/arraylist"
@@ -8,6 +14,30 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func TestStackBinary(t *testing.T) {
+	assert := assert.New(t)
+	original := New(comparator.IntComparator)
+	original.Push(1, 2, 3)
+
+	data, err := original.MarshalBinary()
+	assert.Nil(err)
+
+	var decoded Stack[int]
+	assert.Nil(decoded.UnmarshalBinary(data))
+	assert.Equal([]int{1, 2, 3}, slices.Collect(decoded.Values()))
+
+	// Containers can be embedded in gob encoded structs
+	var buffer bytes.Buffer
+	assert.Nil(gob.NewEncoder(&buffer).Encode(struct{ Container *Stack[int] }{original}))
+
+	var checkpoint struct{ Container *Stack[int] }
+	assert.Nil(gob.NewDecoder(&buffer).Decode(&checkpoint))
+	decoded = *checkpoint.Container
+	assert.Equal([]int{1, 2, 3}, slices.Collect(decoded.Values()))
+
+	assert.NotNil(decoded.UnmarshalBinary([]byte("invalid")))
+}
+
 func TestStackClear(t *testing.T) {
 	assert := assert.New(t)
 	stack := New(comparator.IntComparator)
@@ -47,6 +77,45 @@ func TestStackFromIterable(t *testing.T) {
 	}
 }
 
+func TestStackJSON(t *testing.T) {
+	assert := assert.New(t)
+	stack := New(comparator.IntComparator)
+	stack.Push(1, 2, 3)
+
+	data, err := json.Marshal(stack)
+	assert.Nil(err)
+	assert.Equal("[1,2,3]", string(data))
+
+	data, err = json.Marshal(New(comparator.IntComparator))
+	assert.Nil(err)
+	assert.Equal("[]", string(data))
+
+	var decoded Stack[int]
+	assert.Nil(json.Unmarshal([]byte("[1,2,3]"), &decoded))
+	element, _ := decoded.Pop()
+	assert.Equal(3, element)
+	assert.True(decoded.Contains(1))
+}
+
+func TestStackNewComparable(t *testing.T) {
+	assert := assert.New(t)
+	type point struct{ x, y int }
+	stack := NewComparable[point]()
+	stack.Push(point{1, 2}, point{3, 4})
+
+	assert.True(stack.Contains(point{3, 4}))
+	assert.False(stack.Contains(point{4, 3}))
+}
+
+func TestStackNewWithEqualer(t *testing.T) {
+	assert := assert.New(t)
+	stack := NewWithEqualer(slices.Equal[[]int])
+	stack.Push([]int{1, 2})
+
+	assert.True(stack.Contains([]int{1, 2}))
+	assert.False(stack.Contains([]int{2, 1}))
+}
+
 func TestStackPush(t *testing.T) {
 	assert := assert.New(t)
 	stack := New(comparator.IntComparator)
@@ -139,3 +208,60 @@ func TestStackSize(t *testing.T) {
 	stack.Push(1, 2)
 	assert.Equal(2, stack.Size())
 }
+
+func TestStackAll(t *testing.T) {
+	assert := assert.New(t)
+	stack := New(comparator.IntComparator)
+	stack.Push(1, 2, 3, 4)
+
+	indexes := []int{}
+	values := []int{}
+	for index, value := range stack.All() {
+		if index == 2 {
+			break
+		}
+		indexes = append(indexes, index)
+		values = append(values, value)
+	}
+
+	assert.Equal([]int{0, 1}, indexes)
+	assert.Equal([]int{1, 2}, values)
+}
+
+func TestStackString(t *testing.T) {
+	assert := assert.New(t)
+	stack := New(comparator.IntComparator)
+	stack.Push(1, 2)
+
+	assert.Equal("[1, 2]", stack.String())
+	assert.Equal("[1, 2]", fmt.Sprint(stack))
+	assert.Equal("Stack(size=2) [1, 2]", fmt.Sprintf("%+v", stack))
+	assert.Equal(`&stack.Stack[int]{1, 2}`, fmt.Sprintf("%#v", stack))
+
+	var builder strings.Builder
+	count, err := stack.WriteTo(&builder)
+	assert.Nil(err)
+	assert.Equal(int64(len("[1, 2]")), count)
+	assert.Equal("[1, 2]", builder.String())
+}
+
+func TestStackValues(t *testing.T) {
+	assert := assert.New(t)
+	stack := New(comparator.IntComparator)
+	stack.Push(1, 2, 3, 4)
+
+	values := []int{}
+	for value := range stack.Values() {
+		values = append(values, value)
+	}
+	assert.Equal([]int{1, 2, 3, 4}, values)
+
+	values = []int{}
+	for value := range stack.Values() {
+		if value == 3 {
+			break
+		}
+		values = append(values, value)
+	}
+	assert.Equal([]int{1, 2}, values)
+}
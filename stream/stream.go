@@ -0,0 +1,297 @@
+package stream
+
+import (
+	"errors"
+	"iter"
+	"slices"
+
+	"github.com/dterbah/gods/collection"
+	"github.com/dterbah/gods/set"
+	"github.com/dterbah/gods/set/treeset"
+	comparator "github.com/dterbah/gods/utils"
+)
+
+/*
+Interface of the sources of a stream. It is implemented by all the
+iterables and by the containers exposing a Values iterator
+*/
+type Source[T any] interface {
+	/*
+		Return an iterator over the values of the source
+	*/
+	Values() iter.Seq[T]
+}
+
+/*
+Struct that represents a lazy pipeline of operations over a source.
+The intermediate operations only wrap the iterator of the previous step:
+nothing is evaluated until a terminal operation pulls the elements, and
+the evaluation stops as soon as the terminal operation has its result
+*/
+type Stream[T any] struct {
+	values iter.Seq[T]
+}
+
+/*
+Create a stream over the values of a source
+*/
+func From[T any](source Source[T]) *Stream[T] {
+	return FromSeq(source.Values())
+}
+
+/*
+Create a stream over an iterator
+*/
+func FromSeq[T any](values iter.Seq[T]) *Stream[T] {
+	return &Stream[T]{values: values}
+}
+
+/*
+Create a stream over the elements in parameter
+*/
+func Of[T any](elements ...T) *Stream[T] {
+	return FromSeq(slices.Values(elements))
+}
+
+/*
+Add the elements of the stream to a new collection created by the constructor,
+e.g. Collect(stream, arraylist.New, comparator.IntComparator)
+*/
+func Collect[T any, C collection.Collection[T]](stream *Stream[T],
+	constructor func(comparator comparator.Comparator[T], elements ...T) C,
+	comparator comparator.Comparator[T]) C {
+	return constructor(comparator, stream.ToArray()...)
+}
+
+/*
+Return a stream with the result of the mapper for each element of the stream.
+Use the Map method when the type of the elements does not change
+*/
+func Map[T, U any](stream *Stream[T], mapper func(element T) U) *Stream[U] {
+	return FromSeq(func(yield func(U) bool) {
+		for element := range stream.values {
+			if !yield(mapper(element)) {
+				return
+			}
+		}
+	})
+}
+
+/*
+Return true if all the elements of the stream match the predicate.
+It stops at the first element that does not match
+*/
+func (stream *Stream[T]) AllMatch(predicate func(element T) bool) bool {
+	for element := range stream.values {
+		if !predicate(element) {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+Return true if at least one element of the stream matches the predicate.
+It stops at the first element that matches
+*/
+func (stream *Stream[T]) AnyMatch(predicate func(element T) bool) bool {
+	for element := range stream.values {
+		if predicate(element) {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+Return the number of elements of the stream
+*/
+func (stream *Stream[T]) Count() int {
+	count := 0
+	for range stream.values {
+		count++
+	}
+
+	return count
+}
+
+/*
+Return a stream without the duplicated elements, keeping the first occurrence of
+each one. The elements already seen are stored in a TreeSet using the comparator
+*/
+func (stream *Stream[T]) Distinct(comparator comparator.Comparator[T]) *Stream[T] {
+	return FromSeq(func(yield func(T) bool) {
+		seen := treeset.New(comparator)
+		for element := range stream.values {
+			if seen.Contains(element) {
+				continue
+			}
+			seen.Add(element)
+			if !yield(element) {
+				return
+			}
+		}
+	})
+}
+
+/*
+Return a stream with the elements matching the predicate
+*/
+func (stream *Stream[T]) Filter(predicate func(element T) bool) *Stream[T] {
+	return FromSeq(func(yield func(T) bool) {
+		for element := range stream.values {
+			if predicate(element) && !yield(element) {
+				return
+			}
+		}
+	})
+}
+
+/*
+Return the first element of the stream. If the stream is empty, this method will return an error
+*/
+func (stream *Stream[T]) First() (T, error) {
+	for element := range stream.values {
+		return element, nil
+	}
+
+	var zero T
+	return zero, errors.New("empty stream")
+}
+
+/*
+Call a function for each element of the stream
+*/
+func (stream *Stream[T]) ForEach(callback func(element T)) {
+	for element := range stream.values {
+		callback(element)
+	}
+}
+
+/*
+Return a stream with at most the n first elements
+*/
+func (stream *Stream[T]) Limit(n int) *Stream[T] {
+	return FromSeq(func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+
+		count := 0
+		for element := range stream.values {
+			if !yield(element) {
+				return
+			}
+			count++
+			if count == n {
+				return
+			}
+		}
+	})
+}
+
+/*
+Return a stream with the result of the mapper for each element of the stream.
+Use the Map function to change the type of the elements
+*/
+func (stream *Stream[T]) Map(mapper func(element T) T) *Stream[T] {
+	return Map(stream, mapper)
+}
+
+/*
+Return the greatest element of the stream according to the comparator.
+If the stream is empty, this method will return an error
+*/
+func (stream *Stream[T]) Max(comparator comparator.Comparator[T]) (T, error) {
+	return stream.Min(func(a, b T) int {
+		return comparator(b, a)
+	})
+}
+
+/*
+Return the smallest element of the stream according to the comparator.
+If the stream is empty, this method will return an error
+*/
+func (stream *Stream[T]) Min(comparator comparator.Comparator[T]) (T, error) {
+	var result T
+	found := false
+
+	for element := range stream.values {
+		if !found || comparator(element, result) < 0 {
+			result = element
+			found = true
+		}
+	}
+
+	if !found {
+		return result, errors.New("empty stream")
+	}
+
+	return result, nil
+}
+
+/*
+Return true if no element of the stream matches the predicate.
+It stops at the first element that matches
+*/
+func (stream *Stream[T]) NoneMatch(predicate func(element T) bool) bool {
+	return !stream.AnyMatch(predicate)
+}
+
+/*
+Return a stream without the n first elements
+*/
+func (stream *Stream[T]) Skip(n int) *Stream[T] {
+	return FromSeq(func(yield func(T) bool) {
+		skipped := 0
+		for element := range stream.values {
+			if skipped < n {
+				skipped++
+				continue
+			}
+			if !yield(element) {
+				return
+			}
+		}
+	})
+}
+
+/*
+Return a stream with the elements sorted according to the comparator.
+The sort is stable, and it needs to read all the elements before yielding the first one
+*/
+func (stream *Stream[T]) Sorted(comparator comparator.Comparator[T]) *Stream[T] {
+	return FromSeq(func(yield func(T) bool) {
+		elements := stream.ToArray()
+		slices.SortStableFunc(elements, comparator)
+		for _, element := range elements {
+			if !yield(element) {
+				return
+			}
+		}
+	})
+}
+
+/*
+Return the elements of the stream in a slice
+*/
+func (stream *Stream[T]) ToArray() []T {
+	return slices.Collect(stream.values)
+}
+
+/*
+Return the elements of the stream in a TreeSet using the comparator
+*/
+func (stream *Stream[T]) ToSet(comparator comparator.Comparator[T]) set.BasicSet[T] {
+	return treeset.New(comparator, stream.ToArray()...)
+}
+
+/*
+Return an iterator over the elements of the stream, which can be used with a
+range-over-func loop. Breaking the loop stops the evaluation of the pipeline
+*/
+func (stream *Stream[T]) Values() iter.Seq[T] {
+	return stream.values
+}
@@ -0,0 +1,215 @@
+package stream
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/dterbah/gods/list/arraylist"
+	"github.com/dterbah/gods/list/linkedlist"
+	"github.com/dterbah/gods/stack"
+	comparator "github.com/dterbah/gods/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// Return a stream over the integers from 0 and a pointer to the number of pulled elements
+func counting() (*Stream[int], *int) {
+	pulled := 0
+	return FromSeq(func(yield func(int) bool) {
+		for element := 0; ; element++ {
+			pulled++
+			if !yield(element) {
+				return
+			}
+		}
+	}), &pulled
+}
+
+func isEven(element int) bool {
+	return element%2 == 0
+}
+
+func TestStreamAllMatch(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(Of(2, 4, 6).AllMatch(isEven))
+	assert.True(Of[int]().AllMatch(isEven))
+
+	stream, pulled := counting()
+	assert.False(stream.AllMatch(func(element int) bool { return element < 3 }))
+	assert.Equal(4, *pulled)
+}
+
+func TestStreamAnyMatch(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.False(Of(1, 3).AnyMatch(isEven))
+	assert.False(Of[int]().AnyMatch(isEven))
+
+	stream, pulled := counting()
+	assert.True(stream.AnyMatch(func(element int) bool { return element == 5 }))
+	assert.Equal(6, *pulled)
+}
+
+func TestStreamCollect(t *testing.T) {
+	assert := assert.New(t)
+	source := arraylist.New(comparator.IntComparator, 5, 1, 4, 2)
+
+	list := Collect(From(source).Filter(isEven), linkedlist.New, comparator.IntComparator)
+	assert.Equal([]int{4, 2}, list.ToArray())
+	assert.True(list.Contains(2))
+}
+
+func TestStreamCount(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(0, Of[int]().Count())
+	assert.Equal(2, Of(1, 2, 3, 4).Filter(isEven).Count())
+}
+
+func TestStreamDistinct(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal([]int{3, 1, 2}, Of(3, 1, 3, 2, 1).Distinct(comparator.IntComparator).ToArray())
+
+	stream, pulled := counting()
+	result := stream.Map(func(element int) int { return element / 3 }).Distinct(comparator.IntComparator).Limit(2)
+	assert.Equal([]int{0, 1}, result.ToArray())
+	assert.Equal(4, *pulled)
+}
+
+func TestStreamFilter(t *testing.T) {
+	assert := assert.New(t)
+
+	stream, pulled := counting()
+	result := stream.Filter(isEven)
+	assert.Equal(0, *pulled)
+
+	assert.Equal([]int{0, 2, 4}, result.Limit(3).ToArray())
+	assert.Equal(5, *pulled)
+}
+
+func TestStreamFirst(t *testing.T) {
+	assert := assert.New(t)
+
+	element, err := Of(1, 2, 3).Skip(1).First()
+	assert.Nil(err)
+	assert.Equal(2, element)
+
+	stream, pulled := counting()
+	element, err = stream.First()
+	assert.Nil(err)
+	assert.Equal(0, element)
+	assert.Equal(1, *pulled)
+
+	_, err = Of[int]().First()
+	assert.NotNil(err)
+}
+
+func TestStreamForEach(t *testing.T) {
+	assert := assert.New(t)
+	elements := []int{}
+
+	Of(1, 2, 3).ForEach(func(element int) {
+		elements = append(elements, element)
+	})
+	assert.Equal([]int{1, 2, 3}, elements)
+}
+
+func TestStreamFrom(t *testing.T) {
+	assert := assert.New(t)
+	source := stack.New(comparator.IntComparator)
+	source.Push(1, 2, 3)
+
+	assert.Equal([]int{1, 2, 3}, From(source).ToArray())
+}
+
+func TestStreamLimit(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal([]int{1, 2}, Of(1, 2, 3).Limit(2).ToArray())
+	assert.Equal([]int{1, 2, 3}, Of(1, 2, 3).Limit(5).ToArray())
+	assert.Empty(Of(1, 2, 3).Limit(0).ToArray())
+}
+
+func TestStreamMap(t *testing.T) {
+	assert := assert.New(t)
+
+	labels := Map(Of(1, 2, 3), func(element int) string {
+		return strconv.Itoa(element * 10)
+	})
+	assert.Equal([]string{"10", "20", "30"}, labels.ToArray())
+	assert.Equal([]int{2, 4}, Of(1, 2).Map(func(element int) int { return element * 2 }).ToArray())
+}
+
+func TestStreamMax(t *testing.T) {
+	assert := assert.New(t)
+
+	element, err := Of(3, 7, 1).Max(comparator.IntComparator)
+	assert.Nil(err)
+	assert.Equal(7, element)
+
+	_, err = Of[int]().Max(comparator.IntComparator)
+	assert.NotNil(err)
+}
+
+func TestStreamMin(t *testing.T) {
+	assert := assert.New(t)
+
+	element, err := Of("b", "a", "c").Min(comparator.StringComparator)
+	assert.Nil(err)
+	assert.Equal("a", element)
+
+	_, err = Of[string]().Min(comparator.StringComparator)
+	assert.NotNil(err)
+}
+
+func TestStreamNoneMatch(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(Of(1, 3).NoneMatch(isEven))
+	assert.False(Of(1, 2).NoneMatch(isEven))
+}
+
+func TestStreamSkip(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal([]int{3, 4}, Of(1, 2, 3, 4).Skip(2).ToArray())
+	assert.Empty(Of(1, 2).Skip(3).ToArray())
+
+	stream, pulled := counting()
+	assert.Equal([]int{10, 11}, stream.Skip(10).Limit(2).ToArray())
+	assert.Equal(12, *pulled)
+}
+
+func TestStreamSorted(t *testing.T) {
+	assert := assert.New(t)
+	source := arraylist.New(comparator.IntComparator, 5, 1, 4, 2, 3)
+
+	result := From(source).Sorted(comparator.IntComparator).Limit(3).ToArray()
+	assert.Equal([]int{1, 2, 3}, result)
+	assert.Equal([]int{5, 1, 4, 2, 3}, source.ToArray())
+}
+
+func TestStreamToSet(t *testing.T) {
+	assert := assert.New(t)
+
+	set := Of(3, 1, 3, 2).ToSet(comparator.IntComparator)
+	assert.Equal(3, set.Size())
+	assert.Equal([]int{1, 2, 3}, set.ToArray())
+}
+
+func TestStreamValues(t *testing.T) {
+	assert := assert.New(t)
+	stream, pulled := counting()
+	elements := []int{}
+
+	for element := range stream.Filter(isEven).Values() {
+		if element > 4 {
+			break
+		}
+		elements = append(elements, element)
+	}
+
+	assert.Equal([]int{0, 2, 4}, elements)
+	assert.Equal(7, *pulled)
+}
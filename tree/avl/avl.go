@@ -0,0 +1,797 @@
+package avl
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/dterbah/gods/internal/format"
+	"github.com/dterbah/gods/internal/serialization"
+	comparator "github.com/dterbah/gods/utils"
+)
+
+type Node[T any] struct {
+	left   *Node[T]
+	right  *Node[T]
+	parent *Node[T]
+	value  T
+	height int
+	size   int
+}
+
+/*
+Struct that represents what is an AVLTree. It is a binary search tree
+that rebalances itself after each insertion and removal, so the height
+of the tree stays in O(log n).
+Each node also stores the size of its subtree, to find an element by its
+in-order position in O(log n)
+*/
+type AVLTree[T any] struct {
+	root       *Node[T]
+	comparator comparator.Comparator[T]
+	zeroValue  T
+}
+
+type AVLTreeIterator[T any] struct {
+	zeroValue   T
+	currentNode *Node[T]
+}
+
+// ---- AVLTreeIterator API ---- //
+func newIterator[T any](tree *AVLTree[T]) *AVLTreeIterator[T] {
+	return &AVLTreeIterator[T]{currentNode: tree.root, zeroValue: tree.zeroValue}
+}
+
+/*
+Return true if the current node of the iterator has a right node, else false
+*/
+func (iterator AVLTreeIterator[T]) HasRight() bool {
+	return iterator.currentNode != nil && iterator.currentNode.right != nil
+}
+
+/*
+Return true if the current node of the iterator has a left node, else false
+*/
+func (iterator AVLTreeIterator[T]) HasLeft() bool {
+	return iterator.currentNode != nil && iterator.currentNode.left != nil
+}
+
+/*
+Return true if the current node of the iterator has a parent, else false
+*/
+func (iterator AVLTreeIterator[T]) HasParent() bool {
+	return iterator.currentNode != nil && iterator.currentNode.parent != nil
+}
+
+/*
+Return the left value of the current node of the iterator. The iterator
+will automatically move to the left element
+*/
+func (iterator *AVLTreeIterator[T]) Left() (T, error) {
+	if !iterator.HasLeft() {
+		return iterator.zeroValue, errors.New("no left value available")
+	}
+
+	iterator.currentNode = iterator.currentNode.left
+
+	return iterator.currentNode.value, nil
+}
+
+/*
+Return the right value of the current node of the iterator. The iterator
+will automatically move to the right element
+*/
+func (iterator *AVLTreeIterator[T]) Right() (T, error) {
+	if !iterator.HasRight() {
+		return iterator.zeroValue, errors.New("no right value available")
+	}
+
+	iterator.currentNode = iterator.currentNode.right
+
+	return iterator.currentNode.value, nil
+}
+
+/*
+Return the parent value of the current node of the iterator. The iterator
+will automatically move to the parent element
+*/
+func (iterator *AVLTreeIterator[T]) Parent() (T, error) {
+	if !iterator.HasParent() {
+		return iterator.zeroValue, errors.New("no parent value available")
+	}
+
+	iterator.currentNode = iterator.currentNode.parent
+
+	return iterator.currentNode.value, nil
+}
+
+/*
+Return the current value of the current node of the iterator.
+*/
+func (iterator *AVLTreeIterator[T]) Current() (T, error) {
+	if iterator.currentNode == nil {
+		return iterator.zeroValue, errors.New("no current value available")
+	}
+
+	return iterator.currentNode.value, nil
+}
+
+// ---- Node API ---- //
+
+/*
+Create a new node for the tree
+*/
+func newNode[T any](value T) *Node[T] {
+	return &Node[T]{value: value, height: 1, size: 1}
+}
+
+func (node *Node[T]) getHeight() int {
+	if node == nil {
+		return 0
+	}
+
+	return node.height
+}
+
+func (node *Node[T]) getSize() int {
+	if node == nil {
+		return 0
+	}
+
+	return node.size
+}
+
+func (node *Node[T]) balanceFactor() int {
+	return node.left.getHeight() - node.right.getHeight()
+}
+
+/*
+Recompute the height and the size of the node from its children,
+and attach the children to it
+*/
+func (node *Node[T]) update() {
+	node.height = 1 + max(node.left.getHeight(), node.right.getHeight())
+	node.size = 1 + node.left.getSize() + node.right.getSize()
+
+	if node.left != nil {
+		node.left.parent = node
+	}
+
+	if node.right != nil {
+		node.right.parent = node
+	}
+}
+
+func (node *Node[T]) rotateLeft() *Node[T] {
+	newRoot := node.right
+	node.right = newRoot.left
+	newRoot.left = node
+	node.update()
+	newRoot.update()
+
+	return newRoot
+}
+
+func (node *Node[T]) rotateRight() *Node[T] {
+	newRoot := node.left
+	node.left = newRoot.right
+	newRoot.right = node
+	node.update()
+	newRoot.update()
+
+	return newRoot
+}
+
+/*
+Rebalance the subtree starting at the node. Return the new root of the subtree
+*/
+func (node *Node[T]) rebalance() *Node[T] {
+	node.update()
+
+	balance := node.balanceFactor()
+
+	if balance > 1 {
+		if node.left.balanceFactor() < 0 {
+			node.left = node.left.rotateLeft()
+		}
+		return node.rotateRight()
+	}
+
+	if balance < -1 {
+		if node.right.balanceFactor() > 0 {
+			node.right = node.right.rotateRight()
+		}
+		return node.rotateLeft()
+	}
+
+	return node
+}
+
+/*
+Insert a value in the subtree. Return the new root of the subtree and true
+if the value was inserted
+*/
+func (node *Node[T]) insertValue(value T, comparator comparator.Comparator[T]) (*Node[T], bool) {
+	if node == nil {
+		return newNode(value), true
+	}
+
+	var inserted bool
+	diff := comparator(node.value, value)
+
+	if diff < 0 {
+		node.right, inserted = node.right.insertValue(value, comparator)
+	} else if diff > 0 {
+		node.left, inserted = node.left.insertValue(value, comparator)
+	} else {
+		return node, false
+	}
+
+	if !inserted {
+		return node, false
+	}
+
+	return node.rebalance(), true
+}
+
+/*
+Remove a value from the subtree. Return the new root of the subtree and true
+if the value was removed
+*/
+func (node *Node[T]) removeValue(value T, comparator comparator.Comparator[T]) (*Node[T], bool) {
+	if node == nil {
+		return nil, false
+	}
+
+	var removed bool
+	diff := comparator(node.value, value)
+
+	if diff > 0 {
+		node.left, removed = node.left.removeValue(value, comparator)
+	} else if diff < 0 {
+		node.right, removed = node.right.removeValue(value, comparator)
+	} else {
+		if node.left == nil || node.right == nil {
+			child := node.left
+			if child == nil {
+				child = node.right
+			}
+
+			if child != nil {
+				child.parent = node.parent
+			}
+
+			return child, true
+		}
+
+		// Node with two children: replace its value by the in-order successor
+		successor := node.right.minNode()
+		node.value = successor.value
+		node.right, _ = node.right.removeValue(successor.value, comparator)
+		removed = true
+	}
+
+	if !removed {
+		return node, false
+	}
+
+	return node.rebalance(), true
+}
+
+func (node *Node[T]) minNode() *Node[T] {
+	current := node
+	for current.left != nil {
+		current = current.left
+	}
+
+	return current
+}
+
+func (node *Node[T]) maxNode() *Node[T] {
+	current := node
+	for current.right != nil {
+		current = current.right
+	}
+
+	return current
+}
+
+/*
+Check the AVL invariants of the subtree and return its minimum and maximum nodes
+*/
+func (node *Node[T]) validate(comparator comparator.Comparator[T]) (*Node[T], *Node[T], error) {
+	minNode, maxNode := node, node
+
+	for _, child := range []*Node[T]{node.left, node.right} {
+		if child == nil {
+			continue
+		}
+
+		if child.parent != node {
+			return nil, nil, fmt.Errorf("node %v has a wrong parent", child.value)
+		}
+
+		childMin, childMax, err := child.validate(comparator)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if child == node.left {
+			if comparator(childMax.value, node.value) >= 0 {
+				return nil, nil, fmt.Errorf("node %v is not greater than its left subtree", node.value)
+			}
+			minNode = childMin
+		} else {
+			if comparator(childMin.value, node.value) <= 0 {
+				return nil, nil, fmt.Errorf("node %v is not less than its right subtree", node.value)
+			}
+			maxNode = childMax
+		}
+	}
+
+	if node.height != 1+max(node.left.getHeight(), node.right.getHeight()) {
+		return nil, nil, fmt.Errorf("node %v has a wrong height", node.value)
+	}
+
+	if node.size != 1+node.left.getSize()+node.right.getSize() {
+		return nil, nil, fmt.Errorf("node %v has a wrong size", node.value)
+	}
+
+	if balance := node.balanceFactor(); balance < -1 || balance > 1 {
+		return nil, nil, fmt.Errorf("node %v is unbalanced", node.value)
+	}
+
+	return minNode, maxNode, nil
+}
+
+/*
+Visit the node and its children in order, stopping as soon as yield returns false.
+Return false if the traversal has been stopped
+*/
+func (node *Node[T]) inOrder(index *int, yield func(int, T) bool) bool {
+	if node == nil {
+		return true
+	}
+
+	if !node.left.inOrder(index, yield) {
+		return false
+	}
+
+	if !yield(*index, node.value) {
+		return false
+	}
+	*index++
+
+	return node.right.inOrder(index, yield)
+}
+
+/*
+Visit in order the values of the subtree greater than the specified one (or equal to,
+if inclusive is true), stopping as soon as yield returns false. The subtrees holding
+only lower values are skipped. Return false if the traversal has been stopped
+*/
+func (node *Node[T]) inOrderFrom(value T, inclusive bool, comparator comparator.Comparator[T], yield func(T) bool) bool {
+	if node == nil {
+		return true
+	}
+
+	diff := comparator(node.value, value)
+	if diff < 0 || diff == 0 && !inclusive {
+		return node.right.inOrderFrom(value, inclusive, comparator, yield)
+	}
+
+	if !node.left.inOrderFrom(value, inclusive, comparator, yield) {
+		return false
+	}
+
+	if !yield(node.value) {
+		return false
+	}
+
+	return node.right.inOrderFrom(value, inclusive, comparator, yield)
+}
+
+// ---- AVLTree API ---- //
+
+/*
+Create a new AVL tree
+*/
+func New[T any](comparator comparator.Comparator[T]) *AVLTree[T] {
+	var zero T
+	return &AVLTree[T]{comparator: comparator, zeroValue: zero}
+}
+
+/*
+Add values in the tree. The values already present in the tree are ignored
+*/
+func (tree *AVLTree[T]) Add(values ...T) {
+	for _, value := range values {
+		tree.root, _ = tree.root.insertValue(value, tree.comparator)
+		tree.root.parent = nil
+	}
+}
+
+/*
+Return an iterator over the values of the tree in sorted order, with their
+in-order position. The iteration stops as soon as the loop body breaks
+*/
+func (tree *AVLTree[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		index := 0
+		tree.root.inOrder(&index, yield)
+	}
+}
+
+/*
+Retrieve the value at the specified in-order position. If the index is negative
+or greater than the tree size, the method will return an error
+*/
+func (tree *AVLTree[T]) At(index int) (T, error) {
+	if index < 0 || index >= tree.Size() {
+		return tree.zeroValue, errors.New("index out of bound")
+	}
+
+	node := tree.root
+	for {
+		leftSize := node.left.getSize()
+		if index < leftSize {
+			node = node.left
+		} else if index > leftSize {
+			index -= leftSize + 1
+			node = node.right
+		} else {
+			return node.value, nil
+		}
+	}
+}
+
+/*
+Return the least value greater than or equal to the specified one.
+If there is no such value, the method will return an error
+*/
+func (tree *AVLTree[T]) Ceiling(value T) (T, error) {
+	return tree.search(value, func(diff int) bool { return diff >= 0 }, false)
+}
+
+/*
+Remove all the values of the tree
+*/
+func (tree *AVLTree[T]) Clear() {
+	tree.root = nil
+}
+
+/*
+Return the greatest value less than or equal to the specified one.
+If there is no such value, the method will return an error
+*/
+func (tree *AVLTree[T]) Floor(value T) (T, error) {
+	return tree.search(value, func(diff int) bool { return diff <= 0 }, true)
+}
+
+/*
+Format the tree for the fmt package: %v writes its elements, %+v also writes
+its size, and %#v writes a Go-syntax representation.
+The other verbs are applied to each element
+*/
+func (tree *AVLTree[T]) Format(state fmt.State, verb rune) {
+	format.Format(state, verb, tree, tree.describe())
+}
+
+/*
+Return the value stored in the tree that is equal to the specified one according
+to the comparator. If there is no such value, the method will return an error
+*/
+func (tree *AVLTree[T]) Get(value T) (T, error) {
+	node := tree.find(value)
+	if node == nil {
+		return tree.zeroValue, errors.New("value not found")
+	}
+
+	return node.value, nil
+}
+
+/*
+Decode the tree from the gob encoding, see UnmarshalBinary
+*/
+func (tree *AVLTree[T]) GobDecode(data []byte) error {
+	return tree.UnmarshalBinary(data)
+}
+
+/*
+Encode the tree for gob, see MarshalBinary
+*/
+func (tree *AVLTree[T]) GobEncode() ([]byte, error) {
+	return tree.MarshalBinary()
+}
+
+/*
+Check if a value is present in the tree. Return true if the value is present,
+else false
+*/
+func (tree *AVLTree[T]) Has(value T) bool {
+	return tree.find(value) != nil
+}
+
+/*
+Return the height of the tree, i.e. the number of nodes on the longest
+path from the root to a leaf. An empty tree has a height of 0
+*/
+func (tree *AVLTree[T]) Height() int {
+	return tree.root.getHeight()
+}
+
+/*
+Return the least value strictly greater than the specified one.
+If there is no such value, the method will return an error
+*/
+func (tree *AVLTree[T]) Higher(value T) (T, error) {
+	return tree.search(value, func(diff int) bool { return diff > 0 }, false)
+}
+
+/*
+Return true if the tree has no values, else false
+*/
+func (tree *AVLTree[T]) IsEmpty() bool {
+	return tree.root == nil
+}
+
+/*
+Return a structural iterator positioned on the root of the tree
+*/
+func (tree *AVLTree[T]) Iterator() *AVLTreeIterator[T] {
+	return newIterator(tree)
+}
+
+/*
+Return the greatest value strictly less than the specified one.
+If there is no such value, the method will return an error
+*/
+func (tree *AVLTree[T]) Lower(value T) (T, error) {
+	return tree.search(value, func(diff int) bool { return diff < 0 }, true)
+}
+
+/*
+Encode the tree in a binary format made of a versioned header
+followed by the gob encoding of its values
+*/
+func (tree *AVLTree[T]) MarshalBinary() ([]byte, error) {
+	values := make([]T, 0, tree.Size())
+	for value := range tree.Values() {
+		values = append(values, value)
+	}
+
+	return serialization.Marshal(values)
+}
+
+/*
+Encode the tree as a JSON array of its values, in ascending order
+*/
+func (tree *AVLTree[T]) MarshalJSON() ([]byte, error) {
+	values := make([]T, 0, tree.Size())
+	for value := range tree.Values() {
+		values = append(values, value)
+	}
+
+	return json.Marshal(values)
+}
+
+/*
+Find the maximum value present in the tree
+*/
+func (tree *AVLTree[T]) Max() (T, error) {
+	if tree.root == nil {
+		return tree.zeroValue, errors.New("empty tree")
+	}
+
+	return tree.root.maxNode().value, nil
+}
+
+/*
+Find the minimum value present in the tree
+*/
+func (tree *AVLTree[T]) Min() (T, error) {
+	if tree.root == nil {
+		return tree.zeroValue, errors.New("empty tree")
+	}
+
+	return tree.root.minNode().value, nil
+}
+
+/*
+Return the number of values in the tree that are strictly less than the specified one
+*/
+func (tree *AVLTree[T]) Rank(value T) int {
+	rank := 0
+	node := tree.root
+
+	for node != nil {
+		if tree.comparator(node.value, value) < 0 {
+			rank += node.left.getSize() + 1
+			node = node.right
+		} else {
+			node = node.left
+		}
+	}
+
+	return rank
+}
+
+/*
+Remove the specified element in the tree if it exists
+Return true if the value was removed, else false
+*/
+func (tree *AVLTree[T]) Remove(value T) bool {
+	var removed bool
+	tree.root, removed = tree.root.removeValue(value, tree.comparator)
+	if tree.root != nil {
+		tree.root.parent = nil
+	}
+
+	return removed
+}
+
+/*
+Return the number of values in the tree
+*/
+func (tree *AVLTree[T]) Size() int {
+	return tree.root.getSize()
+}
+
+/*
+Return the elements of the tree as a string, e.g. [1, 2, 3] in ascending order
+*/
+func (tree *AVLTree[T]) String() string {
+	return format.String(tree.describe())
+}
+
+/*
+Decode the binary format written by MarshalBinary into the tree, replacing its content.
+The values are inserted one by one, so the tree stays balanced. The comparator of the
+tree is kept, or the one registered for the type of the values is used
+*/
+func (tree *AVLTree[T]) UnmarshalBinary(data []byte) error {
+	var values []T
+	if err := serialization.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	return tree.load(values)
+}
+
+/*
+Decode a JSON array of values into the tree, replacing its content. The duplicated
+values are ignored. The comparator of the tree is kept. If the tree has no comparator,
+the one registered for the type of the values is used
+*/
+func (tree *AVLTree[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	return tree.load(values)
+}
+
+/*
+Check that the tree respects all the AVL invariants: the values are ordered,
+the parent links are consistent, the stored heights and sizes are correct and
+no node is unbalanced. Return an error describing the first violation found
+*/
+func (tree *AVLTree[T]) Validate() error {
+	if tree.root == nil {
+		return nil
+	}
+
+	if tree.root.parent != nil {
+		return errors.New("root has a parent")
+	}
+
+	_, _, err := tree.root.validate(tree.comparator)
+	return err
+}
+
+/*
+Return an iterator over the values of the tree in sorted order.
+The iteration stops as soon as the loop body breaks
+*/
+func (tree *AVLTree[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, value := range tree.All() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+/*
+Return an iterator over the values of the tree greater than the specified one
+(or equal to, if inclusive is true), in sorted order. Reaching the first value runs
+in O(log n). The iteration stops as soon as the loop body breaks
+*/
+func (tree *AVLTree[T]) ValuesFrom(value T, inclusive bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		tree.root.inOrderFrom(value, inclusive, tree.comparator, yield)
+	}
+}
+
+/*
+Write the elements of the tree, as returned by String, to the writer
+*/
+func (tree *AVLTree[T]) WriteTo(writer io.Writer) (int64, error) {
+	return format.WriteTo(writer, tree.describe())
+}
+
+// Private methods
+
+// Describe the tree for the format package
+func (tree *AVLTree[T]) describe() format.Container[int, T] {
+	return format.Container[int, T]{
+		Name:  "AVLTree",
+		Open:  "[",
+		Close: "]",
+		All:   tree.All(),
+		Size:  tree.Size(),
+	}
+}
+
+func (tree *AVLTree[T]) find(value T) *Node[T] {
+	node := tree.root
+	for node != nil {
+		diff := tree.comparator(node.value, value)
+		if diff == 0 {
+			return node
+		} else if diff > 0 {
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+
+	return nil
+}
+
+// Replace the content of the tree by the values, resolving the comparator if needed
+func (tree *AVLTree[T]) load(values []T) error {
+	comparator, err := comparator.Resolve(tree.comparator)
+	if err != nil {
+		return err
+	}
+
+	*tree = *New(comparator)
+	tree.Add(values...)
+	return nil
+}
+
+/*
+Find the closest value of the tree matching the predicate on the difference
+between a node value and the searched value. When lower is true, the greatest
+matching value is returned, otherwise the least one
+*/
+func (tree *AVLTree[T]) search(value T, matches func(diff int) bool, lower bool) (T, error) {
+	var candidate *Node[T]
+	node := tree.root
+
+	for node != nil {
+		if matches(tree.comparator(node.value, value)) {
+			candidate = node
+			if lower {
+				node = node.right
+			} else {
+				node = node.left
+			}
+		} else if lower {
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+
+	if candidate == nil {
+		return tree.zeroValue, errors.New("no matching value")
+	}
+
+	return candidate.value, nil
+}
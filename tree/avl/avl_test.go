@@ -0,0 +1,354 @@
+package avl
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	comparator "github.com/dterbah/gods/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAVLTreeAdd(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+
+	tree.Add(1, 2, 2, 3)
+
+	assert.Equal(3, tree.Size())
+	assert.True(tree.Has(1))
+	assert.True(tree.Has(3))
+	assert.False(tree.Has(4))
+}
+
+func TestAVLTreeAddSorted(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+
+	for i := 0; i < 1023; i++ {
+		tree.Add(i)
+	}
+
+	// A perfectly balanced tree of 1023 nodes has a height of 10
+	assert.Nil(tree.Validate())
+	assert.Equal(10, tree.Height())
+	assert.Equal(1023, tree.Size())
+}
+
+func TestAVLTreeAt(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+	tree.Add(5, 1, 4, 2, 3)
+
+	for index := 0; index < 5; index++ {
+		value, err := tree.At(index)
+		assert.Nil(err)
+		assert.Equal(index+1, value)
+	}
+
+	_, err := tree.At(-1)
+	assert.NotNil(err)
+	_, err = tree.At(5)
+	assert.NotNil(err)
+}
+
+func TestAVLTreeBinary(t *testing.T) {
+	assert := assert.New(t)
+	original := New(comparator.IntComparator)
+	original.Add(5, 3, 8, 1)
+
+	data, err := original.MarshalBinary()
+	assert.Nil(err)
+
+	var decoded AVLTree[int]
+	assert.Nil(decoded.UnmarshalBinary(data))
+	assert.Equal([]int{1, 3, 5, 8}, slices.Collect(decoded.Values()))
+
+	// Containers can be embedded in gob encoded structs
+	var buffer bytes.Buffer
+	assert.Nil(gob.NewEncoder(&buffer).Encode(struct{ Container *AVLTree[int] }{original}))
+
+	var checkpoint struct{ Container *AVLTree[int] }
+	assert.Nil(gob.NewDecoder(&buffer).Decode(&checkpoint))
+	decoded = *checkpoint.Container
+	assert.Equal([]int{1, 3, 5, 8}, slices.Collect(decoded.Values()))
+
+	assert.NotNil(decoded.UnmarshalBinary([]byte("invalid")))
+}
+
+func TestAVLTreeClear(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+	tree.Add(1, 2)
+	tree.Clear()
+
+	assert.True(tree.IsEmpty())
+	assert.Equal(0, tree.Size())
+}
+
+func TestAVLTreeJSON(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+	tree.Add(5, 3, 8, 1)
+
+	data, err := json.Marshal(tree)
+	assert.Nil(err)
+	assert.Equal("[1,3,5,8]", string(data))
+
+	var decoded AVLTree[int]
+	assert.Nil(json.Unmarshal([]byte("[8,1,5,3,3]"), &decoded))
+	assert.Equal(4, decoded.Size())
+	assert.Nil(decoded.Validate())
+
+	encoded, _ := json.Marshal(&decoded)
+	assert.Equal(data, encoded)
+}
+
+func TestAVLTreeNavigation(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+
+	_, err := tree.Floor(1)
+	assert.NotNil(err)
+
+	tree.Add(10, 20, 30, 40)
+
+	value, err := tree.Floor(25)
+	assert.Nil(err)
+	assert.Equal(20, value)
+	value, _ = tree.Floor(20)
+	assert.Equal(20, value)
+	_, err = tree.Floor(5)
+	assert.NotNil(err)
+
+	value, _ = tree.Ceiling(25)
+	assert.Equal(30, value)
+	value, _ = tree.Ceiling(30)
+	assert.Equal(30, value)
+	_, err = tree.Ceiling(45)
+	assert.NotNil(err)
+
+	value, _ = tree.Lower(20)
+	assert.Equal(10, value)
+	_, err = tree.Lower(10)
+	assert.NotNil(err)
+
+	value, _ = tree.Higher(20)
+	assert.Equal(30, value)
+	_, err = tree.Higher(40)
+	assert.NotNil(err)
+}
+
+func TestAVLTreeGet(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(func(a, b []int) int { return comparator.IntComparator(a[0], b[0]) })
+	tree.Add([]int{1, 10}, []int{2, 20})
+
+	value, err := tree.Get([]int{2})
+	assert.Nil(err)
+	assert.Equal([]int{2, 20}, value)
+
+	_, err = tree.Get([]int{3})
+	assert.NotNil(err)
+}
+
+func TestAVLTreeIterator(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+
+	iterator := tree.Iterator()
+	_, err := iterator.Current()
+	assert.NotNil(err)
+	_, err = iterator.Parent()
+	assert.NotNil(err)
+
+	// Sorted insertion is rotated to have 2 as the root
+	tree.Add(1, 2, 3)
+
+	iterator = tree.Iterator()
+	value, err := iterator.Current()
+	assert.Nil(err)
+	assert.Equal(2, value)
+
+	value, err = iterator.Left()
+	assert.Nil(err)
+	assert.Equal(1, value)
+	_, err = iterator.Left()
+	assert.NotNil(err)
+
+	value, err = iterator.Parent()
+	assert.Nil(err)
+	assert.Equal(2, value)
+
+	value, err = iterator.Right()
+	assert.Nil(err)
+	assert.Equal(3, value)
+	_, err = iterator.Right()
+	assert.NotNil(err)
+}
+
+func TestAVLTreeMinMax(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+
+	_, err := tree.Min()
+	assert.NotNil(err)
+	_, err = tree.Max()
+	assert.NotNil(err)
+
+	tree.Add(3, -1, 8, 4)
+
+	min, err := tree.Min()
+	assert.Nil(err)
+	assert.Equal(-1, min)
+
+	max, err := tree.Max()
+	assert.Nil(err)
+	assert.Equal(8, max)
+}
+
+func TestAVLTreeRank(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+	tree.Add(10, 20, 30)
+
+	assert.Equal(0, tree.Rank(5))
+	assert.Equal(0, tree.Rank(10))
+	assert.Equal(1, tree.Rank(15))
+	assert.Equal(3, tree.Rank(35))
+}
+
+func TestAVLTreeRemove(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+
+	assert.False(tree.Remove(1))
+
+	tree.Add(3, 2, 1, 6, 4, 7, 5, -1)
+	assert.True(tree.Remove(6))
+	assert.False(tree.Remove(6))
+	assert.True(tree.Remove(3))
+	assert.Equal([]int{-1, 1, 2, 4, 5, 7}, slices.Collect(tree.Values()))
+}
+
+func TestAVLTreeRandomOperations(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+	random := rand.New(rand.NewSource(42))
+	expected := map[int]bool{}
+
+	for i := 0; i < 5000; i++ {
+		value := random.Intn(500)
+		if random.Intn(3) == 0 {
+			assert.Equal(expected[value], tree.Remove(value))
+			delete(expected, value)
+		} else {
+			tree.Add(value)
+			expected[value] = true
+		}
+
+		if err := tree.Validate(); err != nil {
+			assert.Fail(err.Error())
+			return
+		}
+	}
+
+	values := slices.Collect(tree.Values())
+	assert.Equal(len(expected), len(values))
+	assert.True(slices.IsSorted(values))
+}
+
+func TestAVLTreeString(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+	tree.Add(2, 1, 3)
+
+	assert.Equal("[1, 2, 3]", tree.String())
+	assert.Equal("[1, 2, 3]", fmt.Sprint(tree))
+	assert.Equal("AVLTree(size=3) [1, 2, 3]", fmt.Sprintf("%+v", tree))
+	assert.Equal(`&avl.AVLTree[int]{1, 2, 3}`, fmt.Sprintf("%#v", tree))
+
+	var builder strings.Builder
+	count, err := tree.WriteTo(&builder)
+	assert.Nil(err)
+	assert.Equal(int64(len("[1, 2, 3]")), count)
+	assert.Equal("[1, 2, 3]", builder.String())
+}
+
+func TestAVLTreeValidate(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+	assert.Nil(tree.Validate())
+
+	tree.Add(1, 2, 3)
+	tree.root.left.value = 5
+	assert.NotNil(tree.Validate())
+
+	tree.root.left.value = 1
+	tree.root.height = 5
+	assert.NotNil(tree.Validate())
+
+	tree.root.height = 2
+	tree.root.left.parent = nil
+	assert.NotNil(tree.Validate())
+}
+
+func TestAVLTreeValues(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+	tree.Add(4, 2, 3, 1)
+
+	values := []int{}
+	for value := range tree.Values() {
+		if value == 3 {
+			break
+		}
+		values = append(values, value)
+	}
+
+	assert.Equal([]int{1, 2}, values)
+}
+
+func TestAVLTreeValuesFrom(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+	for value := 0; value < 100; value += 10 {
+		tree.Add(value)
+	}
+
+	assert.Equal([]int{50, 60, 70, 80, 90}, slices.Collect(tree.ValuesFrom(50, true)))
+	assert.Equal([]int{60, 70, 80, 90}, slices.Collect(tree.ValuesFrom(50, false)))
+	assert.Equal([]int{60, 70, 80, 90}, slices.Collect(tree.ValuesFrom(55, true)))
+	assert.Equal(slices.Collect(tree.Values()), slices.Collect(tree.ValuesFrom(-1, false)))
+	assert.Empty(slices.Collect(tree.ValuesFrom(90, false)))
+
+	values := []int{}
+	for value := range tree.ValuesFrom(20, true) {
+		if value == 50 {
+			break
+		}
+		values = append(values, value)
+	}
+	assert.Equal([]int{20, 30, 40}, values)
+
+	// Only the path to the first value and the yielded values are compared
+	comparisons := 0
+	counting := func(a, b int) int {
+		comparisons++
+		return comparator.IntComparator(a, b)
+	}
+	large := New(counting)
+	for value := range 1024 {
+		large.Add(value)
+	}
+	comparisons = 0
+	for range large.ValuesFrom(1000, true) {
+		break
+	}
+	assert.LessOrEqual(comparisons, 2*large.Height())
+}
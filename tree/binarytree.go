@@ -1,8 +1,16 @@
 package tree
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
 
+	"github.com/dterbah/gods/collection"
+	"github.com/dterbah/gods/internal/format"
+	"github.com/dterbah/gods/internal/serialization"
 	comparator "github.com/dterbah/gods/utils"
 )
 
@@ -11,6 +19,8 @@ type Node[T any] struct {
 	right  *Node[T]
 	parent *Node[T]
 	value  T
+	// Number of nodes in the subtree rooted at this node
+	size int
 }
 
 /*
@@ -22,6 +32,16 @@ type BinaryTree[T any] struct {
 	zeroValue  T
 }
 
+/*
+Payload of the binary format of a BinaryTree. Shape tells, for each position
+of the pre-order traversal including the missing children, if there is a node.
+Values contains the values of the nodes in pre-order
+*/
+type binaryTree[T any] struct {
+	Shape  []bool
+	Values []T
+}
+
 type BinaryTreeIterator[T any] struct {
 	zeroValue   T
 	currentNode *Node[T]
@@ -106,54 +126,427 @@ func (iterator *BinaryTreeIterator[T]) Current() (T, error) {
 	return iterator.currentNode.value, nil
 }
 
+// ---- TraversalIterator API ---- //
+
+/*
+Pull iterator over the values of a tree in a traversal order, created by
+the InOrderIterator, PreOrderIterator, PostOrderIterator and LevelOrderIterator
+methods. The tree must not be modified while the iterator is used
+*/
+type TraversalIterator[T any] struct {
+	zeroValue T
+	next      *Node[T]
+	advance   func() *Node[T]
+}
+
+func newTraversalIterator[T any](zeroValue T, advance func() *Node[T]) *TraversalIterator[T] {
+	return &TraversalIterator[T]{zeroValue: zeroValue, next: advance(), advance: advance}
+}
+
+/*
+Return true if the traversal has a next value, else false
+*/
+func (iterator *TraversalIterator[T]) HasNext() bool {
+	return iterator.next != nil
+}
+
+/*
+Return the next value of the traversal and move the iterator forward.
+If the traversal is over, this method will return an error
+*/
+func (iterator *TraversalIterator[T]) Next() (T, error) {
+	if iterator.next == nil {
+		return iterator.zeroValue, errors.New("no next value available")
+	}
+
+	value := iterator.next.value
+	iterator.next = iterator.advance()
+	return value, nil
+}
+
+// Call the callback for each remaining value of the traversal, with its position
+func (iterator *TraversalIterator[T]) forEach(callback func(value T, index int)) {
+	for index := 0; iterator.HasNext(); index++ {
+		value, _ := iterator.Next()
+		callback(value, index)
+	}
+}
+
+// ---- BinaryTreeCursor API ---- //
+
+/*
+Cursor moving over the values of a tree in order, from any starting point, created by
+the Cursor method. It follows the parent pointers of the nodes, so each move runs in
+O(h) without recursion. The tree must only be modified with RemoveCurrent while
+the cursor is used
+*/
+type BinaryTreeCursor[T any] struct {
+	tree    *BinaryTree[T]
+	current *Node[T]
+}
+
+/*
+Return the value under the cursor. If the cursor has no current value,
+i.e. the tree is empty or the cursor moved past the greatest value,
+this method will return an error
+*/
+func (cursor *BinaryTreeCursor[T]) Current() (T, error) {
+	if cursor.current == nil {
+		return cursor.tree.zeroValue, errors.New("no current value available")
+	}
+
+	return cursor.current.value, nil
+}
+
+/*
+Move the cursor to the smallest value of the tree and return it.
+If the tree is empty, this method will return an error
+*/
+func (cursor *BinaryTreeCursor[T]) First() (T, error) {
+	cursor.current = cursor.tree.root.leftmost()
+	return cursor.Current()
+}
+
+/*
+Return true if the value under the cursor has an in-order successor, else false
+*/
+func (cursor *BinaryTreeCursor[T]) HasNext() bool {
+	return cursor.current != nil && cursor.current.successor() != nil
+}
+
+/*
+Return true if the value under the cursor has an in-order predecessor, else false
+*/
+func (cursor *BinaryTreeCursor[T]) HasPrev() bool {
+	return cursor.current != nil && cursor.current.predecessor() != nil
+}
+
+/*
+Move the cursor to the greatest value of the tree and return it.
+If the tree is empty, this method will return an error
+*/
+func (cursor *BinaryTreeCursor[T]) Last() (T, error) {
+	cursor.current = cursor.tree.root.rightmost()
+	return cursor.Current()
+}
+
+/*
+Move the cursor to the in-order successor of the current value and return it.
+If there is no successor, the cursor does not move and this method will return an error
+*/
+func (cursor *BinaryTreeCursor[T]) Next() (T, error) {
+	if !cursor.HasNext() {
+		return cursor.tree.zeroValue, errors.New("no next value available")
+	}
+
+	cursor.current = cursor.current.successor()
+	return cursor.current.value, nil
+}
+
+/*
+Move the cursor to the in-order predecessor of the current value and return it.
+If there is no predecessor, the cursor does not move and this method will return an error
+*/
+func (cursor *BinaryTreeCursor[T]) Prev() (T, error) {
+	if !cursor.HasPrev() {
+		return cursor.tree.zeroValue, errors.New("no previous value available")
+	}
+
+	cursor.current = cursor.current.predecessor()
+	return cursor.current.value, nil
+}
+
+/*
+Remove the value under the cursor from the tree and move the cursor to its successor,
+so that a loop can remove values while walking the tree. After removing the greatest
+value, the cursor has no current value. If the cursor has no current value,
+this method will return an error
+*/
+func (cursor *BinaryTreeCursor[T]) RemoveCurrent() error {
+	node := cursor.current
+	if node == nil {
+		return errors.New("no current value available")
+	}
+
+	// A node with two children takes the value of its successor, which is removed
+	// in its place, so the node itself becomes the successor
+	next := node
+	if node.left == nil || node.right == nil {
+		next = node.successor()
+	}
+
+	cursor.tree.Delete(node.value)
+	cursor.current = next
+	return nil
+}
+
+/*
+Move the cursor to the smallest value greater than or equal to the specified one and
+return it. If there is no such value, the cursor has no current value and this method
+will return an error
+*/
+func (cursor *BinaryTreeCursor[T]) Seek(value T) (T, error) {
+	cursor.current = cursor.tree.closestNode(value, false, true)
+	return cursor.Current()
+}
+
 // ---- Node API ---- //
 
 /*
 Create a new node for the tree
 */
 func newNode[T any](value T, parent *Node[T]) *Node[T] {
-	return &Node[T]{value: value, parent: parent}
+	return &Node[T]{value: value, parent: parent, size: 1}
 }
 
-func (node Node[T]) hasValue(value T, comparator comparator.Comparator[T]) bool {
-	if comparator(node.value, value) == 0 {
-		return true
+/*
+Return the node holding the value in the subtree of the node, or nil
+*/
+func (node *Node[T]) find(value T, comparator comparator.Comparator[T]) *Node[T] {
+	for node != nil {
+		diff := comparator(node.value, value)
+		if diff == 0 {
+			return node
+		} else if diff > 0 {
+			node = node.left
+		} else {
+			node = node.right
+		}
 	}
 
-	res := false
+	return nil
+}
 
-	if node.left != nil {
-		res = res || node.left.hasValue(value, comparator)
+/*
+Return the node with the smallest value in the subtree of the node, or nil
+*/
+func (node *Node[T]) leftmost() *Node[T] {
+	for node != nil && node.left != nil {
+		node = node.left
 	}
 
+	return node
+}
+
+/*
+Return the node with the greatest value in the subtree of the node, or nil
+*/
+func (node *Node[T]) rightmost() *Node[T] {
+	for node != nil && node.right != nil {
+		node = node.right
+	}
+
+	return node
+}
+
+/*
+Return the node holding the in-order successor of the node value, or nil
+*/
+func (node *Node[T]) successor() *Node[T] {
 	if node.right != nil {
-		res = res || node.right.hasValue(value, comparator)
+		return node.right.leftmost()
+	}
+
+	for node.parent != nil && node.parent.right == node {
+		node = node.parent
+	}
+
+	return node.parent
+}
+
+/*
+Return the node holding the in-order predecessor of the node value, or nil
+*/
+func (node *Node[T]) predecessor() *Node[T] {
+	if node.left != nil {
+		return node.left.rightmost()
+	}
+
+	for node.parent != nil && node.parent.left == node {
+		node = node.parent
+	}
+
+	return node.parent
+}
+
+/*
+Return the number of nodes in the subtree of the node, 0 for a nil node
+*/
+func (node *Node[T]) subtreeSize() int {
+	if node == nil {
+		return 0
 	}
 
-	return res
+	return node.size
 }
 
 /*
-Insert a value in a node
+Visit the node and its children in order, stopping as soon as yield returns false.
+Return false if the traversal has been stopped
 */
-func (node *Node[T]) insertValue(value T, comparator comparator.Comparator[T], parent *Node[T]) {
+func (node *Node[T]) inOrder(index *int, yield func(int, T) bool) bool {
+	if node == nil {
+		return true
+	}
+
+	if !node.left.inOrder(index, yield) {
+		return false
+	}
+
+	if !yield(*index, node.value) {
+		return false
+	}
+	*index++
+
+	return node.right.inOrder(index, yield)
+}
+
+/*
+Create the node and its children from the binary payload, in pre-order.
+The consumed markers and values are removed from the payload
+*/
+func decodeNode[T any](payload *binaryTree[T], parent *Node[T]) (*Node[T], error) {
+	if len(payload.Shape) == 0 {
+		return nil, errors.New("invalid binary tree shape")
+	}
+
+	present := payload.Shape[0]
+	payload.Shape = payload.Shape[1:]
+	if !present {
+		return nil, nil
+	}
+
+	if len(payload.Values) == 0 {
+		return nil, errors.New("missing binary tree values")
+	}
+
+	node := newNode(payload.Values[0], parent)
+	payload.Values = payload.Values[1:]
+
+	var err error
+	if node.left, err = decodeNode(payload, node); err != nil {
+		return nil, err
+	}
+
+	if node.right, err = decodeNode(payload, node); err != nil {
+		return nil, err
+	}
+
+	node.size += node.left.subtreeSize() + node.right.subtreeSize()
+	return node, nil
+}
+
+/*
+Append the node and its children to the binary payload, in pre-order
+*/
+func (node *Node[T]) encode(payload *binaryTree[T]) {
+	if node == nil {
+		payload.Shape = append(payload.Shape, false)
+		return
+	}
+
+	payload.Shape = append(payload.Shape, true)
+	payload.Values = append(payload.Values, node.value)
+	node.left.encode(payload)
+	node.right.encode(payload)
+}
+
+/*
+Create a balanced subtree from sorted values, the middle value being its root
+*/
+func buildNode[T any](values []T, parent *Node[T]) *Node[T] {
+	if len(values) == 0 {
+		return nil
+	}
+
+	middle := len(values) / 2
+	node := newNode(values[middle], parent)
+	node.left = buildNode(values[:middle], node)
+	node.right = buildNode(values[middle+1:], node)
+	node.size = len(values)
+	return node
+}
+
+/*
+Return the number of nodes on the longest path from the node to a leaf, 0 for a nil node
+*/
+func (node *Node[T]) height() int {
+	if node == nil {
+		return 0
+	}
+
+	return 1 + max(node.left.height(), node.right.height())
+}
+
+/*
+Append the values of the node and its children in pre-order
+*/
+func (node *Node[T]) preOrder(values []T) []T {
+	if node == nil {
+		return values
+	}
+
+	values = append(values, node.value)
+	values = node.left.preOrder(values)
+	return node.right.preOrder(values)
+}
+
+/*
+Write the children of the node in the diagram of the tree, each line
+starting with the prefix. The left child is written before the right one
+*/
+func (node *Node[T]) writeDiagram(writer io.Writer, prefix string) {
+	if node.left == nil && node.right == nil {
+		return
+	}
+
+	for index, child := range []*Node[T]{node.left, node.right} {
+		connector, indent := "|-- ", "|   "
+		if index == 1 {
+			connector, indent = "`-- ", "    "
+		}
+
+		if child == nil {
+			fmt.Fprintf(writer, "%s%s(nil)\n", prefix, connector)
+			continue
+		}
+
+		fmt.Fprintf(writer, "%s%s%v\n", prefix, connector, child.value)
+		child.writeDiagram(writer, prefix+indent)
+	}
+}
+
+/*
+Insert a value in a node. Return true if the value has been inserted,
+false if it was already present
+*/
+func (node *Node[T]) insertValue(value T, comparator comparator.Comparator[T]) bool {
 	diff := comparator(node.value, value)
+	inserted := false
 
 	if diff < 0 {
 		// node.value < value, so create a node on the right
 		if node.right == nil {
 			node.right = newNode(value, node)
+			inserted = true
 		} else {
-			node.right.insertValue(value, comparator, node)
+			inserted = node.right.insertValue(value, comparator)
 		}
 	} else if diff > 0 {
 		// node.value > value, so create a node on the left
 		if node.left == nil {
 			node.left = newNode(value, node)
+			inserted = true
 		} else {
-			node.left.insertValue(value, comparator, node)
+			inserted = node.left.insertValue(value, comparator)
 		}
 	}
+
+	if inserted {
+		node.size++
+	}
+
+	return inserted
 }
 
 // ---- BinaryTree API ---- //
@@ -166,6 +559,30 @@ func New[T any](comparator comparator.Comparator[T]) *BinaryTree[T] {
 	return &BinaryTree[T]{comparator: comparator, zeroValue: zero}
 }
 
+/*
+Create a perfectly balanced tree from values sorted in ascending order, in O(n).
+The duplicated values are only added once. If the values are not sorted,
+this function will return an error
+*/
+func FromSorted[T any](comparator comparator.Comparator[T], values []T) (*BinaryTree[T], error) {
+	distinct := make([]T, 0, len(values))
+	for index, value := range values {
+		if index > 0 {
+			diff := comparator(values[index-1], value)
+			if diff > 0 {
+				return nil, errors.New("the values are not sorted")
+			} else if diff == 0 {
+				continue
+			}
+		}
+		distinct = append(distinct, value)
+	}
+
+	tree := New(comparator)
+	tree.root = buildNode(distinct, nil)
+	return tree, nil
+}
+
 /*
 Add values in the Tree
 */
@@ -174,9 +591,131 @@ func (tree *BinaryTree[T]) Add(values ...T) {
 		if tree.root == nil {
 			tree.root = newNode(value, nil)
 		} else {
-			tree.root.insertValue(value, tree.comparator, tree.root)
+			tree.root.insertValue(value, tree.comparator)
+		}
+	}
+}
+
+/*
+Add all the elements of the collection in the tree
+*/
+func (tree *BinaryTree[T]) AddAll(collection collection.Collection[T]) {
+	tree.Add(collection.ToArray()...)
+}
+
+/*
+Return an iterator over the values of the tree in sorted order, with their
+in-order position. The iteration stops as soon as the loop body breaks
+*/
+func (tree *BinaryTree[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		index := 0
+		tree.root.inOrder(&index, yield)
+	}
+}
+
+/*
+Retrieve the value at the specified in-order position, see Kth. If the index is
+negative or greater than the tree size, the method will return an error
+*/
+func (tree *BinaryTree[T]) At(index int) (T, error) {
+	return tree.Kth(index)
+}
+
+/*
+Return the smallest value of the tree greater than or equal to the value.
+If there is no such value, this method will return an error
+*/
+func (tree *BinaryTree[T]) Ceiling(value T) (T, error) {
+	return tree.closest(value, false, true)
+}
+
+/*
+Remove all the values of the tree
+*/
+func (tree *BinaryTree[T]) Clear() {
+	tree.root = nil
+}
+
+/*
+Return true if the tree contains the element, else false. It is the same as Has
+*/
+func (tree *BinaryTree[T]) Contains(element T) bool {
+	return tree.Has(element)
+}
+
+/*
+Return true if the tree contains all the elements of the collection, else false
+*/
+func (tree *BinaryTree[T]) ContainsAll(collection collection.Collection[T]) bool {
+	for _, element := range collection.ToArray() {
+		if !tree.Has(element) {
+			return false
 		}
 	}
+
+	return true
+}
+
+/*
+Return a cursor positioned on the smallest value of the tree, see BinaryTreeCursor
+*/
+func (tree *BinaryTree[T]) Cursor() *BinaryTreeCursor[T] {
+	return &BinaryTreeCursor[T]{tree: tree, current: tree.root.leftmost()}
+}
+
+/*
+Return an ASCII diagram of the tree, with one node per line. The left child of a node
+is drawn before its right one, and a missing child is drawn as (nil) when the other
+child exists. If the tree is empty, it returns an empty string
+*/
+func (tree *BinaryTree[T]) Diagram() string {
+	if tree.root == nil {
+		return ""
+	}
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "%v\n", tree.root.value)
+	tree.root.writeDiagram(&builder, "")
+	return builder.String()
+}
+
+/*
+Return the greatest value of the tree lower than or equal to the value.
+If there is no such value, this method will return an error
+*/
+func (tree *BinaryTree[T]) Floor(value T) (T, error) {
+	return tree.closest(value, true, true)
+}
+
+/*
+Call a function for each value of the tree, in ascending order
+*/
+func (tree *BinaryTree[T]) ForEach(callback func(element T, index int)) {
+	tree.InOrder(callback)
+}
+
+/*
+Format the tree for the fmt package: %v writes its elements, %+v also writes
+its size, and %#v writes a Go-syntax representation.
+The other verbs are applied to each element
+*/
+func (tree *BinaryTree[T]) Format(state fmt.State, verb rune) {
+	format.Format(state, verb, tree, tree.describe())
+}
+
+/*
+Decode the tree from the gob encoding, see UnmarshalBinary
+*/
+func (tree *BinaryTree[T]) GobDecode(data []byte) error {
+	return tree.UnmarshalBinary(data)
+}
+
+/*
+Encode the tree for gob, see MarshalBinary
+*/
+func (tree *BinaryTree[T]) GobEncode() ([]byte, error) {
+	return tree.MarshalBinary()
 }
 
 /*
@@ -184,17 +723,163 @@ Check if a value is present in the tree. Return true if the value is present,
 else false
 */
 func (tree BinaryTree[T]) Has(value T) bool {
-	if tree.root == nil {
-		return false
+	return tree.root.find(value, tree.comparator) != nil
+}
+
+/*
+Return the height of the tree, i.e. the number of nodes on the longest
+path from the root to a leaf. An empty tree has a height of 0
+*/
+func (tree *BinaryTree[T]) Height() int {
+	return tree.root.height()
+}
+
+/*
+Return the smallest value of the tree strictly greater than the value.
+If there is no such value, this method will return an error
+*/
+func (tree *BinaryTree[T]) Higher(value T) (T, error) {
+	return tree.closest(value, false, false)
+}
+
+/*
+Call a function for each value of the tree in order, i.e. in ascending order
+*/
+func (tree *BinaryTree[T]) InOrder(callback func(value T, index int)) {
+	tree.InOrderIterator().forEach(callback)
+}
+
+/*
+Return a pull iterator over the values of the tree in order, i.e. in ascending order
+*/
+func (tree *BinaryTree[T]) InOrderIterator() *TraversalIterator[T] {
+	stack := []*Node[T]{}
+	current := tree.root
+
+	return newTraversalIterator(tree.zeroValue, func() *Node[T] {
+		for current != nil {
+			stack = append(stack, current)
+			current = current.left
+		}
+
+		if len(stack) == 0 {
+			return nil
+		}
+
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		current = node.right
+		return node
+	})
+}
+
+/*
+Return the in-order position of the element in the tree.
+If the element is not present in the tree, the method will return -1
+*/
+func (tree *BinaryTree[T]) IndexOf(element T) int {
+	if !tree.Has(element) {
+		return -1
 	}
 
-	return tree.root.hasValue(value, tree.comparator)
+	return tree.Rank(element)
+}
+
+/*
+Return true if the tree has no values, else false
+*/
+func (tree *BinaryTree[T]) IsEmpty() bool {
+	return tree.root == nil
 }
 
 func (tree BinaryTree[T]) Iterator() *BinaryTreeIterator[T] {
 	return newIterator[T](tree)
 }
 
+/*
+Return the value at the index k in the sorted values of the tree, starting from 0.
+It runs in O(h), h being the height of the tree. If k is out of bounds,
+this method will return an error
+*/
+func (tree *BinaryTree[T]) Kth(k int) (T, error) {
+	if k < 0 || k >= tree.root.subtreeSize() {
+		return tree.zeroValue, errors.New("index out of bound")
+	}
+
+	node := tree.root
+	for {
+		leftSize := node.left.subtreeSize()
+		if k < leftSize {
+			node = node.left
+		} else if k == leftSize {
+			return node.value, nil
+		} else {
+			k -= leftSize + 1
+			node = node.right
+		}
+	}
+}
+
+/*
+Call a function for each value of the tree in level order, i.e. breadth first from
+the root, each level being visited from the left to the right
+*/
+func (tree *BinaryTree[T]) LevelOrder(callback func(value T, index int)) {
+	tree.LevelOrderIterator().forEach(callback)
+}
+
+/*
+Return a pull iterator over the values of the tree in level order
+*/
+func (tree *BinaryTree[T]) LevelOrderIterator() *TraversalIterator[T] {
+	queue := []*Node[T]{}
+	if tree.root != nil {
+		queue = append(queue, tree.root)
+	}
+
+	return newTraversalIterator(tree.zeroValue, func() *Node[T] {
+		if len(queue) == 0 {
+			return nil
+		}
+
+		node := queue[0]
+		queue = queue[1:]
+		for _, child := range []*Node[T]{node.left, node.right} {
+			if child != nil {
+				queue = append(queue, child)
+			}
+		}
+		return node
+	})
+}
+
+/*
+Return the greatest value of the tree strictly lower than the value.
+If there is no such value, this method will return an error
+*/
+func (tree *BinaryTree[T]) Lower(value T) (T, error) {
+	return tree.closest(value, true, false)
+}
+
+/*
+Encode the tree in a binary format made of a versioned header followed by the gob
+encoding of its nodes in pre-order, with a marker for each missing child. The exact
+shape of the tree is kept, so a decoded tree has the same iterator behavior
+*/
+func (tree *BinaryTree[T]) MarshalBinary() ([]byte, error) {
+	payload := &binaryTree[T]{Shape: []bool{}, Values: []T{}}
+	tree.root.encode(payload)
+	return serialization.Marshal(payload)
+}
+
+/*
+Encode the tree as a JSON array of its values in pre-order. Adding these values
+in the same order to an empty tree rebuilds a tree with the same shape
+*/
+func (tree *BinaryTree[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(tree.root.preOrder([]T{}))
+}
+
 /*
 Find the maximum value present in the tree
 */
@@ -239,12 +924,145 @@ func (tree BinaryTree[T]) Min() (T, error) {
 }
 
 /*
-Remove the specified element in the tree if it exists
-*/ /*
+Call a function for each value of the tree in post-order, i.e. the children
+of a node before the node itself
+*/
+func (tree *BinaryTree[T]) PostOrder(callback func(value T, index int)) {
+	tree.PostOrderIterator().forEach(callback)
+}
+
+/*
+Return a pull iterator over the values of the tree in post-order
+*/
+func (tree *BinaryTree[T]) PostOrderIterator() *TraversalIterator[T] {
+	stack := []*Node[T]{}
+	current := tree.root
+	var last *Node[T]
+
+	return newTraversalIterator(tree.zeroValue, func() *Node[T] {
+		for current != nil || len(stack) > 0 {
+			if current != nil {
+				stack = append(stack, current)
+				current = current.left
+				continue
+			}
+
+			node := stack[len(stack)-1]
+			if node.right != nil && node.right != last {
+				current = node.right
+				continue
+			}
+
+			stack = stack[:len(stack)-1]
+			last = node
+			return node
+		}
+
+		return nil
+	})
+}
+
+/*
+Call a function for each value of the tree in pre-order, i.e. a node
+before its left children, and then its right children
+*/
+func (tree *BinaryTree[T]) PreOrder(callback func(value T, index int)) {
+	tree.PreOrderIterator().forEach(callback)
+}
+
+/*
+Return a pull iterator over the values of the tree in pre-order
+*/
+func (tree *BinaryTree[T]) PreOrderIterator() *TraversalIterator[T] {
+	stack := []*Node[T]{}
+	if tree.root != nil {
+		stack = append(stack, tree.root)
+	}
+
+	return newTraversalIterator(tree.zeroValue, func() *Node[T] {
+		if len(stack) == 0 {
+			return nil
+		}
+
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, child := range []*Node[T]{node.right, node.left} {
+			if child != nil {
+				stack = append(stack, child)
+			}
+		}
+		return node
+	})
+}
+
+/*
+Print the values of the tree on the console, in ascending order
+*/
+func (tree *BinaryTree[T]) Print() {
+	fmt.Println(tree.String())
+}
+
+/*
+Return the values of the tree between from and to, in ascending order. Each bound
+is included in the range if its inclusive flag is true, e.g. Range(1, true, 5, false)
+returns the values v such as 1 <= v < 5. Only the nodes that can be in the range are visited
+*/
+func (tree *BinaryTree[T]) Range(from T, fromInclusive bool, to T, toInclusive bool) []T {
+	values := []T{}
+
+	var visit func(node *Node[T])
+	visit = func(node *Node[T]) {
+		if node == nil {
+			return
+		}
+
+		low := tree.comparator(node.value, from)
+		high := tree.comparator(node.value, to)
+
+		if low > 0 {
+			visit(node.left)
+		}
+
+		if (low > 0 || low == 0 && fromInclusive) && (high < 0 || high == 0 && toInclusive) {
+			values = append(values, node.value)
+		}
+
+		if high < 0 {
+			visit(node.right)
+		}
+	}
+
+	visit(tree.root)
+	return values
+}
+
+/*
+Return the number of values of the tree strictly lower than the value, i.e. the index
+of the value in the sorted values if it is present. It runs in O(h), h being the height of the tree
+*/
+func (tree *BinaryTree[T]) Rank(value T) int {
+	rank := 0
+
+	for node := tree.root; node != nil; {
+		diff := tree.comparator(node.value, value)
+		if diff == 0 {
+			return rank + node.left.subtreeSize()
+		} else if diff < 0 {
+			rank += node.left.subtreeSize() + 1
+			node = node.right
+		} else {
+			node = node.left
+		}
+	}
+
+	return rank
+}
+
+/*
 Remove the specified element in the tree if it exists
 Return true if the value was removed, else false
 */
-func (tree *BinaryTree[T]) Remove(value T) bool {
+func (tree *BinaryTree[T]) Delete(value T) bool {
 	// Helper function to find the minimum value node in a subtree
 	findMin := func(node *Node[T]) *Node[T] {
 		current := node
@@ -262,32 +1080,32 @@ func (tree *BinaryTree[T]) Remove(value T) bool {
 		}
 
 		diff := tree.comparator(node.value, value)
+		removed := true
 		if diff > 0 {
-			var removed bool
 			node.left, removed = removeNode(node.left, value)
-			return node, removed
 		} else if diff < 0 {
-			var removed bool
 			node.right, removed = removeNode(node.right, value)
-			return node, removed
-		} else {
-			// Node with only one child or no child
-			if node.left == nil {
-				temp := node.right
-				node = nil
-				return temp, true
-			} else if node.right == nil {
-				temp := node.left
-				node = nil
-				return temp, true
+		} else if node.left == nil || node.right == nil {
+			// Node with only one child or no child: the child takes its place
+			child := node.left
+			if child == nil {
+				child = node.right
 			}
-
+			if child != nil {
+				child.parent = node.parent
+			}
+			return child, true
+		} else {
 			// Node with two children: Get the inorder successor (smallest in the right subtree)
-			temp := findMin(node.right)
-			node.value = temp.value
-			node.right, _ = removeNode(node.right, temp.value)
-			return node, true
+			successor := findMin(node.right)
+			node.value = successor.value
+			node.right, _ = removeNode(node.right, successor.value)
 		}
+
+		if removed {
+			node.size--
+		}
+		return node, removed
 	}
 
 	var removed bool
@@ -295,4 +1113,165 @@ func (tree *BinaryTree[T]) Remove(value T) bool {
 	return removed
 }
 
-// MAP .??????
+/*
+Rebuild the tree as a perfectly balanced tree, in O(n). It is useful
+for the degenerate trees created by adding sorted values one by one
+*/
+func (tree *BinaryTree[T]) Rebalance() {
+	tree.root = buildNode(tree.ToArray(), nil)
+}
+
+/*
+Remove the specified element in the tree if it exists. Use Delete
+to know if the element was removed
+*/
+func (tree *BinaryTree[T]) Remove(element T) {
+	tree.Delete(element)
+}
+
+/*
+Return the number of values in the tree
+*/
+func (tree *BinaryTree[T]) Size() int {
+	return tree.root.subtreeSize()
+}
+
+/*
+Return the elements of the tree as a string, e.g. [1, 2, 3] in ascending order
+*/
+func (tree *BinaryTree[T]) String() string {
+	return format.String(tree.describe())
+}
+
+/*
+Return the values of the tree in a slice, in ascending order
+*/
+func (tree *BinaryTree[T]) ToArray() []T {
+	values := []T{}
+	tree.InOrder(func(value T, _ int) {
+		values = append(values, value)
+	})
+
+	return values
+}
+
+/*
+Decode the binary format written by MarshalBinary into the tree, replacing its content
+and rebuilding the exact same shape, without comparing the values. The comparator of the
+tree is kept for the next insertions, or the one registered for the type of the values is used.
+If the shape is corrupted, this method will return an error
+*/
+func (tree *BinaryTree[T]) UnmarshalBinary(data []byte) error {
+	var payload binaryTree[T]
+	if err := serialization.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	comparator, err := comparator.Resolve(tree.comparator)
+	if err != nil {
+		return err
+	}
+
+	root, err := decodeNode(&payload, nil)
+	if err != nil {
+		return err
+	}
+
+	if len(payload.Shape) != 0 || len(payload.Values) != 0 {
+		return errors.New("invalid binary tree shape")
+	}
+
+	*tree = *New(comparator)
+	tree.root = root
+	return nil
+}
+
+/*
+Decode a JSON array of values in pre-order into the tree, replacing its content.
+The comparator of the tree is kept. If the tree has no comparator, the one registered
+for the type of the values is used
+*/
+func (tree *BinaryTree[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	comparator, err := comparator.Resolve(tree.comparator)
+	if err != nil {
+		return err
+	}
+
+	*tree = *New(comparator)
+	tree.Add(values...)
+	return nil
+}
+
+/*
+Return an iterator over the values of the tree in sorted order.
+The iteration stops as soon as the loop body breaks
+*/
+func (tree *BinaryTree[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, value := range tree.All() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+/*
+Write the elements of the tree, as returned by String, to the writer
+*/
+func (tree *BinaryTree[T]) WriteTo(writer io.Writer) (int64, error) {
+	return format.WriteTo(writer, tree.describe())
+}
+
+// Private methods
+
+// Return the value of the tree closest to the value, lower than it if below is true,
+// else greater than it. A value equal to it is accepted if inclusive is true
+func (tree *BinaryTree[T]) closest(value T, below, inclusive bool) (T, error) {
+	node := tree.closestNode(value, below, inclusive)
+	if node == nil {
+		return tree.zeroValue, errors.New("no value found")
+	}
+
+	return node.value, nil
+}
+
+// Return the node holding the value returned by closest, or nil
+func (tree *BinaryTree[T]) closestNode(value T, below, inclusive bool) *Node[T] {
+	var result *Node[T]
+
+	for node := tree.root; node != nil; {
+		diff := tree.comparator(node.value, value)
+		if diff == 0 && inclusive {
+			return node
+		}
+
+		if below && diff < 0 || !below && diff > 0 {
+			result = node
+		}
+
+		if diff < 0 || diff == 0 && !below {
+			node = node.right
+		} else {
+			node = node.left
+		}
+	}
+
+	return result
+}
+
+// Describe the tree for the format package
+func (tree *BinaryTree[T]) describe() format.Container[int, T] {
+	return format.Container[int, T]{
+		Name:  "BinaryTree",
+		Open:  "[",
+		Close: "]",
+		All:   tree.All(),
+		Size:  tree.root.subtreeSize(),
+	}
+}
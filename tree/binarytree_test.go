@@ -1,8 +1,19 @@
 package tree
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/dterbah/gods/collection"
+	"github.com/dterbah/gods/internal/serialization"
+	"github.com/dterbah/gods/iterable"
+	"github.com/dterbah/gods/list/arraylist"
 	comparator "github.com/dterbah/gods/utils"
 	"github.com/stretchr/testify/assert"
 )
@@ -20,6 +31,435 @@ func TestBinaryTreeAdd(t *testing.T) {
 	assert.False(tree.Has(4))
 }
 
+func TestBinaryTreeAddAll(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+	tree.Add(2)
+
+	tree.AddAll(arraylist.New(comparator.IntComparator, 3, 1, 2))
+	assert.Equal([]int{1, 2, 3}, tree.ToArray())
+
+	list := arraylist.New(comparator.IntComparator)
+	list.AddAll(tree)
+	assert.Equal([]int{1, 2, 3}, list.ToArray())
+}
+
+func TestBinaryTreeAt(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+	tree.Add(20, 10, 30)
+
+	value, err := tree.At(1)
+	assert.Nil(err)
+	assert.Equal(20, value)
+
+	_, err = tree.At(3)
+	assert.NotNil(err)
+	_, err = tree.At(-1)
+	assert.NotNil(err)
+}
+
+func TestBinaryTreeBinary(t *testing.T) {
+	assert := assert.New(t)
+	original := New(comparator.IntComparator)
+	original.Add(5, 3, 8, 1, 4, 9, 7)
+	original.Remove(8)
+
+	data, err := original.MarshalBinary()
+	assert.Nil(err)
+
+	var decoded BinaryTree[int]
+	assert.Nil(decoded.UnmarshalBinary(data))
+	assert.Equal(slices.Collect(original.Values()), slices.Collect(decoded.Values()))
+
+	// The shape is kept, so the pre-order is the same
+	expected, _ := json.Marshal(original)
+	actual, _ := json.Marshal(&decoded)
+	assert.Equal(expected, actual)
+
+	// The iterator can walk down and up the decoded tree
+	iterator := decoded.Iterator()
+	value, _ := iterator.Left()
+	assert.Equal(3, value)
+	value, _ = iterator.Right()
+	assert.Equal(4, value)
+	value, _ = iterator.Parent()
+	assert.Equal(3, value)
+	value, _ = iterator.Parent()
+	assert.Equal(5, value)
+	assert.True(decoded.Has(9))
+
+	var buffer bytes.Buffer
+	assert.Nil(gob.NewEncoder(&buffer).Encode(original))
+	var fromGob BinaryTree[int]
+	assert.Nil(gob.NewDecoder(&buffer).Decode(&fromGob))
+	actual, _ = json.Marshal(&fromGob)
+	assert.Equal(expected, actual)
+}
+
+func TestBinaryTreeBinaryEmpty(t *testing.T) {
+	assert := assert.New(t)
+
+	data, err := New(comparator.IntComparator).MarshalBinary()
+	assert.Nil(err)
+
+	decoded := New(comparator.IntComparator)
+	decoded.Add(1)
+	assert.Nil(decoded.UnmarshalBinary(data))
+	assert.False(decoded.Has(1))
+	_, err = decoded.Min()
+	assert.NotNil(err)
+}
+
+func TestBinaryTreeBinaryInvalid(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+
+	assert.NotNil(tree.UnmarshalBinary([]byte("invalid")))
+
+	// The shape announces more nodes than there are values
+	data, _ := serialization.Marshal(binaryTree[int]{Shape: []bool{true, true, false, false, false}, Values: []int{1}})
+	assert.NotNil(tree.UnmarshalBinary(data))
+
+	// The shape ends before the tree is complete
+	data, _ = serialization.Marshal(binaryTree[int]{Shape: []bool{true, false}, Values: []int{1}})
+	assert.NotNil(tree.UnmarshalBinary(data))
+
+	// Some values are not used by the shape
+	data, _ = serialization.Marshal(binaryTree[int]{Shape: []bool{true, false, false}, Values: []int{1, 2}})
+	assert.NotNil(tree.UnmarshalBinary(data))
+}
+
+func TestBinaryTreeCeiling(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+
+	_, err := tree.Ceiling(1)
+	assert.NotNil(err)
+
+	tree.Add(50, 30, 70, 20, 40, 60, 80)
+	var value int
+
+	value, err = tree.Ceiling(45)
+	assert.Nil(err)
+	assert.Equal(50, value)
+
+	value, err = tree.Ceiling(40)
+	assert.Nil(err)
+	assert.Equal(40, value)
+
+	value, err = tree.Ceiling(80)
+	assert.Nil(err)
+	assert.Equal(80, value)
+
+	_, err = tree.Ceiling(81)
+	assert.NotNil(err)
+
+	value, err = tree.Ceiling(0)
+	assert.Nil(err)
+	assert.Equal(20, value)
+
+	value, err = tree.Ceiling(65)
+	assert.Nil(err)
+	assert.Equal(70, value)
+}
+
+func TestBinaryTreeClear(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+	tree.Add(1, 2)
+
+	tree.Clear()
+	assert.True(tree.IsEmpty())
+	assert.Equal(0, tree.Size())
+	assert.False(tree.Has(1))
+}
+
+func TestBinaryTreeContains(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+	tree.Add(2, 1, 3)
+
+	assert.True(tree.Contains(3))
+	assert.False(tree.Contains(4))
+	assert.True(tree.ContainsAll(arraylist.New(comparator.IntComparator, 1, 3)))
+	assert.False(tree.ContainsAll(arraylist.New(comparator.IntComparator, 1, 4)))
+}
+
+func TestBinaryTreeCursor(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+	tree.Add(50, 30, 70, 20, 40, 60, 80)
+	cursor := tree.Cursor()
+
+	values := []int{}
+	for value, err := cursor.Current(); err == nil; value, err = cursor.Next() {
+		values = append(values, value)
+	}
+	assert.Equal([]int{20, 30, 40, 50, 60, 70, 80}, values)
+	assert.False(cursor.HasNext())
+
+	current, err := cursor.Current()
+	assert.Nil(err)
+	assert.Equal(80, current)
+
+	values = []int{}
+	for cursor.HasPrev() {
+		value, err := cursor.Prev()
+		assert.Nil(err)
+		values = append(values, value)
+	}
+	assert.Equal([]int{70, 60, 50, 40, 30, 20}, values)
+
+	_, err = cursor.Prev()
+	assert.NotNil(err)
+
+	last, err := cursor.Last()
+	assert.Nil(err)
+	assert.Equal(80, last)
+	first, err := cursor.First()
+	assert.Nil(err)
+	assert.Equal(20, first)
+
+	empty := New(comparator.IntComparator).Cursor()
+	_, err = empty.Current()
+	assert.NotNil(err)
+	_, err = empty.Next()
+	assert.NotNil(err)
+	_, err = empty.First()
+	assert.NotNil(err)
+	assert.False(empty.HasPrev())
+}
+
+func TestBinaryTreeCursorRemoveCurrent(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+	tree.Add(50, 30, 70, 20, 40, 60, 80, 35, 45)
+	cursor := tree.Cursor()
+
+	// Remove the multiples of 10 while walking, including nodes with two children
+	for value, err := cursor.Current(); err == nil; value, err = cursor.Current() {
+		if value%10 == 0 {
+			assert.Nil(cursor.RemoveCurrent())
+		} else if _, err := cursor.Next(); err != nil {
+			break
+		}
+	}
+	assert.Equal([]int{35, 45}, tree.ToArray())
+	assert.Equal(2, tree.Size())
+
+	cursor.Last()
+	assert.Nil(cursor.RemoveCurrent())
+	_, err := cursor.Current()
+	assert.NotNil(err)
+	assert.NotNil(cursor.RemoveCurrent())
+
+	cursor.First()
+	assert.Nil(cursor.RemoveCurrent())
+	assert.True(tree.IsEmpty())
+}
+
+func TestBinaryTreeCursorRemoveRandom(t *testing.T) {
+	assert := assert.New(t)
+	random := rand.New(rand.NewSource(42))
+	tree := New(comparator.IntComparator)
+	model := []int{}
+	for _, value := range random.Perm(200) {
+		tree.Add(value)
+		model = append(model, value)
+	}
+	slices.Sort(model)
+	cursor := tree.Cursor()
+
+	for len(model) > 0 {
+		index := random.Intn(len(model))
+		value, err := cursor.Seek(model[index])
+		assert.Nil(err)
+		assert.Equal(model[index], value)
+
+		assert.Nil(cursor.RemoveCurrent())
+		model = slices.Delete(model, index, index+1)
+		current, err := cursor.Current()
+		if index < len(model) {
+			assert.Nil(err)
+			assert.Equal(model[index], current)
+		} else {
+			assert.NotNil(err)
+		}
+		assert.Equal(model, tree.ToArray())
+	}
+}
+
+func TestBinaryTreeCursorSeek(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+	tree.Add(50, 30, 70, 20, 40, 60, 80)
+	cursor := tree.Cursor()
+
+	value, err := cursor.Seek(45)
+	assert.Nil(err)
+	assert.Equal(50, value)
+
+	value, err = cursor.Seek(60)
+	assert.Nil(err)
+	assert.Equal(60, value)
+
+	window := []int{value}
+	for cursor.HasNext() && len(window) < 3 {
+		value, _ = cursor.Next()
+		window = append(window, value)
+	}
+	assert.Equal([]int{60, 70, 80}, window)
+
+	value, err = cursor.Seek(1)
+	assert.Nil(err)
+	assert.Equal(20, value)
+
+	_, err = cursor.Seek(81)
+	assert.NotNil(err)
+	_, err = cursor.Current()
+	assert.NotNil(err)
+	assert.False(cursor.HasNext())
+}
+
+func TestBinaryTreeDelete(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+
+	assert.False(tree.Delete(1))
+
+	tree.Add(1)
+	assert.True(tree.Delete(1))
+	assert.False(tree.Delete(1))
+	assert.False(tree.Has(1))
+
+	tree.Add(1, 3)
+	assert.True(tree.Delete(3))
+	assert.False(tree.Delete(3))
+
+	tree.Add(-1, -2, -3)
+	assert.True(tree.Delete(-2))
+	assert.True(tree.Delete(-1))
+
+	tree = New(comparator.IntComparator)
+	tree.Add(3, 2, 1, 6, 4, 7, 5, -1)
+
+	assert.True(tree.Delete(6))
+}
+
+func TestBinaryTreeDeleteParent(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+	tree.Add(5, 3, 8, 1)
+
+	assert.True(tree.Delete(3))
+	iterator := tree.Iterator()
+	value, err := iterator.Left()
+	assert.Nil(err)
+	assert.Equal(1, value)
+
+	value, err = iterator.Parent()
+	assert.Nil(err)
+	assert.Equal(5, value)
+
+	assert.True(tree.Delete(5))
+	iterator = tree.Iterator()
+	assert.False(iterator.HasParent())
+	value, err = iterator.Current()
+	assert.Nil(err)
+	assert.Equal(8, value)
+}
+
+func TestBinaryTreeDiagram(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+
+	assert.Equal("", tree.Diagram())
+
+	tree.Add(5, 3, 8, 1, 4, 9)
+	expected := `5
+|-- 3
+|   |-- 1
+|   ` + "`" + `-- 4
+` + "`" + `-- 8
+    |-- (nil)
+    ` + "`" + `-- 9
+`
+
+	assert.Equal(expected, tree.Diagram())
+}
+
+func TestBinaryTreeFloor(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+
+	_, err := tree.Floor(1)
+	assert.NotNil(err)
+
+	tree.Add(50, 30, 70, 20, 40, 60, 80)
+	var value int
+
+	value, err = tree.Floor(45)
+	assert.Nil(err)
+	assert.Equal(40, value)
+
+	value, err = tree.Floor(40)
+	assert.Nil(err)
+	assert.Equal(40, value)
+
+	value, err = tree.Floor(20)
+	assert.Nil(err)
+	assert.Equal(20, value)
+
+	_, err = tree.Floor(19)
+	assert.NotNil(err)
+
+	value, err = tree.Floor(100)
+	assert.Nil(err)
+	assert.Equal(80, value)
+
+	value, err = tree.Floor(65)
+	assert.Nil(err)
+	assert.Equal(60, value)
+}
+
+func TestBinaryTreeForEach(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+	tree.Add(2, 1, 3)
+	values := []int{}
+
+	tree.ForEach(func(element, index int) {
+		assert.Equal(len(values), index)
+		values = append(values, element)
+	})
+	assert.Equal([]int{1, 2, 3}, values)
+}
+
+func TestBinaryTreeFromSorted(t *testing.T) {
+	assert := assert.New(t)
+	values := []int{}
+	for value := range 100 {
+		values = append(values, value, value)
+	}
+
+	tree, err := FromSorted(comparator.IntComparator, values)
+	assert.Nil(err)
+	assert.Equal(100, tree.Size())
+	assert.Equal(7, tree.Height())
+	assert.Equal(slices.Compact(values), tree.ToArray())
+	assert.Equal(42, tree.Rank(42))
+	tree.Add(100)
+	assert.True(tree.Has(100))
+
+	tree, err = FromSorted(comparator.IntComparator, []int{})
+	assert.Nil(err)
+	assert.True(tree.IsEmpty())
+
+	_, err = FromSorted(comparator.IntComparator, []int{1, 3, 2})
+	assert.NotNil(err)
+}
+
 func TestBinaryTreeHas(t *testing.T) {
 	assert := assert.New(t)
 	tree := New(comparator.IntComparator)
@@ -34,6 +474,254 @@ func TestBinaryTreeHas(t *testing.T) {
 	assert.True(tree.Has(-2))
 }
 
+func TestBinaryTreeHeight(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+
+	assert.Equal(0, tree.Height())
+
+	tree.Add(5)
+	assert.Equal(1, tree.Height())
+
+	tree.Add(3, 8, 1)
+	assert.Equal(3, tree.Height())
+}
+
+func TestBinaryTreeHigher(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+
+	_, err := tree.Higher(1)
+	assert.NotNil(err)
+
+	tree.Add(50, 30, 70, 20, 40, 60, 80)
+	var value int
+
+	value, err = tree.Higher(45)
+	assert.Nil(err)
+	assert.Equal(50, value)
+
+	value, err = tree.Higher(40)
+	assert.Nil(err)
+	assert.Equal(50, value)
+
+	_, err = tree.Higher(80)
+	assert.NotNil(err)
+
+	value, err = tree.Higher(0)
+	assert.Nil(err)
+	assert.Equal(20, value)
+
+	value, err = tree.Higher(50)
+	assert.Nil(err)
+	assert.Equal(60, value)
+}
+
+func TestBinaryTreeInOrder(t *testing.T) {
+	assert := assert.New(t)
+	collect := func(tree *BinaryTree[int]) []int {
+		values := []int{}
+		tree.InOrder(func(value, index int) {
+			assert.Equal(len(values), index)
+			values = append(values, value)
+		})
+		return values
+	}
+
+	tree := New(comparator.IntComparator)
+	assert.Empty(collect(tree))
+
+	tree.Add(5, 3, 8, 1, 4, 9, 7)
+	assert.Equal([]int{1, 3, 4, 5, 7, 8, 9}, collect(tree))
+
+	rightChain := New(comparator.IntComparator)
+	rightChain.Add(1, 2, 3)
+	assert.Equal([]int{1, 2, 3}, collect(rightChain))
+
+	leftChain := New(comparator.IntComparator)
+	leftChain.Add(3, 2, 1)
+	assert.Equal([]int{1, 2, 3}, collect(leftChain))
+}
+
+func TestBinaryTreeInOrderIterator(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+
+	iterator := tree.InOrderIterator()
+	assert.False(iterator.HasNext())
+	_, err := iterator.Next()
+	assert.NotNil(err)
+
+	tree.Add(5, 3, 8, 1, 4, 9, 7)
+	iterator = tree.InOrderIterator()
+	values := []int{}
+	for iterator.HasNext() {
+		value, err := iterator.Next()
+		assert.Nil(err)
+		values = append(values, value)
+	}
+	assert.Equal([]int{1, 3, 4, 5, 7, 8, 9}, values)
+
+	_, err = iterator.Next()
+	assert.NotNil(err)
+}
+
+func TestBinaryTreeIndexOf(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+	tree.Add(20, 10, 30)
+
+	assert.Equal(0, tree.IndexOf(10))
+	assert.Equal(2, tree.IndexOf(30))
+	assert.Equal(-1, tree.IndexOf(25))
+}
+
+func TestBinaryTreeInterfaces(t *testing.T) {
+	assert := assert.New(t)
+	var collection collection.Collection[int] = New(comparator.IntComparator)
+	var iterable iterable.Iterable[int] = New(comparator.IntComparator)
+
+	assert.True(collection.IsEmpty())
+	assert.Equal(-1, iterable.IndexOf(1))
+}
+
+func TestBinaryTreeJSON(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+	tree.Add(5, 3, 8, 1, 4, 9)
+
+	data, err := json.Marshal(tree)
+	assert.Nil(err)
+	assert.Equal("[5,3,1,4,8,9]", string(data))
+
+	// The pre-order keeps the shape of the tree
+	var decoded BinaryTree[int]
+	assert.Nil(json.Unmarshal(data, &decoded))
+	encoded, _ := json.Marshal(&decoded)
+	assert.Equal(data, encoded)
+
+	data, err = json.Marshal(New(comparator.IntComparator))
+	assert.Nil(err)
+	assert.Equal("[]", string(data))
+}
+
+func TestBinaryTreeKth(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+
+	_, err := tree.Kth(0)
+	assert.NotNil(err)
+
+	tree.Add(50, 30, 70, 20, 40, 60, 80, 30)
+	for index, expected := range tree.ToArray() {
+		value, err := tree.Kth(index)
+		assert.Nil(err)
+		assert.Equal(expected, value)
+	}
+
+	_, err = tree.Kth(7)
+	assert.NotNil(err)
+	_, err = tree.Kth(-1)
+	assert.NotNil(err)
+
+	tree.Remove(50)
+	tree.Remove(20)
+	tree.Remove(100)
+	value, err := tree.Kth(2)
+	assert.Nil(err)
+	assert.Equal(60, value)
+	_, err = tree.Kth(5)
+	assert.NotNil(err)
+
+	data, err := tree.MarshalBinary()
+	assert.Nil(err)
+	decoded := New(comparator.IntComparator)
+	assert.Nil(decoded.UnmarshalBinary(data))
+	value, err = decoded.Kth(4)
+	assert.Nil(err)
+	assert.Equal(80, value)
+	assert.Equal(2, decoded.Rank(60))
+}
+
+func TestBinaryTreeLevelOrder(t *testing.T) {
+	assert := assert.New(t)
+	collect := func(tree *BinaryTree[int]) []int {
+		values := []int{}
+		tree.LevelOrder(func(value, index int) {
+			assert.Equal(len(values), index)
+			values = append(values, value)
+		})
+		return values
+	}
+
+	tree := New(comparator.IntComparator)
+	assert.Empty(collect(tree))
+
+	tree.Add(5, 3, 8, 1, 4, 9, 7)
+	assert.Equal([]int{5, 3, 8, 1, 4, 7, 9}, collect(tree))
+
+	rightChain := New(comparator.IntComparator)
+	rightChain.Add(1, 2, 3)
+	assert.Equal([]int{1, 2, 3}, collect(rightChain))
+
+	leftChain := New(comparator.IntComparator)
+	leftChain.Add(3, 2, 1)
+	assert.Equal([]int{3, 2, 1}, collect(leftChain))
+}
+
+func TestBinaryTreeLevelOrderIterator(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+
+	iterator := tree.LevelOrderIterator()
+	assert.False(iterator.HasNext())
+	_, err := iterator.Next()
+	assert.NotNil(err)
+
+	tree.Add(5, 3, 8, 1, 4, 9, 7)
+	iterator = tree.LevelOrderIterator()
+	values := []int{}
+	for iterator.HasNext() {
+		value, err := iterator.Next()
+		assert.Nil(err)
+		values = append(values, value)
+	}
+	assert.Equal([]int{5, 3, 8, 1, 4, 7, 9}, values)
+
+	_, err = iterator.Next()
+	assert.NotNil(err)
+}
+
+func TestBinaryTreeLower(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+
+	_, err := tree.Lower(1)
+	assert.NotNil(err)
+
+	tree.Add(50, 30, 70, 20, 40, 60, 80)
+	var value int
+
+	value, err = tree.Lower(45)
+	assert.Nil(err)
+	assert.Equal(40, value)
+
+	value, err = tree.Lower(40)
+	assert.Nil(err)
+	assert.Equal(30, value)
+
+	_, err = tree.Lower(20)
+	assert.NotNil(err)
+
+	value, err = tree.Lower(100)
+	assert.Nil(err)
+	assert.Equal(80, value)
+
+	value, err = tree.Lower(50)
+	assert.Nil(err)
+	assert.Equal(40, value)
+}
+
 func TestBinaryTreeMin(t *testing.T) {
 	assert := assert.New(t)
 	tree := New(comparator.IntComparator)
@@ -177,27 +865,247 @@ func TestBinaryTreeIteratorParent(t *testing.T) {
 	}
 }
 
+func TestBinaryTreePostOrder(t *testing.T) {
+	assert := assert.New(t)
+	collect := func(tree *BinaryTree[int]) []int {
+		values := []int{}
+		tree.PostOrder(func(value, index int) {
+			assert.Equal(len(values), index)
+			values = append(values, value)
+		})
+		return values
+	}
+
+	tree := New(comparator.IntComparator)
+	assert.Empty(collect(tree))
+
+	tree.Add(5, 3, 8, 1, 4, 9, 7)
+	assert.Equal([]int{1, 4, 3, 7, 9, 8, 5}, collect(tree))
+
+	rightChain := New(comparator.IntComparator)
+	rightChain.Add(1, 2, 3)
+	assert.Equal([]int{3, 2, 1}, collect(rightChain))
+
+	leftChain := New(comparator.IntComparator)
+	leftChain.Add(3, 2, 1)
+	assert.Equal([]int{1, 2, 3}, collect(leftChain))
+}
+
+func TestBinaryTreePostOrderIterator(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+
+	iterator := tree.PostOrderIterator()
+	assert.False(iterator.HasNext())
+	_, err := iterator.Next()
+	assert.NotNil(err)
+
+	tree.Add(5, 3, 8, 1, 4, 9, 7)
+	iterator = tree.PostOrderIterator()
+	values := []int{}
+	for iterator.HasNext() {
+		value, err := iterator.Next()
+		assert.Nil(err)
+		values = append(values, value)
+	}
+	assert.Equal([]int{1, 4, 3, 7, 9, 8, 5}, values)
+
+	_, err = iterator.Next()
+	assert.NotNil(err)
+}
+
+func TestBinaryTreePreOrder(t *testing.T) {
+	assert := assert.New(t)
+	collect := func(tree *BinaryTree[int]) []int {
+		values := []int{}
+		tree.PreOrder(func(value, index int) {
+			assert.Equal(len(values), index)
+			values = append(values, value)
+		})
+		return values
+	}
+
+	tree := New(comparator.IntComparator)
+	assert.Empty(collect(tree))
+
+	tree.Add(5, 3, 8, 1, 4, 9, 7)
+	assert.Equal([]int{5, 3, 1, 4, 8, 7, 9}, collect(tree))
+
+	rightChain := New(comparator.IntComparator)
+	rightChain.Add(1, 2, 3)
+	assert.Equal([]int{1, 2, 3}, collect(rightChain))
+
+	leftChain := New(comparator.IntComparator)
+	leftChain.Add(3, 2, 1)
+	assert.Equal([]int{3, 2, 1}, collect(leftChain))
+}
+
+func TestBinaryTreePreOrderIterator(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+
+	iterator := tree.PreOrderIterator()
+	assert.False(iterator.HasNext())
+	_, err := iterator.Next()
+	assert.NotNil(err)
+
+	tree.Add(5, 3, 8, 1, 4, 9, 7)
+	iterator = tree.PreOrderIterator()
+	values := []int{}
+	for iterator.HasNext() {
+		value, err := iterator.Next()
+		assert.Nil(err)
+		values = append(values, value)
+	}
+	assert.Equal([]int{5, 3, 1, 4, 8, 7, 9}, values)
+
+	_, err = iterator.Next()
+	assert.NotNil(err)
+}
+
+func TestBinaryTreeRange(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+
+	assert.Equal([]int{}, tree.Range(0, true, 10, true))
+
+	tree.Add(50, 30, 70, 20, 40, 60, 80)
+	assert.Equal([]int{30, 40, 50, 60}, tree.Range(30, true, 60, true))
+	assert.Equal([]int{40, 50}, tree.Range(30, false, 60, false))
+	assert.Equal([]int{30, 40, 50}, tree.Range(30, true, 60, false))
+	assert.Equal([]int{40, 50, 60}, tree.Range(35, false, 65, true))
+	assert.Equal([]int{20, 30, 40, 50, 60, 70, 80}, tree.Range(0, true, 100, true))
+	assert.Equal([]int{}, tree.Range(41, true, 49, true))
+	assert.Equal([]int{}, tree.Range(60, true, 30, true))
+	assert.Equal([]int{50}, tree.Range(50, true, 50, true))
+	assert.Equal([]int{}, tree.Range(50, true, 50, false))
+}
+
+func TestBinaryTreeRank(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+
+	assert.Equal(0, tree.Rank(10))
+
+	tree.Add(50, 30, 70, 20, 40, 60, 80)
+	for index, value := range tree.All() {
+		assert.Equal(index, tree.Rank(value))
+	}
+	assert.Equal(0, tree.Rank(10))
+	assert.Equal(3, tree.Rank(45))
+	assert.Equal(7, tree.Rank(90))
+}
+
+func TestBinaryTreeAll(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+	tree.Add(3, 1, 4, 2)
+
+	indexes := []int{}
+	values := []int{}
+	for index, value := range tree.All() {
+		if index == 2 {
+			break
+		}
+		indexes = append(indexes, index)
+		values = append(values, value)
+	}
+
+	assert.Equal([]int{0, 1}, indexes)
+	assert.Equal([]int{1, 2}, values)
+}
+
+func TestBinaryTreeRebalance(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+	for value := range 127 {
+		tree.Add(value)
+	}
+	assert.Equal(127, tree.Height())
+
+	tree.Rebalance()
+	assert.Equal(7, tree.Height())
+	assert.Equal(127, tree.Size())
+	value, err := tree.Kth(100)
+	assert.Nil(err)
+	assert.Equal(100, value)
+	assert.True(tree.Delete(64))
+	assert.Equal(126, tree.Size())
+
+	empty := New(comparator.IntComparator)
+	empty.Rebalance()
+	assert.True(empty.IsEmpty())
+}
+
 func TestBinaryTreeRemove(t *testing.T) {
 	assert := assert.New(t)
 	tree := New(comparator.IntComparator)
+	tree.Add(2, 1, 3)
 
-	assert.False(tree.Remove(1))
+	tree.Remove(2)
+	tree.Remove(4)
+	assert.Equal([]int{1, 3}, tree.ToArray())
+}
 
-	tree.Add(1)
-	assert.True(tree.Remove(1))
-	assert.False(tree.Remove(1))
-	assert.False(tree.Has(1))
+func TestBinaryTreeSize(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
 
-	tree.Add(1, 3)
-	assert.True(tree.Remove(3))
-	assert.False(tree.Remove(3))
+	assert.Equal(0, tree.Size())
+	assert.True(tree.IsEmpty())
 
-	tree.Add(-1, -2, -3)
-	assert.True(tree.Remove(-2))
-	assert.True(tree.Remove(-1))
+	tree.Add(2, 1, 3, 2)
+	assert.Equal(3, tree.Size())
+	assert.False(tree.IsEmpty())
 
-	tree = New(comparator.IntComparator)
-	tree.Add(3, 2, 1, 6, 4, 7, 5, -1)
+	tree.Delete(2)
+	assert.Equal(2, tree.Size())
+}
+
+func TestBinaryTreeString(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+	tree.Add(2, 1, 3)
+
+	assert.Equal("[1, 2, 3]", tree.String())
+	assert.Equal("[1, 2, 3]", fmt.Sprint(tree))
+	assert.Equal("BinaryTree(size=3) [1, 2, 3]", fmt.Sprintf("%+v", tree))
+	assert.Equal(`&tree.BinaryTree[int]{1, 2, 3}`, fmt.Sprintf("%#v", tree))
+
+	var builder strings.Builder
+	count, err := tree.WriteTo(&builder)
+	assert.Nil(err)
+	assert.Equal(int64(len("[1, 2, 3]")), count)
+	assert.Equal("[1, 2, 3]", builder.String())
+}
 
-	assert.True(tree.Remove(6))
+func TestBinaryTreeToArray(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+
+	assert.Equal([]int{}, tree.ToArray())
+
+	tree.Add(5, 3, 8, 1, 4, 9, 7, 3)
+	assert.Equal([]int{1, 3, 4, 5, 7, 8, 9}, tree.ToArray())
+}
+
+func TestBinaryTreeValues(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+	tree.Add(3, 1, 4, 2)
+
+	values := []int{}
+	for value := range tree.Values() {
+		values = append(values, value)
+	}
+	assert.Equal([]int{1, 2, 3, 4}, values)
+
+	values = []int{}
+	for value := range tree.Values() {
+		if value == 3 {
+			break
+		}
+		values = append(values, value)
+	}
+	assert.Equal([]int{1, 2}, values)
 }
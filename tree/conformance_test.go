@@ -0,0 +1,16 @@
+package tree_test
+
+import (
+	"testing"
+
+	"github.com/dterbah/gods/collection"
+	"github.com/dterbah/gods/collection/collectiontest"
+	"github.com/dterbah/gods/tree"
+	comparator "github.com/dterbah/gods/utils"
+)
+
+func TestBinaryTreeConformance(t *testing.T) {
+	collectiontest.TestCollection(t, func() collection.Collection[int] {
+		return tree.New(comparator.IntComparator)
+	})
+}
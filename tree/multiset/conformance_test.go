@@ -0,0 +1,16 @@
+package multiset_test
+
+import (
+	"testing"
+
+	"github.com/dterbah/gods/collection"
+	"github.com/dterbah/gods/collection/collectiontest"
+	"github.com/dterbah/gods/tree/multiset"
+	comparator "github.com/dterbah/gods/utils"
+)
+
+func TestTreeMultisetConformance(t *testing.T) {
+	collectiontest.TestCollection(t, func() collection.Collection[int] {
+		return multiset.New(comparator.IntComparator)
+	})
+}
@@ -0,0 +1,475 @@
+package multiset
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/dterbah/gods/collection"
+	"github.com/dterbah/gods/internal/format"
+	"github.com/dterbah/gods/internal/serialization"
+	"github.com/dterbah/gods/tree/avl"
+	comparator "github.com/dterbah/gods/utils"
+)
+
+// Value of the multiset with its number of occurrences
+type entry[T any] struct {
+	value T
+	count int
+}
+
+/*
+Element of the multiset with its number of occurrences, as written by
+MarshalJSON and MarshalBinary
+*/
+type occurrences[T any] struct {
+	Value T   `json:"value"`
+	Count int `json:"count"`
+}
+
+/*
+Struct that defines what a TreeMultiset is. Unlike a BinaryTree or a TreeSet,
+it keeps the elements that compare equal: each distinct element is stored once
+in a self-balancing tree, with its number of occurrences.
+The elements are always sorted according to the comparator, and the equal
+elements are returned next to each other. Add, Count and Remove run in O(log n)
+*/
+type TreeMultiset[T any] struct {
+	tree       *avl.AVLTree[*entry[T]]
+	comparator comparator.Comparator[T]
+	size       int
+	zeroValue  T
+}
+
+/*
+Create a new TreeMultiset. If the comparator is nil, the one registered for the type
+of the elements is used. If there is none, this function returns nil
+*/
+func New[T any](elementComparator comparator.Comparator[T], elements ...T) *TreeMultiset[T] {
+	elementComparator, err := comparator.Resolve(elementComparator)
+	if err != nil {
+		return nil
+	}
+
+	tree := avl.New(func(a, b *entry[T]) int {
+		return elementComparator(a.value, b.value)
+	})
+	multiset := &TreeMultiset[T]{tree: tree, comparator: elementComparator}
+	multiset.Add(elements...)
+	return multiset
+}
+
+/*
+Add one occurrence of each element in the multiset
+*/
+func (multiset *TreeMultiset[T]) Add(elements ...T) {
+	for _, element := range elements {
+		multiset.AddN(element, 1)
+	}
+}
+
+/*
+Add all elements present in the collection
+*/
+func (multiset *TreeMultiset[T]) AddAll(elements collection.Collection[T]) {
+	multiset.Add(elements.ToArray()...)
+}
+
+/*
+Add n occurrences of an element in the multiset. If n is negative or zero,
+the multiset is not modified
+*/
+func (multiset *TreeMultiset[T]) AddN(element T, n int) {
+	if n <= 0 {
+		return
+	}
+
+	if found := multiset.find(element); found != nil {
+		found.count += n
+	} else {
+		multiset.tree.Add(&entry[T]{value: element, count: n})
+	}
+	multiset.size += n
+}
+
+/*
+Return an iterator over the indexes and the elements of the multiset, in ascending order.
+An element is yielded as many times as it occurs. The iteration stops as soon as the loop body breaks
+*/
+func (multiset *TreeMultiset[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		index := 0
+		for entry := range multiset.tree.Values() {
+			for range entry.count {
+				if !yield(index, entry.value) {
+					return
+				}
+				index++
+			}
+		}
+	}
+}
+
+/*
+Retrieve an element by its position in ascending order, counting all the occurrences.
+It runs in O(d), where d is the number of distinct elements.
+If the index is negative or greater than the multiset size, the method will return an error
+*/
+func (multiset *TreeMultiset[T]) At(index int) (T, error) {
+	if index < 0 || index >= multiset.size {
+		return multiset.zeroValue, errors.New("index out of bound")
+	}
+
+	for entry := range multiset.tree.Values() {
+		if index < entry.count {
+			return entry.value, nil
+		}
+		index -= entry.count
+	}
+
+	return multiset.zeroValue, errors.New("index out of bound")
+}
+
+/*
+Clear all the elements in the multiset. After a clear, the multiset is totally empty
+*/
+func (multiset *TreeMultiset[T]) Clear() {
+	multiset.tree.Clear()
+	multiset.size = 0
+}
+
+/*
+Return true if the element occurs at least once in the multiset, else false
+*/
+func (multiset *TreeMultiset[T]) Contains(element T) bool {
+	return multiset.find(element) != nil
+}
+
+/*
+Return true if all elements of the collection are in the multiset, else false.
+The occurrences are not counted
+*/
+func (multiset *TreeMultiset[T]) ContainsAll(elements collection.Collection[T]) bool {
+	for _, element := range elements.ToArray() {
+		if !multiset.Contains(element) {
+			return false
+		}
+	}
+
+	return true
+}
+
+/*
+Return the number of occurrences of an element in the multiset
+*/
+func (multiset *TreeMultiset[T]) Count(element T) int {
+	if found := multiset.find(element); found != nil {
+		return found.count
+	}
+
+	return 0
+}
+
+/*
+Return the number of distinct elements in the multiset
+*/
+func (multiset *TreeMultiset[T]) DistinctSize() int {
+	return multiset.tree.Size()
+}
+
+/*
+Return an iterator over the distinct elements of the multiset with their
+number of occurrences, in ascending order.
+The iteration stops as soon as the loop body breaks
+*/
+func (multiset *TreeMultiset[T]) Entries() iter.Seq2[T, int] {
+	return func(yield func(T, int) bool) {
+		for entry := range multiset.tree.Values() {
+			if !yield(entry.value, entry.count) {
+				return
+			}
+		}
+	}
+}
+
+/*
+Call a function for each element of the multiset, in ascending order.
+An element is passed as many times as it occurs
+*/
+func (multiset *TreeMultiset[T]) ForEach(callback func(element T, index int)) {
+	for index, element := range multiset.All() {
+		callback(element, index)
+	}
+}
+
+/*
+Format the multiset for the fmt package: %v writes its elements, %+v also writes
+its size, and %#v writes a Go-syntax representation.
+The other verbs are applied to each element
+*/
+func (multiset *TreeMultiset[T]) Format(state fmt.State, verb rune) {
+	format.Format(state, verb, multiset, multiset.describe())
+}
+
+/*
+Decode the multiset from the gob encoding, see UnmarshalBinary
+*/
+func (multiset *TreeMultiset[T]) GobDecode(data []byte) error {
+	return multiset.UnmarshalBinary(data)
+}
+
+/*
+Encode the multiset for gob, see MarshalBinary
+*/
+func (multiset *TreeMultiset[T]) GobEncode() ([]byte, error) {
+	return multiset.MarshalBinary()
+}
+
+/*
+Return the position of the first occurrence of an element, or -1 if it is not in the multiset.
+It runs in O(d), where d is the number of distinct elements
+*/
+func (multiset *TreeMultiset[T]) IndexOf(element T) int {
+	index := 0
+	for entry := range multiset.tree.Values() {
+		diff := multiset.comparator(entry.value, element)
+		if diff == 0 {
+			return index
+		} else if diff > 0 {
+			break
+		}
+		index += entry.count
+	}
+
+	return -1
+}
+
+/*
+Return true if the multiset is empty, else false
+*/
+func (multiset *TreeMultiset[T]) IsEmpty() bool {
+	return multiset.size == 0
+}
+
+/*
+Encode the multiset in a binary format made of a versioned header followed by
+the gob encoding of its distinct elements with their number of occurrences
+*/
+func (multiset *TreeMultiset[T]) MarshalBinary() ([]byte, error) {
+	return serialization.Marshal(multiset.toOccurrences())
+}
+
+/*
+Encode the multiset as a JSON array of objects with a "value" and a "count" field,
+one for each distinct element in ascending order, e.g. [{"value":1,"count":2}]
+*/
+func (multiset *TreeMultiset[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(multiset.toOccurrences())
+}
+
+/*
+Return the greatest element of the multiset. If the multiset is empty, this method will return an error
+*/
+func (multiset *TreeMultiset[T]) Max() (T, error) {
+	max, err := multiset.tree.Max()
+	if err != nil {
+		return multiset.zeroValue, err
+	}
+
+	return max.value, nil
+}
+
+/*
+Return the smallest element of the multiset. If the multiset is empty, this method will return an error
+*/
+func (multiset *TreeMultiset[T]) Min() (T, error) {
+	min, err := multiset.tree.Min()
+	if err != nil {
+		return multiset.zeroValue, err
+	}
+
+	return min.value, nil
+}
+
+/*
+Print the elements of the multiset
+*/
+func (multiset *TreeMultiset[T]) Print() {
+	fmt.Println(multiset.String())
+}
+
+/*
+Remove one occurrence of an element, see RemoveOne
+*/
+func (multiset *TreeMultiset[T]) Remove(element T) {
+	multiset.RemoveOne(element)
+}
+
+/*
+Remove all the occurrences of an element.
+Return the number of removed occurrences
+*/
+func (multiset *TreeMultiset[T]) RemoveAll(element T) int {
+	found := multiset.find(element)
+	if found == nil {
+		return 0
+	}
+
+	multiset.tree.Remove(found)
+	multiset.size -= found.count
+	return found.count
+}
+
+/*
+Remove one occurrence of an element. The element leaves the multiset
+with its last occurrence. Return true if an occurrence was removed, else false
+*/
+func (multiset *TreeMultiset[T]) RemoveOne(element T) bool {
+	found := multiset.find(element)
+	if found == nil {
+		return false
+	}
+
+	if found.count == 1 {
+		multiset.tree.Remove(found)
+	} else {
+		found.count--
+	}
+	multiset.size--
+	return true
+}
+
+/*
+Return the total number of elements in the multiset, counting all the occurrences.
+Use DistinctSize to get the number of distinct elements
+*/
+func (multiset *TreeMultiset[T]) Size() int {
+	return multiset.size
+}
+
+/*
+Return the elements of the multiset as a string, e.g. [1, 1, 2] in ascending order
+*/
+func (multiset *TreeMultiset[T]) String() string {
+	return format.String(multiset.describe())
+}
+
+/*
+Return all the elements of the multiset in an array, in ascending order.
+An element appears as many times as it occurs
+*/
+func (multiset *TreeMultiset[T]) ToArray() []T {
+	elements := make([]T, 0, multiset.size)
+	for element := range multiset.Values() {
+		elements = append(elements, element)
+	}
+
+	return elements
+}
+
+/*
+Decode the binary format written by MarshalBinary into the multiset, replacing its
+content with the same number of occurrences for each element. The comparator of the
+multiset is kept, or the one registered for the type of the elements is used
+*/
+func (multiset *TreeMultiset[T]) UnmarshalBinary(data []byte) error {
+	var elements []occurrences[T]
+	if err := serialization.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	return multiset.load(elements)
+}
+
+/*
+Decode a JSON array of objects with a "value" and a "count" field into the multiset,
+replacing its content. The counts of an element present several times are added up.
+If a count is not strictly positive, this method will return an error. The comparator
+of the multiset is kept. If the multiset has no comparator, the one registered for
+the type of the elements is used
+*/
+func (multiset *TreeMultiset[T]) UnmarshalJSON(data []byte) error {
+	var elements []occurrences[T]
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	return multiset.load(elements)
+}
+
+/*
+Return an iterator over the elements of the multiset, in ascending order.
+An element is yielded as many times as it occurs. The iteration stops as soon as the loop body breaks
+*/
+func (multiset *TreeMultiset[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, element := range multiset.All() {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+/*
+Write the elements of the multiset, as returned by String, to the writer
+*/
+func (multiset *TreeMultiset[T]) WriteTo(writer io.Writer) (int64, error) {
+	return format.WriteTo(writer, multiset.describe())
+}
+
+// Private methods
+
+// Describe the multiset for the format package
+func (multiset *TreeMultiset[T]) describe() format.Container[int, T] {
+	return format.Container[int, T]{
+		Name:  "TreeMultiset",
+		Open:  "[",
+		Close: "]",
+		All:   multiset.All(),
+		Size:  multiset.size,
+	}
+}
+
+// Return the entry of an element, or nil if it is not in the multiset
+func (multiset *TreeMultiset[T]) find(element T) *entry[T] {
+	found, err := multiset.tree.Get(&entry[T]{value: element})
+	if err != nil {
+		return nil
+	}
+
+	return found
+}
+
+// Replace the content of the multiset by the elements, resolving the comparator if needed
+func (multiset *TreeMultiset[T]) load(elements []occurrences[T]) error {
+	for _, element := range elements {
+		if element.Count <= 0 {
+			return errors.New("invalid number of occurrences")
+		}
+	}
+
+	loaded := New(multiset.comparator)
+	if loaded == nil {
+		return comparator.ErrNoComparator
+	}
+
+	for _, element := range elements {
+		loaded.AddN(element.Value, element.Count)
+	}
+
+	*multiset = *loaded
+	return nil
+}
+
+// Return the distinct elements of the multiset with their number of occurrences, in ascending order
+func (multiset *TreeMultiset[T]) toOccurrences() []occurrences[T] {
+	elements := make([]occurrences[T], 0, multiset.DistinctSize())
+	for value, count := range multiset.Entries() {
+		elements = append(elements, occurrences[T]{Value: value, Count: count})
+	}
+
+	return elements
+}
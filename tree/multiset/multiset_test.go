@@ -0,0 +1,269 @@
+package multiset
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/dterbah/gods/list/arraylist"
+	comparator "github.com/dterbah/gods/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTreeMultisetAdd(t *testing.T) {
+	assert := assert.New(t)
+	multiset := New(comparator.IntComparator, 5, 3)
+
+	multiset.Add(5, 1, 5)
+
+	assert.Equal(5, multiset.Size())
+	assert.Equal(3, multiset.DistinctSize())
+	assert.Equal(3, multiset.Count(5))
+	assert.Equal([]int{1, 3, 5, 5, 5}, multiset.ToArray())
+}
+
+func TestTreeMultisetAddAll(t *testing.T) {
+	assert := assert.New(t)
+	multiset := New(comparator.IntComparator, 2)
+
+	multiset.AddAll(arraylist.New(comparator.IntComparator, 2, 1, 2))
+	assert.Equal([]int{1, 2, 2, 2}, multiset.ToArray())
+
+	list := arraylist.New(comparator.IntComparator)
+	list.AddAll(multiset)
+	assert.Equal([]int{1, 2, 2, 2}, list.ToArray())
+}
+
+func TestTreeMultisetAddN(t *testing.T) {
+	assert := assert.New(t)
+	multiset := New(comparator.IntComparator)
+
+	multiset.AddN(7, 3)
+	multiset.AddN(7, 2)
+	multiset.AddN(4, 0)
+	multiset.AddN(4, -1)
+
+	assert.Equal(5, multiset.Count(7))
+	assert.Equal(0, multiset.Count(4))
+	assert.False(multiset.Contains(4))
+	assert.Equal(5, multiset.Size())
+	assert.Equal(1, multiset.DistinctSize())
+}
+
+func TestTreeMultisetAt(t *testing.T) {
+	assert := assert.New(t)
+	multiset := New(comparator.IntComparator, 2, 1, 2, 3)
+
+	for index, expected := range []int{1, 2, 2, 3} {
+		element, err := multiset.At(index)
+		assert.Nil(err)
+		assert.Equal(expected, element)
+	}
+
+	_, err := multiset.At(4)
+	assert.NotNil(err)
+	_, err = multiset.At(-1)
+	assert.NotNil(err)
+}
+
+func TestTreeMultisetBinary(t *testing.T) {
+	assert := assert.New(t)
+	original := New(comparator.IntComparator, 3, 1, 3, 3)
+
+	data, err := original.MarshalBinary()
+	assert.Nil(err)
+
+	var decoded TreeMultiset[int]
+	assert.Nil(decoded.UnmarshalBinary(data))
+	assert.Equal([]int{1, 3, 3, 3}, decoded.ToArray())
+	assert.Equal(3, decoded.Count(3))
+
+	// Containers can be embedded in gob encoded structs
+	var buffer bytes.Buffer
+	assert.Nil(gob.NewEncoder(&buffer).Encode(struct{ Container *TreeMultiset[int] }{original}))
+
+	var checkpoint struct{ Container *TreeMultiset[int] }
+	assert.Nil(gob.NewDecoder(&buffer).Decode(&checkpoint))
+	assert.Equal([]int{1, 3, 3, 3}, checkpoint.Container.ToArray())
+
+	assert.NotNil(decoded.UnmarshalBinary([]byte("invalid")))
+}
+
+func TestTreeMultisetClear(t *testing.T) {
+	assert := assert.New(t)
+	multiset := New(comparator.IntComparator, 1, 1, 2)
+
+	multiset.Clear()
+	assert.True(multiset.IsEmpty())
+	assert.Equal(0, multiset.Size())
+	assert.Equal(0, multiset.DistinctSize())
+	assert.Equal(0, multiset.Count(1))
+}
+
+func TestTreeMultisetContainsAll(t *testing.T) {
+	assert := assert.New(t)
+	multiset := New(comparator.IntComparator, 1, 2)
+
+	assert.True(multiset.ContainsAll(arraylist.New(comparator.IntComparator, 1, 1, 2)))
+	assert.False(multiset.ContainsAll(arraylist.New(comparator.IntComparator, 1, 3)))
+}
+
+func TestTreeMultisetEntries(t *testing.T) {
+	assert := assert.New(t)
+	multiset := New(comparator.StringComparator, "b", "a", "b", "c", "b")
+	counts := map[string]int{}
+	keys := []string{}
+
+	for element, count := range multiset.Entries() {
+		keys = append(keys, element)
+		counts[element] = count
+	}
+
+	assert.Equal([]string{"a", "b", "c"}, keys)
+	assert.Equal(map[string]int{"a": 1, "b": 3, "c": 1}, counts)
+
+	for element := range multiset.Entries() {
+		assert.Equal("a", element)
+		break
+	}
+}
+
+func TestTreeMultisetForEach(t *testing.T) {
+	assert := assert.New(t)
+	multiset := New(comparator.IntComparator, 3, 1, 3)
+	elements := []int{}
+
+	multiset.ForEach(func(element, index int) {
+		assert.Equal(len(elements), index)
+		elements = append(elements, element)
+	})
+	assert.Equal([]int{1, 3, 3}, elements)
+}
+
+func TestTreeMultisetIndexOf(t *testing.T) {
+	assert := assert.New(t)
+	multiset := New(comparator.IntComparator, 1, 1, 2, 4, 4)
+
+	assert.Equal(0, multiset.IndexOf(1))
+	assert.Equal(2, multiset.IndexOf(2))
+	assert.Equal(3, multiset.IndexOf(4))
+	assert.Equal(-1, multiset.IndexOf(3))
+	assert.Equal(-1, multiset.IndexOf(5))
+}
+
+func TestTreeMultisetJSON(t *testing.T) {
+	assert := assert.New(t)
+	multiset := New(comparator.IntComparator, 3, 1, 3)
+
+	data, err := json.Marshal(multiset)
+	assert.Nil(err)
+	assert.Equal(`[{"value":1,"count":1},{"value":3,"count":2}]`, string(data))
+
+	var decoded TreeMultiset[int]
+	assert.Nil(json.Unmarshal([]byte(`[{"value":3,"count":2},{"value":1,"count":1},{"value":3,"count":1}]`), &decoded))
+	assert.Equal([]int{1, 3, 3, 3}, decoded.ToArray())
+	assert.Equal(2, decoded.DistinctSize())
+
+	assert.NotNil(json.Unmarshal([]byte(`[{"value":1,"count":0}]`), &decoded))
+	assert.Equal([]int{1, 3, 3, 3}, decoded.ToArray())
+
+	type point struct{ x, y int }
+	var points TreeMultiset[point]
+	assert.ErrorIs(points.UnmarshalJSON([]byte("[]")), comparator.ErrNoComparator)
+}
+
+func TestTreeMultisetMinMax(t *testing.T) {
+	assert := assert.New(t)
+	multiset := New(comparator.IntComparator)
+
+	_, err := multiset.Min()
+	assert.NotNil(err)
+	_, err = multiset.Max()
+	assert.NotNil(err)
+
+	multiset.Add(4, 9, 4, 1)
+	min, err := multiset.Min()
+	assert.Nil(err)
+	assert.Equal(1, min)
+	max, err := multiset.Max()
+	assert.Nil(err)
+	assert.Equal(9, max)
+}
+
+func TestTreeMultisetNew(t *testing.T) {
+	assert := assert.New(t)
+
+	// The registered comparator is used
+	multiset := New[int](nil, 2, 1, 2)
+	assert.Equal([]int{1, 2, 2}, multiset.ToArray())
+
+	type point struct{ x, y int }
+	assert.Nil(New[point](nil))
+}
+
+func TestTreeMultisetRemove(t *testing.T) {
+	assert := assert.New(t)
+	multiset := New(comparator.IntComparator, 1, 2, 2)
+
+	multiset.Remove(2)
+	assert.Equal([]int{1, 2}, multiset.ToArray())
+
+	multiset.Remove(3)
+	assert.Equal(2, multiset.Size())
+}
+
+func TestTreeMultisetRemoveAll(t *testing.T) {
+	assert := assert.New(t)
+	multiset := New(comparator.IntComparator, 1, 2, 2, 2, 3)
+
+	assert.Equal(3, multiset.RemoveAll(2))
+	assert.Equal(0, multiset.RemoveAll(2))
+	assert.False(multiset.Contains(2))
+	assert.Equal(2, multiset.Size())
+	assert.Equal(2, multiset.DistinctSize())
+	assert.Equal([]int{1, 3}, multiset.ToArray())
+}
+
+func TestTreeMultisetRemoveOne(t *testing.T) {
+	assert := assert.New(t)
+	multiset := New(comparator.IntComparator, 5, 5)
+
+	assert.True(multiset.RemoveOne(5))
+	assert.Equal(1, multiset.Count(5))
+	assert.Equal(1, multiset.DistinctSize())
+
+	assert.True(multiset.RemoveOne(5))
+	assert.False(multiset.Contains(5))
+	assert.Equal(0, multiset.DistinctSize())
+	assert.True(multiset.IsEmpty())
+
+	assert.False(multiset.RemoveOne(5))
+	assert.Equal(0, multiset.Size())
+}
+
+func TestTreeMultisetString(t *testing.T) {
+	assert := assert.New(t)
+	multiset := New(comparator.IntComparator, 2, 1, 2)
+
+	assert.Equal("[1, 2, 2]", multiset.String())
+	assert.Equal("TreeMultiset(size=3) [1, 2, 2]", fmt.Sprintf("%+v", multiset))
+	assert.Equal("[01, 02, 02]", fmt.Sprintf("%02d", multiset))
+	assert.Equal("[]", New(comparator.IntComparator).String())
+}
+
+func TestTreeMultisetValues(t *testing.T) {
+	assert := assert.New(t)
+	multiset := New(comparator.IntComparator, 3, 3, 3, 1)
+	elements := []int{}
+
+	for element := range multiset.Values() {
+		if len(elements) == 2 {
+			break
+		}
+		elements = append(elements, element)
+	}
+
+	assert.Equal([]int{1, 3}, elements)
+}
@@ -0,0 +1,728 @@
+package redblack
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/dterbah/gods/internal/format"
+	"github.com/dterbah/gods/internal/serialization"
+	comparator "github.com/dterbah/gods/utils"
+)
+
+type Node[T any] struct {
+	left   *Node[T]
+	right  *Node[T]
+	parent *Node[T]
+	value  T
+	red    bool
+}
+
+/*
+Struct that represents what is a RedBlackTree. It is a binary search tree
+where each node is colored in red or black. The coloring rules guarantee
+that the longest path from the root to a leaf is at most twice as long as
+the shortest one, so the height of the tree stays in O(log n).
+*/
+type RedBlackTree[T any] struct {
+	root       *Node[T]
+	size       int
+	comparator comparator.Comparator[T]
+	zeroValue  T
+}
+
+type RedBlackTreeIterator[T any] struct {
+	zeroValue   T
+	currentNode *Node[T]
+}
+
+// ---- RedBlackTreeIterator API ---- //
+func newIterator[T any](tree *RedBlackTree[T]) *RedBlackTreeIterator[T] {
+	return &RedBlackTreeIterator[T]{currentNode: tree.root, zeroValue: tree.zeroValue}
+}
+
+/*
+Return true if the current node of the iterator has a right node, else false
+*/
+func (iterator RedBlackTreeIterator[T]) HasRight() bool {
+	return iterator.currentNode != nil && iterator.currentNode.right != nil
+}
+
+/*
+Return true if the current node of the iterator has a left node, else false
+*/
+func (iterator RedBlackTreeIterator[T]) HasLeft() bool {
+	return iterator.currentNode != nil && iterator.currentNode.left != nil
+}
+
+/*
+Return true if the current node of the iterator has a parent, else false
+*/
+func (iterator RedBlackTreeIterator[T]) HasParent() bool {
+	return iterator.currentNode != nil && iterator.currentNode.parent != nil
+}
+
+/*
+Return the left value of the current node of the iterator. The iterator
+will automatically move to the left element
+*/
+func (iterator *RedBlackTreeIterator[T]) Left() (T, error) {
+	if !iterator.HasLeft() {
+		return iterator.zeroValue, errors.New("no left value available")
+	}
+
+	iterator.currentNode = iterator.currentNode.left
+
+	return iterator.currentNode.value, nil
+}
+
+/*
+Return the right value of the current node of the iterator. The iterator
+will automatically move to the right element
+*/
+func (iterator *RedBlackTreeIterator[T]) Right() (T, error) {
+	if !iterator.HasRight() {
+		return iterator.zeroValue, errors.New("no right value available")
+	}
+
+	iterator.currentNode = iterator.currentNode.right
+
+	return iterator.currentNode.value, nil
+}
+
+/*
+Return the parent value of the current node of the iterator. The iterator
+will automatically move to the parent element
+*/
+func (iterator *RedBlackTreeIterator[T]) Parent() (T, error) {
+	if !iterator.HasParent() {
+		return iterator.zeroValue, errors.New("no parent value available")
+	}
+
+	iterator.currentNode = iterator.currentNode.parent
+
+	return iterator.currentNode.value, nil
+}
+
+/*
+Return the current value of the current node of the iterator.
+*/
+func (iterator *RedBlackTreeIterator[T]) Current() (T, error) {
+	if iterator.currentNode == nil {
+		return iterator.zeroValue, errors.New("no current value available")
+	}
+
+	return iterator.currentNode.value, nil
+}
+
+// ---- Node API ---- //
+
+/*
+Return true if the node is red. The nil leaves are considered as black
+*/
+func isRed[T any](node *Node[T]) bool {
+	return node != nil && node.red
+}
+
+func (node *Node[T]) height() int {
+	if node == nil {
+		return 0
+	}
+
+	return 1 + max(node.left.height(), node.right.height())
+}
+
+func (node *Node[T]) minNode() *Node[T] {
+	current := node
+	for current.left != nil {
+		current = current.left
+	}
+
+	return current
+}
+
+func (node *Node[T]) maxNode() *Node[T] {
+	current := node
+	for current.right != nil {
+		current = current.right
+	}
+
+	return current
+}
+
+/*
+Visit the node and its children in order, stopping as soon as yield returns false.
+Return false if the traversal has been stopped
+*/
+func (node *Node[T]) inOrder(index *int, yield func(int, T) bool) bool {
+	if node == nil {
+		return true
+	}
+
+	if !node.left.inOrder(index, yield) {
+		return false
+	}
+
+	if !yield(*index, node.value) {
+		return false
+	}
+	*index++
+
+	return node.right.inOrder(index, yield)
+}
+
+/*
+Check the red-black invariants of the subtree. Return the number of black
+nodes on each path from the node to a leaf
+*/
+func (node *Node[T]) validate(comparator comparator.Comparator[T], lower, upper *Node[T]) (int, error) {
+	if node == nil {
+		return 1, nil
+	}
+
+	if lower != nil && comparator(lower.value, node.value) >= 0 {
+		return 0, fmt.Errorf("node %v is not greater than %v", node.value, lower.value)
+	}
+
+	if upper != nil && comparator(upper.value, node.value) <= 0 {
+		return 0, fmt.Errorf("node %v is not less than %v", node.value, upper.value)
+	}
+
+	for _, child := range []*Node[T]{node.left, node.right} {
+		if child == nil {
+			continue
+		}
+
+		if child.parent != node {
+			return 0, fmt.Errorf("node %v has a wrong parent", child.value)
+		}
+
+		if node.red && child.red {
+			return 0, fmt.Errorf("red node %v has a red child", node.value)
+		}
+	}
+
+	leftHeight, err := node.left.validate(comparator, lower, node)
+	if err != nil {
+		return 0, err
+	}
+
+	rightHeight, err := node.right.validate(comparator, node, upper)
+	if err != nil {
+		return 0, err
+	}
+
+	if leftHeight != rightHeight {
+		return 0, fmt.Errorf("node %v has different black heights", node.value)
+	}
+
+	if node.red {
+		return leftHeight, nil
+	}
+
+	return leftHeight + 1, nil
+}
+
+// ---- RedBlackTree API ---- //
+
+/*
+Create a new Red-Black tree
+*/
+func New[T any](comparator comparator.Comparator[T]) *RedBlackTree[T] {
+	var zero T
+	return &RedBlackTree[T]{comparator: comparator, zeroValue: zero}
+}
+
+/*
+Add values in the tree. The values already present in the tree are ignored
+*/
+func (tree *RedBlackTree[T]) Add(values ...T) {
+	for _, value := range values {
+		tree.insert(value)
+	}
+}
+
+/*
+Return an iterator over the values of the tree in sorted order, with their
+in-order position. The iteration stops as soon as the loop body breaks
+*/
+func (tree *RedBlackTree[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		index := 0
+		tree.root.inOrder(&index, yield)
+	}
+}
+
+/*
+Remove all the values of the tree
+*/
+func (tree *RedBlackTree[T]) Clear() {
+	tree.root = nil
+	tree.size = 0
+}
+
+/*
+Format the tree for the fmt package: %v writes its elements, %+v also writes
+its size, and %#v writes a Go-syntax representation.
+The other verbs are applied to each element
+*/
+func (tree *RedBlackTree[T]) Format(state fmt.State, verb rune) {
+	format.Format(state, verb, tree, tree.describe())
+}
+
+/*
+Decode the tree from the gob encoding, see UnmarshalBinary
+*/
+func (tree *RedBlackTree[T]) GobDecode(data []byte) error {
+	return tree.UnmarshalBinary(data)
+}
+
+/*
+Encode the tree for gob, see MarshalBinary
+*/
+func (tree *RedBlackTree[T]) GobEncode() ([]byte, error) {
+	return tree.MarshalBinary()
+}
+
+/*
+Check if a value is present in the tree. Return true if the value is present,
+else false
+*/
+func (tree *RedBlackTree[T]) Has(value T) bool {
+	return tree.find(value) != nil
+}
+
+/*
+Return the height of the tree, i.e. the number of nodes on the longest
+path from the root to a leaf. An empty tree has a height of 0
+*/
+func (tree *RedBlackTree[T]) Height() int {
+	return tree.root.height()
+}
+
+/*
+Return true if the tree has no values, else false
+*/
+func (tree *RedBlackTree[T]) IsEmpty() bool {
+	return tree.root == nil
+}
+
+/*
+Return a structural iterator positioned on the root of the tree
+*/
+func (tree *RedBlackTree[T]) Iterator() *RedBlackTreeIterator[T] {
+	return newIterator(tree)
+}
+
+/*
+Encode the tree in a binary format made of a versioned header
+followed by the gob encoding of its values
+*/
+func (tree *RedBlackTree[T]) MarshalBinary() ([]byte, error) {
+	values := make([]T, 0, tree.Size())
+	for value := range tree.Values() {
+		values = append(values, value)
+	}
+
+	return serialization.Marshal(values)
+}
+
+/*
+Encode the tree as a JSON array of its values, in ascending order
+*/
+func (tree *RedBlackTree[T]) MarshalJSON() ([]byte, error) {
+	values := make([]T, 0, tree.Size())
+	for value := range tree.Values() {
+		values = append(values, value)
+	}
+
+	return json.Marshal(values)
+}
+
+/*
+Find the maximum value present in the tree
+*/
+func (tree *RedBlackTree[T]) Max() (T, error) {
+	if tree.root == nil {
+		return tree.zeroValue, errors.New("empty tree")
+	}
+
+	return tree.root.maxNode().value, nil
+}
+
+/*
+Find the minimum value present in the tree
+*/
+func (tree *RedBlackTree[T]) Min() (T, error) {
+	if tree.root == nil {
+		return tree.zeroValue, errors.New("empty tree")
+	}
+
+	return tree.root.minNode().value, nil
+}
+
+/*
+Remove the specified element in the tree if it exists
+Return true if the value was removed, else false
+*/
+func (tree *RedBlackTree[T]) Remove(value T) bool {
+	node := tree.find(value)
+	if node == nil {
+		return false
+	}
+
+	var child, childParent *Node[T]
+	removedRed := node.red
+
+	if node.left == nil {
+		child = node.right
+		childParent = node.parent
+		tree.transplant(node, node.right)
+	} else if node.right == nil {
+		child = node.left
+		childParent = node.parent
+		tree.transplant(node, node.left)
+	} else {
+		// Node with two children: the in-order successor takes its place
+		successor := node.right.minNode()
+		removedRed = successor.red
+		child = successor.right
+
+		if successor.parent == node {
+			childParent = successor
+		} else {
+			childParent = successor.parent
+			tree.transplant(successor, successor.right)
+			successor.right = node.right
+			successor.right.parent = successor
+		}
+
+		tree.transplant(node, successor)
+		successor.left = node.left
+		successor.left.parent = successor
+		successor.red = node.red
+	}
+
+	if !removedRed {
+		tree.removeFixup(child, childParent)
+	}
+
+	tree.size--
+
+	return true
+}
+
+/*
+Return the number of values in the tree
+*/
+func (tree *RedBlackTree[T]) Size() int {
+	return tree.size
+}
+
+/*
+Return the elements of the tree as a string, e.g. [1, 2, 3] in ascending order
+*/
+func (tree *RedBlackTree[T]) String() string {
+	return format.String(tree.describe())
+}
+
+/*
+Decode the binary format written by MarshalBinary into the tree, replacing its content.
+The tree is rebalanced while the values are inserted. The comparator of the tree is kept,
+or the one registered for the type of the values is used
+*/
+func (tree *RedBlackTree[T]) UnmarshalBinary(data []byte) error {
+	var values []T
+	if err := serialization.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	return tree.load(values)
+}
+
+/*
+Decode a JSON array of values into the tree, replacing its content. The duplicated
+values are ignored. The comparator of the tree is kept. If the tree has no comparator,
+the one registered for the type of the values is used
+*/
+func (tree *RedBlackTree[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	return tree.load(values)
+}
+
+/*
+Check that the tree respects all the red-black invariants: the values are ordered,
+the parent links are consistent, the root is black, a red node has no red child and
+every path from a node to its leaves has the same number of black nodes.
+Return an error describing the first violation found
+*/
+func (tree *RedBlackTree[T]) Validate() error {
+	if tree.root == nil {
+		return nil
+	}
+
+	if tree.root.parent != nil {
+		return errors.New("root has a parent")
+	}
+
+	if tree.root.red {
+		return errors.New("root is red")
+	}
+
+	_, err := tree.root.validate(tree.comparator, nil, nil)
+	return err
+}
+
+/*
+Return an iterator over the values of the tree in sorted order.
+The iteration stops as soon as the loop body breaks
+*/
+func (tree *RedBlackTree[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, value := range tree.All() {
+			if !yield(value) {
+				return
+			}
+		}
+	}
+}
+
+/*
+Write the elements of the tree, as returned by String, to the writer
+*/
+func (tree *RedBlackTree[T]) WriteTo(writer io.Writer) (int64, error) {
+	return format.WriteTo(writer, tree.describe())
+}
+
+// Private methods
+
+// Describe the tree for the format package
+func (tree *RedBlackTree[T]) describe() format.Container[int, T] {
+	return format.Container[int, T]{
+		Name:  "RedBlackTree",
+		Open:  "[",
+		Close: "]",
+		All:   tree.All(),
+		Size:  tree.Size(),
+	}
+}
+
+func (tree *RedBlackTree[T]) find(value T) *Node[T] {
+	node := tree.root
+	for node != nil {
+		diff := tree.comparator(node.value, value)
+		if diff == 0 {
+			return node
+		} else if diff > 0 {
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+
+	return nil
+}
+
+func (tree *RedBlackTree[T]) insert(value T) {
+	var parent *Node[T]
+	diff := 0
+	node := tree.root
+
+	for node != nil {
+		parent = node
+		diff = tree.comparator(node.value, value)
+		if diff == 0 {
+			return
+		} else if diff > 0 {
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+
+	node = &Node[T]{value: value, parent: parent, red: true}
+	if parent == nil {
+		tree.root = node
+	} else if diff > 0 {
+		parent.left = node
+	} else {
+		parent.right = node
+	}
+
+	tree.size++
+	tree.insertFixup(node)
+}
+
+// Restore the red-black invariants after the insertion of a red node
+func (tree *RedBlackTree[T]) insertFixup(node *Node[T]) {
+	for isRed(node.parent) {
+		grandParent := node.parent.parent
+
+		if node.parent == grandParent.left {
+			uncle := grandParent.right
+			if isRed(uncle) {
+				node.parent.red = false
+				uncle.red = false
+				grandParent.red = true
+				node = grandParent
+				continue
+			}
+
+			if node == node.parent.right {
+				node = node.parent
+				tree.rotateLeft(node)
+			}
+
+			node.parent.red = false
+			grandParent.red = true
+			tree.rotateRight(grandParent)
+		} else {
+			uncle := grandParent.left
+			if isRed(uncle) {
+				node.parent.red = false
+				uncle.red = false
+				grandParent.red = true
+				node = grandParent
+				continue
+			}
+
+			if node == node.parent.left {
+				node = node.parent
+				tree.rotateRight(node)
+			}
+
+			node.parent.red = false
+			grandParent.red = true
+			tree.rotateLeft(grandParent)
+		}
+	}
+
+	tree.root.red = false
+}
+
+// Replace the content of the tree by the values, resolving the comparator if needed
+func (tree *RedBlackTree[T]) load(values []T) error {
+	comparator, err := comparator.Resolve(tree.comparator)
+	if err != nil {
+		return err
+	}
+
+	*tree = *New(comparator)
+	tree.Add(values...)
+	return nil
+}
+
+/*
+Restore the red-black invariants after the removal of a black node. The node
+taking its place may be a nil leaf, so its parent is passed explicitly
+*/
+func (tree *RedBlackTree[T]) removeFixup(node *Node[T], parent *Node[T]) {
+	for node != tree.root && !isRed(node) {
+		if node == parent.left {
+			sibling := parent.right
+			if isRed(sibling) {
+				sibling.red = false
+				parent.red = true
+				tree.rotateLeft(parent)
+				sibling = parent.right
+			}
+
+			if !isRed(sibling.left) && !isRed(sibling.right) {
+				sibling.red = true
+				node = parent
+				parent = node.parent
+				continue
+			}
+
+			if !isRed(sibling.right) {
+				sibling.left.red = false
+				sibling.red = true
+				tree.rotateRight(sibling)
+				sibling = parent.right
+			}
+
+			sibling.red = parent.red
+			parent.red = false
+			sibling.right.red = false
+			tree.rotateLeft(parent)
+		} else {
+			sibling := parent.left
+			if isRed(sibling) {
+				sibling.red = false
+				parent.red = true
+				tree.rotateRight(parent)
+				sibling = parent.left
+			}
+
+			if !isRed(sibling.left) && !isRed(sibling.right) {
+				sibling.red = true
+				node = parent
+				parent = node.parent
+				continue
+			}
+
+			if !isRed(sibling.left) {
+				sibling.right.red = false
+				sibling.red = true
+				tree.rotateLeft(sibling)
+				sibling = parent.left
+			}
+
+			sibling.red = parent.red
+			parent.red = false
+			sibling.left.red = false
+			tree.rotateRight(parent)
+		}
+
+		node = tree.root
+	}
+
+	if node != nil {
+		node.red = false
+	}
+}
+
+func (tree *RedBlackTree[T]) rotateLeft(node *Node[T]) {
+	newRoot := node.right
+	node.right = newRoot.left
+	if newRoot.left != nil {
+		newRoot.left.parent = node
+	}
+
+	tree.transplant(node, newRoot)
+	newRoot.left = node
+	node.parent = newRoot
+}
+
+func (tree *RedBlackTree[T]) rotateRight(node *Node[T]) {
+	newRoot := node.left
+	node.left = newRoot.right
+	if newRoot.right != nil {
+		newRoot.right.parent = node
+	}
+
+	tree.transplant(node, newRoot)
+	newRoot.right = node
+	node.parent = newRoot
+}
+
+// Replace the subtree rooted at node by the one rooted at replacement
+func (tree *RedBlackTree[T]) transplant(node *Node[T], replacement *Node[T]) {
+	if node.parent == nil {
+		tree.root = replacement
+	} else if node == node.parent.left {
+		node.parent.left = replacement
+	} else {
+		node.parent.right = replacement
+	}
+
+	if replacement != nil {
+		replacement.parent = node.parent
+	}
+}
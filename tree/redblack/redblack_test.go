@@ -0,0 +1,242 @@
+package redblack
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+
+	comparator "github.com/dterbah/gods/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedBlackTreeAdd(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+
+	tree.Add(1, 2, 2, 3)
+
+	assert.Nil(tree.Validate())
+	assert.Equal(3, tree.Size())
+	assert.True(tree.Has(1))
+	assert.True(tree.Has(3))
+	assert.False(tree.Has(4))
+}
+
+func TestRedBlackTreeAddSorted(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+
+	for i := 0; i < 1024; i++ {
+		tree.Add(i)
+	}
+
+	assert.Nil(tree.Validate())
+	// The height of a red-black tree is at most 2 * log2(n + 1)
+	assert.LessOrEqual(tree.Height(), 20)
+}
+
+func TestRedBlackTreeBinary(t *testing.T) {
+	assert := assert.New(t)
+	original := New(comparator.IntComparator)
+	original.Add(5, 3, 8, 1)
+
+	data, err := original.MarshalBinary()
+	assert.Nil(err)
+
+	var decoded RedBlackTree[int]
+	assert.Nil(decoded.UnmarshalBinary(data))
+	assert.Equal([]int{1, 3, 5, 8}, slices.Collect(decoded.Values()))
+
+	// Containers can be embedded in gob encoded structs
+	var buffer bytes.Buffer
+	assert.Nil(gob.NewEncoder(&buffer).Encode(struct{ Container *RedBlackTree[int] }{original}))
+
+	var checkpoint struct{ Container *RedBlackTree[int] }
+	assert.Nil(gob.NewDecoder(&buffer).Decode(&checkpoint))
+	decoded = *checkpoint.Container
+	assert.Equal([]int{1, 3, 5, 8}, slices.Collect(decoded.Values()))
+
+	assert.NotNil(decoded.UnmarshalBinary([]byte("invalid")))
+}
+
+func TestRedBlackTreeClear(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+	tree.Add(1, 2)
+	tree.Clear()
+
+	assert.True(tree.IsEmpty())
+	assert.Equal(0, tree.Size())
+	assert.Equal(0, tree.Height())
+}
+
+func TestRedBlackTreeIterator(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+
+	iterator := tree.Iterator()
+	_, err := iterator.Current()
+	assert.NotNil(err)
+	_, err = iterator.Left()
+	assert.NotNil(err)
+
+	tree.Add(1, 2, 3)
+
+	iterator = tree.Iterator()
+	value, err := iterator.Current()
+	assert.Nil(err)
+	assert.Equal(2, value)
+	assert.False(iterator.HasParent())
+
+	value, err = iterator.Left()
+	assert.Nil(err)
+	assert.Equal(1, value)
+	assert.False(iterator.HasLeft())
+
+	value, err = iterator.Parent()
+	assert.Nil(err)
+	assert.Equal(2, value)
+
+	value, err = iterator.Right()
+	assert.Nil(err)
+	assert.Equal(3, value)
+	assert.False(iterator.HasRight())
+	_, err = iterator.Right()
+	assert.NotNil(err)
+}
+
+func TestRedBlackTreeJSON(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+	tree.Add(5, 3, 8, 1)
+
+	data, err := json.Marshal(tree)
+	assert.Nil(err)
+	assert.Equal("[1,3,5,8]", string(data))
+
+	var decoded RedBlackTree[int]
+	assert.Nil(json.Unmarshal([]byte("[8,1,5,3,3]"), &decoded))
+	assert.Equal(4, decoded.Size())
+	assert.Nil(decoded.Validate())
+
+	encoded, _ := json.Marshal(&decoded)
+	assert.Equal(data, encoded)
+}
+
+func TestRedBlackTreeMinMax(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+
+	_, err := tree.Min()
+	assert.NotNil(err)
+	_, err = tree.Max()
+	assert.NotNil(err)
+
+	tree.Add(3, -1, 8, 4)
+
+	min, err := tree.Min()
+	assert.Nil(err)
+	assert.Equal(-1, min)
+
+	max, err := tree.Max()
+	assert.Nil(err)
+	assert.Equal(8, max)
+}
+
+func TestRedBlackTreeRemove(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+
+	assert.False(tree.Remove(1))
+
+	tree.Add(3, 2, 1, 6, 4, 7, 5, -1)
+	assert.True(tree.Remove(6))
+	assert.False(tree.Remove(6))
+	assert.True(tree.Remove(3))
+	assert.Nil(tree.Validate())
+	assert.Equal([]int{-1, 1, 2, 4, 5, 7}, slices.Collect(tree.Values()))
+}
+
+func TestRedBlackTreeRandomOperations(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+	random := rand.New(rand.NewSource(42))
+	expected := map[int]bool{}
+
+	for i := 0; i < 5000; i++ {
+		value := random.Intn(500)
+		if random.Intn(3) == 0 {
+			assert.Equal(expected[value], tree.Remove(value))
+			delete(expected, value)
+		} else {
+			tree.Add(value)
+			expected[value] = true
+		}
+
+		if err := tree.Validate(); err != nil {
+			assert.Fail(err.Error())
+			return
+		}
+	}
+
+	values := slices.Collect(tree.Values())
+	assert.Equal(len(expected), tree.Size())
+	assert.Equal(len(expected), len(values))
+	assert.True(slices.IsSorted(values))
+}
+
+func TestRedBlackTreeString(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+	tree.Add(2, 1, 3)
+
+	assert.Equal("[1, 2, 3]", tree.String())
+	assert.Equal("[1, 2, 3]", fmt.Sprint(tree))
+	assert.Equal("RedBlackTree(size=3) [1, 2, 3]", fmt.Sprintf("%+v", tree))
+	assert.Equal(`&redblack.RedBlackTree[int]{1, 2, 3}`, fmt.Sprintf("%#v", tree))
+
+	var builder strings.Builder
+	count, err := tree.WriteTo(&builder)
+	assert.Nil(err)
+	assert.Equal(int64(len("[1, 2, 3]")), count)
+	assert.Equal("[1, 2, 3]", builder.String())
+}
+
+func TestRedBlackTreeValidate(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+	assert.Nil(tree.Validate())
+
+	tree.Add(1, 2, 3)
+	tree.root.red = true
+	assert.NotNil(tree.Validate())
+
+	tree.root.red = false
+	tree.root.left.red = false
+	assert.NotNil(tree.Validate())
+
+	tree.root.left.red = true
+	tree.root.left.value = 5
+	assert.NotNil(tree.Validate())
+}
+
+func TestRedBlackTreeValues(t *testing.T) {
+	assert := assert.New(t)
+	tree := New(comparator.IntComparator)
+	tree.Add(4, 2, 3, 1)
+
+	values := []int{}
+	for value := range tree.Values() {
+		if value == 3 {
+			break
+		}
+		values = append(values, value)
+	}
+
+	assert.Equal([]int{1, 2}, values)
+}
@@ -0,0 +1,81 @@
+package comparator
+
+/*
+Create a comparator of T comparing the keys extracted by the key function,
+e.g. Comparing(func(user User) string { return user.Name }, StringComparator)
+*/
+func Comparing[T, K any](key func(element T) K, comparator Comparator[K]) Comparator[T] {
+	return func(a, b T) int {
+		return comparator(key(a), key(b))
+	}
+}
+
+/*
+Create a comparator of pointers where nil is less than any other pointer.
+The pointed values are compared with the comparator in parameter
+*/
+func NilsFirst[T any](comparator Comparator[T]) Comparator[*T] {
+	return func(a, b *T) int {
+		if a == nil || b == nil {
+			return compareNils(a, b)
+		}
+
+		return comparator(*a, *b)
+	}
+}
+
+/*
+Create a comparator of pointers where nil is greater than any other pointer.
+The pointed values are compared with the comparator in parameter
+*/
+func NilsLast[T any](comparator Comparator[T]) Comparator[*T] {
+	return func(a, b *T) int {
+		if a == nil || b == nil {
+			return -compareNils(a, b)
+		}
+
+		return comparator(*a, *b)
+	}
+}
+
+/*
+Create a comparator in the reverse order of the one in parameter
+*/
+func Reverse[T any](comparator Comparator[T]) Comparator[T] {
+	return func(a, b T) int {
+		return comparator(b, a)
+	}
+}
+
+/*
+Create a comparator using the first comparator, then the next ones in their
+order to break the ties, e.g. to sort by multiple keys
+*/
+func ThenComparing[T any](first Comparator[T], next ...Comparator[T]) Comparator[T] {
+	return func(a, b T) int {
+		if result := first(a, b); result != 0 {
+			return result
+		}
+
+		for _, comparator := range next {
+			if result := comparator(a, b); result != 0 {
+				return result
+			}
+		}
+
+		return 0
+	}
+}
+
+// Private functions
+
+// Compare two pointers, one of them being nil, with nil first
+func compareNils[T any](a, b *T) int {
+	if a == nil && b == nil {
+		return 0
+	} else if a == nil {
+		return -1
+	} else {
+		return 1
+	}
+}
@@ -0,0 +1,66 @@
+package comparator
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type user struct {
+	name string
+	age  int
+}
+
+func TestComparing(t *testing.T) {
+	assert := assert.New(t)
+	byAge := Comparing(func(user user) int { return user.age }, IntComparator)
+
+	assert.Equal(-1, byAge(user{"b", 20}, user{"a", 30}))
+	assert.Equal(0, byAge(user{"b", 20}, user{"a", 20}))
+}
+
+func TestNilsFirst(t *testing.T) {
+	assert := assert.New(t)
+	comparator := NilsFirst(IntComparator)
+	one, two := 1, 2
+
+	assert.Equal(-1, comparator(nil, &one))
+	assert.Equal(1, comparator(&one, nil))
+	assert.Equal(0, comparator(nil, nil))
+	assert.Equal(-1, comparator(&one, &two))
+}
+
+func TestNilsLast(t *testing.T) {
+	assert := assert.New(t)
+	comparator := NilsLast(IntComparator)
+	one, two := 1, 2
+
+	assert.Equal(1, comparator(nil, &one))
+	assert.Equal(-1, comparator(&one, nil))
+	assert.Equal(0, comparator(nil, nil))
+	assert.Equal(1, comparator(&two, &one))
+}
+
+func TestReverse(t *testing.T) {
+	assert := assert.New(t)
+	comparator := Reverse(StringComparator)
+
+	assert.Equal(1, comparator("a", "b"))
+	assert.Equal(-1, comparator("b", "a"))
+	assert.Equal(0, comparator("a", "a"))
+}
+
+func TestThenComparing(t *testing.T) {
+	assert := assert.New(t)
+	users := []user{{"bob", 30}, {"alice", 30}, {"carl", 20}, {"alice", 25}}
+
+	slices.SortFunc(users, ThenComparing(
+		Reverse(Comparing(func(user user) int { return user.age }, IntComparator)),
+		Comparing(func(user user) string { return user.name }, StringComparator),
+	))
+	assert.Equal([]user{{"alice", 30}, {"bob", 30}, {"alice", 25}, {"carl", 20}}, users)
+
+	comparator := ThenComparing(IntComparator)
+	assert.Equal(0, comparator(1, 1))
+}
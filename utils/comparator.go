@@ -1,6 +1,13 @@
 package comparator
 
-import "strings"
+import (
+	"bytes"
+	"cmp"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
 
 type Comparator[T any] func(a, b T) int
 
@@ -15,26 +22,132 @@ func IntComparator(a, b int) int {
 
 }
 
+/*
+Compare two float32. NaN is considered equal to NaN and less than
+any other value, so that the containers stay consistent
+*/
 func Float32Comparator(a, b float32) int {
-	if a < b {
-		return -1
-	} else if a > b {
-		return 1
-	} else {
-		return 0
-	}
+	return cmp.Compare(a, b)
 }
 
+/*
+Compare two float64. NaN is considered equal to NaN and less than
+any other value, so that the containers stay consistent
+*/
 func Float64Comparator(a, b float64) int {
-	if a < b {
+	return cmp.Compare(a, b)
+}
+
+func StringComparator(a, b string) int {
+	return strings.Compare(a, b)
+}
+
+/*
+Compare two booleans, false being less than true
+*/
+func BoolComparator(a, b bool) int {
+	if a == b {
+		return 0
+	} else if !a {
 		return -1
-	} else if a > b {
-		return 1
 	} else {
-		return 0
+		return 1
 	}
 }
 
-func StringComparator(a, b string) int {
-	return strings.Compare(a, b)
+/*
+Compare two byte slices lexicographically, a nil slice being equal to an empty one
+*/
+func BytesComparator(a, b []byte) int {
+	return bytes.Compare(a, b)
+}
+
+/*
+Compare two strings without taking the case into account, e.g. "abc" and "ABC" are equal
+*/
+func CaseInsensitiveStringComparator(a, b string) int {
+	for a != "" && b != "" {
+		runeA, sizeA := utf8.DecodeRuneInString(a)
+		runeB, sizeB := utf8.DecodeRuneInString(b)
+		if result := cmp.Compare(unicode.ToLower(runeA), unicode.ToLower(runeB)); result != 0 {
+			return result
+		}
+		a, b = a[sizeA:], b[sizeB:]
+	}
+
+	return cmp.Compare(len(a), len(b))
+}
+
+/*
+Compare two durations
+*/
+func DurationComparator(a, b time.Duration) int {
+	return cmp.Compare(a, b)
+}
+
+/*
+Compare two strings in the natural order: the sequences of digits are compared
+by their numeric value, e.g. "file2" is less than "file10". When two numbers
+have the same value, the one with fewer leading zeros comes first
+*/
+func NaturalStringComparator(a, b string) int {
+	for a != "" && b != "" {
+		if isDigit(a[0]) && isDigit(b[0]) {
+			numberA, restA := splitNumber(a)
+			numberB, restB := splitNumber(b)
+			valueA := strings.TrimLeft(numberA, "0")
+			valueB := strings.TrimLeft(numberB, "0")
+
+			if result := cmp.Compare(len(valueA), len(valueB)); result != 0 {
+				return result
+			}
+			if result := strings.Compare(valueA, valueB); result != 0 {
+				return result
+			}
+			if result := cmp.Compare(len(numberA), len(numberB)); result != 0 {
+				return result
+			}
+
+			a, b = restA, restB
+			continue
+		}
+
+		if result := cmp.Compare(a[0], b[0]); result != 0 {
+			return result
+		}
+		a, b = a[1:], b[1:]
+	}
+
+	return cmp.Compare(len(a), len(b))
+}
+
+/*
+Compare two values of an ordered type with cmp.Compare.
+For the floats, NaN is equal to NaN and less than any other value
+*/
+func Ordered[T cmp.Ordered](a, b T) int {
+	return cmp.Compare(a, b)
+}
+
+/*
+Compare two instants
+*/
+func TimeComparator(a, b time.Time) int {
+	return a.Compare(b)
+}
+
+// Private functions
+
+func isDigit(character byte) bool {
+	return '0' <= character && character <= '9'
+}
+
+// Split a string starting with a digit into its leading number and the rest
+func splitNumber(value string) (string, string) {
+	end := 1
+	for end < len(value) && isDigit(value[end]) {
+		end++
+	}
+
+	return value[:end], value[end:]
 }
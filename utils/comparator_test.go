@@ -0,0 +1,89 @@
+package comparator
+
+import (
+	"math"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoolComparator(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(-1, BoolComparator(false, true))
+	assert.Equal(1, BoolComparator(true, false))
+	assert.Equal(0, BoolComparator(true, true))
+}
+
+func TestBytesComparator(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(-1, BytesComparator([]byte("ab"), []byte("b")))
+	assert.Equal(1, BytesComparator([]byte("ab"), []byte("a")))
+	assert.Equal(0, BytesComparator(nil, []byte{}))
+}
+
+func TestCaseInsensitiveStringComparator(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(0, CaseInsensitiveStringComparator("Hello", "hELLO"))
+	assert.Equal(0, CaseInsensitiveStringComparator("ÉTÉ", "été"))
+	assert.Equal(-1, CaseInsensitiveStringComparator("apple", "Banana"))
+	assert.Equal(1, CaseInsensitiveStringComparator("Zoo", "apple"))
+	assert.Equal(-1, CaseInsensitiveStringComparator("ab", "ABC"))
+}
+
+func TestDurationComparator(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(-1, DurationComparator(time.Second, time.Minute))
+	assert.Equal(0, DurationComparator(time.Second, 1000*time.Millisecond))
+}
+
+func TestFloat64Comparator(t *testing.T) {
+	assert := assert.New(t)
+	nan := math.NaN()
+
+	assert.Equal(-1, Float64Comparator(1.5, 2))
+	assert.Equal(0, Float64Comparator(nan, nan))
+	assert.Equal(-1, Float64Comparator(nan, math.Inf(-1)))
+	assert.Equal(1, Float64Comparator(0, nan))
+	assert.Equal(0, Float32Comparator(float32(nan), float32(nan)))
+	assert.Equal(-1, Float32Comparator(float32(nan), 0))
+}
+
+func TestNaturalStringComparator(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(-1, NaturalStringComparator("file2", "file10"))
+	assert.Equal(1, NaturalStringComparator("file10", "file9"))
+	assert.Equal(0, NaturalStringComparator("a1b2", "a1b2"))
+	assert.Equal(-1, NaturalStringComparator("file1", "file01"))
+	assert.Equal(-1, NaturalStringComparator("file", "file1"))
+	assert.Equal(-1, NaturalStringComparator("1a", "a"))
+	assert.Equal(1, NaturalStringComparator("x100000000000000000000000", "x99999999999999999999999"))
+
+	files := []string{"file10.txt", "file2.txt", "file1.txt", "file02.txt"}
+	slices.SortFunc(files, NaturalStringComparator)
+	assert.Equal([]string{"file1.txt", "file2.txt", "file02.txt", "file10.txt"}, files)
+}
+
+func TestOrdered(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(-1, Ordered(int8(1), 2))
+	assert.Equal(1, Ordered(uint64(3), 2))
+	assert.Equal(0, Ordered("a", "a"))
+	assert.Equal(-1, Ordered(math.NaN(), 0))
+}
+
+func TestTimeComparator(t *testing.T) {
+	assert := assert.New(t)
+	now := time.Now()
+
+	assert.Equal(-1, TimeComparator(now, now.Add(time.Second)))
+	assert.Equal(1, TimeComparator(now, now.Add(-time.Second)))
+	assert.Equal(0, TimeComparator(now.UTC(), now.In(time.FixedZone("X", 3600))))
+}
@@ -0,0 +1,36 @@
+package comparator
+
+import "errors"
+
+/*
+Function that reports whether two elements are equal. It is enough for the containers
+operations relying on equality only (Contains, IndexOf, Remove), for the types that
+have no natural ordering
+*/
+type Equaler[T any] func(a, b T) bool
+
+/*
+Error returned when sorting a container created with an Equaler, as it has no ordering
+*/
+var ErrUnordered = errors.New("the elements have no ordering: the container was created with an equaler")
+
+/*
+Report whether two comparable elements are equal with the == operator
+*/
+func Equal[T comparable](a, b T) bool {
+	return a == b
+}
+
+/*
+Create a comparator from an equaler. It returns 0 for equal elements and 1 for
+the others, so it only reports the equality and must not be used to order elements
+*/
+func FromEqualer[T any](equaler Equaler[T]) Comparator[T] {
+	return func(a, b T) int {
+		if equaler(a, b) {
+			return 0
+		}
+
+		return 1
+	}
+}
@@ -0,0 +1,23 @@
+package comparator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqual(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(Equal(point{1, 2}, point{1, 2}))
+	assert.False(Equal(point{1, 2}, point{2, 1}))
+}
+
+func TestFromEqualer(t *testing.T) {
+	assert := assert.New(t)
+	comparator := FromEqualer(Equal[string])
+
+	assert.Equal(0, comparator("a", "a"))
+	assert.NotEqual(0, comparator("a", "b"))
+	assert.NotEqual(0, comparator("b", "a"))
+}
@@ -0,0 +1,78 @@
+package comparator
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+)
+
+/*
+Error returned when sorting a container that has no comparator
+*/
+var ErrNoComparator = errors.New("the container has no comparator")
+
+// Comparators registered by type, used when a container is created without comparator
+var registry sync.Map
+
+func init() {
+	Register(IntComparator)
+	Register(Float32Comparator)
+	Register(Float64Comparator)
+	Register(StringComparator)
+	Register(BoolComparator)
+	Register(BytesComparator)
+	Register(DurationComparator)
+	Register(TimeComparator)
+}
+
+/*
+Register the default comparator of the type T. It is used by the containers
+that are decoded without comparator, e.g. with json.Unmarshal on a zero value.
+Registering a comparator for a type replaces the previous one
+*/
+func Register[T any](comparator Comparator[T]) {
+	registry.Store(reflect.TypeFor[T](), comparator)
+}
+
+/*
+Return the default comparator of the type T. If no comparator has been
+registered for this type, this function will return an error
+*/
+func Default[T any]() (Comparator[T], error) {
+	comparator, ok := registry.Load(reflect.TypeFor[T]())
+	if !ok {
+		return nil, errors.New("no comparator registered for type " + reflect.TypeFor[T]().String())
+	}
+
+	return comparator.(Comparator[T]), nil
+}
+
+/*
+Return the comparator if it is not nil, else the default comparator of the type T
+*/
+func Resolve[T any](comparator Comparator[T]) (Comparator[T], error) {
+	if comparator != nil {
+		return comparator, nil
+	}
+
+	return Default[T]()
+}
+
+/*
+Return the comparator if it is not nil, else the default comparator of the type T. If no
+comparator is registered for T, return a comparator only reporting the equality of the
+elements, like the ones created by FromEqualer: the elements are compared with == if T is
+comparable, else with reflect.DeepEqual. It is used by the operations relying on equality
+only (Contains, IndexOf, Remove), which must not report a present element as absent
+*/
+func ResolveEquality[T any](comparator Comparator[T]) Comparator[T] {
+	if resolved, err := Resolve(comparator); err == nil {
+		return resolved
+	}
+
+	if reflect.TypeFor[T]().Comparable() {
+		return FromEqualer(func(a, b T) bool { return any(a) == any(b) })
+	}
+
+	return FromEqualer(func(a, b T) bool { return reflect.DeepEqual(a, b) })
+}
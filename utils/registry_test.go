@@ -0,0 +1,70 @@
+package comparator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type point struct {
+	x, y int
+}
+
+func TestDefault(t *testing.T) {
+	assert := assert.New(t)
+
+	comparator, err := Default[int]()
+	assert.Nil(err)
+	assert.Equal(-1, comparator(1, 2))
+
+	comparator2, err := Default[string]()
+	assert.Nil(err)
+	assert.Equal(1, comparator2("b", "a"))
+
+	_, err = Default[point]()
+	assert.NotNil(err)
+}
+
+func TestRegister(t *testing.T) {
+	assert := assert.New(t)
+
+	Register(func(a, b point) int {
+		return IntComparator(a.x, b.x)
+	})
+
+	comparator, err := Default[point]()
+	assert.Nil(err)
+	assert.Equal(-1, comparator(point{1, 5}, point{2, 0}))
+}
+
+func TestResolve(t *testing.T) {
+	assert := assert.New(t)
+	reverse := func(a, b int) int { return IntComparator(b, a) }
+
+	comparator, err := Resolve[int](reverse)
+	assert.Nil(err)
+	assert.Equal(1, comparator(1, 2))
+
+	comparator, err = Resolve[int](nil)
+	assert.Nil(err)
+	assert.Equal(-1, comparator(1, 2))
+
+	_, err = Resolve[[]int](nil)
+	assert.NotNil(err)
+}
+
+func TestResolveEquality(t *testing.T) {
+	assert := assert.New(t)
+
+	// The registered comparator is kept
+	comparator := ResolveEquality[int](nil)
+	assert.Equal(-1, comparator(1, 2))
+
+	equal := ResolveEquality[struct{ x, y int }](nil)
+	assert.Equal(0, equal(struct{ x, y int }{1, 2}, struct{ x, y int }{1, 2}))
+	assert.NotEqual(0, equal(struct{ x, y int }{1, 2}, struct{ x, y int }{2, 1}))
+
+	deepEqual := ResolveEquality[[]int](nil)
+	assert.Equal(0, deepEqual([]int{1, 2}, []int{1, 2}))
+	assert.NotEqual(0, deepEqual([]int{1, 2}, []int{2, 1}))
+}